@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// anonymize returns processes stripped of anything that could identify the system they were
+// recorded on: they are renumbered 1..n in order of arrival, ties in workload order, arrivals are
+// shifted so the first is at 0, and bulk groups are dropped. With a scale other than 1 every
+// arrival, burst and deadline is also multiplied by it and rounded, keeping bursts at least 1 and
+// deadlines after arrival. Dependencies follow the renumbering.
+func anonymize(processes []Process, scale float64) []Process {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return processes[order[a]].ArrivalTime < processes[order[b]].ArrivalTime
+	})
+	renumbered := make(map[int64]int64, len(processes))
+	for k, i := range order {
+		renumbered[processes[i].ProcessID] = int64(k + 1)
+	}
+	var first int64
+	if len(order) > 0 {
+		first = processes[order[0]].ArrivalTime
+	}
+	scaled := func(t int64) int64 { return int64(math.Round(float64(t) * scale)) }
+
+	out := make([]Process, len(processes))
+	for k, i := range order {
+		p := processes[i]
+		q := Process{
+			ProcessID:     int64(k + 1),
+			ArrivalTime:   scaled(p.ArrivalTime - first),
+			BurstDuration: max(scaled(p.BurstDuration), 1),
+			Priority:      p.Priority,
+			Memory:        p.Memory,
+			Weight:        p.Weight,
+		}
+		if p.Deadline != 0 {
+			q.Deadline = max(scaled(p.Deadline-first), q.ArrivalTime+1)
+		}
+		for _, pid := range p.Depends {
+			q.Depends = append(q.Depends, renumbered[pid])
+		}
+		out[k] = q
+	}
+
+	return out
+}
+
+// writeWorkloadHeaderCSV writes processes as a workload with a header row, adding the deadline,
+// memory, weight and depends columns only when some process sets them.
+func writeWorkloadHeaderCSV(w io.Writer, processes []Process) error {
+	var deadline, memory, weight bool
+	for _, p := range processes {
+		deadline = deadline || p.Deadline != 0
+		memory = memory || p.Memory != 0
+		weight = weight || p.Weight != 0
+	}
+	depends := hasDependencies(processes)
+
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = crlfFiles
+	header := []string{"pid", "burst", "arrival", "priority"}
+	for _, c := range []struct {
+		name string
+		on   bool
+	}{{"deadline", deadline}, {"memory", memory}, {"weight", weight}, {"depends", depends}} {
+		if c.on {
+			header = append(header, c.name)
+		}
+	}
+	_ = cw.Write(header)
+	for _, p := range processes {
+		row := []string{
+			strconv.FormatInt(p.ProcessID, 10),
+			strconv.FormatInt(p.BurstDuration, 10),
+			strconv.FormatInt(p.ArrivalTime, 10),
+			strconv.FormatInt(p.Priority, 10),
+		}
+		if deadline {
+			row = append(row, strconv.FormatInt(p.Deadline, 10))
+		}
+		if memory {
+			row = append(row, strconv.FormatInt(p.Memory, 10))
+		}
+		if weight {
+			row = append(row, strconv.FormatInt(p.Weight, 10))
+		}
+		if depends {
+			pids := make([]string, len(p.Depends))
+			for i, pid := range p.Depends {
+				pids[i] = strconv.FormatInt(pid, 10)
+			}
+			row = append(row, strings.Join(pids, ";"))
+		}
+		_ = cw.Write(row)
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("%w: writing workload CSV", err)
+	}
+
+	return nil
+}
+
+// runAnonymize is the anonymize subcommand: it rewrites a workload, typically one recorded by
+// observe, so it can be shared as a teaching dataset. Only the scheduling columns are kept, so
+// commands, names, comments and any other column are dropped.
+func runAnonymize(args []string) error {
+	fs := flag.NewFlagSet("anonymize", flag.ExitOnError)
+	scale := fs.Float64("scale", 1, "`factor` to multiply every arrival, burst and deadline by")
+	delim := fs.String("delimiter", ",", "field `separator` of the input workload")
+	fs.BoolVar(&crlfFiles, "crlf", false, "end lines with \\r\\n for Windows tools")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return fmt.Errorf("%w: usage: anonymize [-scale factor] in.csv [out.csv]", ErrInvalidArgs)
+	}
+	if *scale <= 0 || math.IsInf(*scale, 0) || math.IsNaN(*scale) {
+		return fmt.Errorf("%w: -scale must be a positive number", ErrInvalidArgs)
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		return err
+	}
+	processes, err := loadWorkloadFile(fs.Arg(0), delimiter)
+	if err != nil {
+		return err
+	}
+	processes = anonymize(processes, *scale)
+
+	if fs.NArg() == 1 {
+		return writeWorkloadHeaderCSV(os.Stdout, processes)
+	}
+	w, closeOut, err := createOutput(fs.Arg(1), false)
+	if err != nil {
+		return fmt.Errorf("%w: writing workload", err)
+	}
+	if err := writeWorkloadHeaderCSV(w, processes); err != nil {
+		_ = closeOut()
+		return err
+	}
+
+	return closeOut()
+}
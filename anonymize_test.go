@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_anonymize(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 120, BurstDuration: 9, Priority: 20, Deadline: 140},
+		{ProcessID: 2, ArrivalTime: 100, BurstDuration: 3, Priority: 25, Group: 2},
+		{ProcessID: 3, ArrivalTime: 120, BurstDuration: 1, Depends: []int64{2, 1}},
+	}
+	tests := []struct {
+		name  string
+		scale float64
+		want  []Process
+	}{
+		{
+			name:  "renumbered by arrival",
+			scale: 1,
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 25},
+				{ProcessID: 2, ArrivalTime: 20, BurstDuration: 9, Priority: 20, Deadline: 40},
+				{ProcessID: 3, ArrivalTime: 20, BurstDuration: 1, Depends: []int64{1, 2}},
+			},
+		},
+		{
+			name:  "scaled",
+			scale: 0.1,
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1, Priority: 25},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1, Priority: 20, Deadline: 4},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Depends: []int64{1, 2}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := anonymize(processes, tt.scale); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("anonymize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeWorkloadHeaderCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2, Weight: 2},
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 5, Depends: []int64{1}},
+	}
+	var w bytes.Buffer
+	if err := writeWorkloadHeaderCSV(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	want := "pid,burst,arrival,priority,weight,depends\n1,3,0,2,2,\n2,5,4,0,0,1\n"
+	if w.String() != want {
+		t.Errorf("writeWorkloadHeaderCSV() = %q, want %q", w.String(), want)
+	}
+	got, err := loadProcesses(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, processes) {
+		t.Errorf("loadProcesses() = %+v, want %+v", got, processes)
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// tunable is an Options parameter autotune can search, over a fixed grid of values.
+	tunable struct {
+		name    string
+		values  []float64
+		applies func(a algorithm) bool
+		set     func(opts *Options, v float64)
+	}
+	// tuneResult is the best configuration the search found for one algorithm, next to the
+	// score of the defaults. Params is nil for an algorithm with nothing to tune.
+	tuneResult struct {
+		Name         string
+		Params       []tunable
+		Best         []float64
+		Score        float64
+		DefaultScore float64
+		Evaluations  int
+	}
+)
+
+// tunables are the parameters autotune knows. The simulator has no MLFQ or aging policy, so the
+// multilevel queue's foreground cutoff, the quantum of the quantum-based policies and the burst
+// predictor of sjf-predicted are all there is to search.
+var tunables = []tunable{
+	{
+		name:    "quantum",
+		values:  []float64{1, 2, 3, 4, 6, 8, 12, 16},
+		applies: func(a algorithm) bool { return a.quantum },
+		set:     func(opts *Options, v float64) { opts.Quantum = int64(v) },
+	},
+	{
+		name:    "foreground",
+		values:  []float64{1, 2, 3, 5, 10, 20, 50},
+		applies: func(a algorithm) bool { return a.name == "mlq" },
+		set:     func(opts *Options, v float64) { opts.Foreground = int64(v) },
+	},
+	{
+		name:    "alpha",
+		values:  []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1},
+		applies: func(a algorithm) bool { return a.name == "sjf-predicted" },
+		set:     func(opts *Options, v float64) { opts.Alpha = v },
+	},
+	{
+		name:    "initial",
+		values:  []float64{1, 2, 4, 8, 16, 32},
+		applies: func(a algorithm) bool { return a.name == "sjf-predicted" },
+		set:     func(opts *Options, v float64) { opts.InitialGuess = int64(v) },
+	},
+}
+
+// tuner scores configurations of one algorithm over a workload suite, remembering every score so
+// a search never runs a configuration twice.
+type tuner struct {
+	a         algorithm
+	params    []tunable
+	workloads [][]Process
+	objective weightedObjective
+	scores    map[string]float64
+	// err is the first run that failed, such as a plugin's invalid schedule; once it is set every
+	// configuration scores +Inf and the search's answer is discarded.
+	err error
+}
+
+func newTuner(a algorithm, workloads [][]Process, objective weightedObjective) *tuner {
+	t := &tuner{a: a, workloads: workloads, objective: objective, scores: make(map[string]float64)}
+	for _, p := range tunables {
+		if p.applies(a) {
+			t.params = append(t.params, p)
+		}
+	}
+
+	return t
+}
+
+// options returns the configuration at point, an index into each parameter's values.
+func (t *tuner) options(point []int) Options {
+	var opts Options
+	for i, p := range t.params {
+		p.set(&opts, p.values[point[i]])
+	}
+
+	return opts
+}
+
+// score is the objective averaged over the suite with the configuration at point.
+func (t *tuner) score(point []int) float64 {
+	key := fmt.Sprint(point)
+	if s, ok := t.scores[key]; ok {
+		return s
+	}
+	s := t.scoreOptions(t.options(point))
+	t.scores[key] = s
+
+	return s
+}
+
+func (t *tuner) scoreOptions(opts Options) float64 {
+	var total float64
+	for _, processes := range t.workloads {
+		if t.err != nil {
+			return math.Inf(1)
+		}
+		result, err := runAlgorithmChecked(t.a, io.Discard, processes, opts)
+		if err != nil {
+			t.err = err
+			return math.Inf(1)
+		}
+		total += t.objective.score(result)
+	}
+
+	return total / float64(len(t.workloads))
+}
+
+// grid tries every combination of values and returns the best, the first found on ties.
+func (t *tuner) grid() []int {
+	var best []int
+	point := make([]int, len(t.params))
+	for {
+		if best == nil || t.score(point) < t.score(best) {
+			best = append([]int(nil), point...)
+		}
+		// advance point like an odometer over the parameters' value indexes
+		i := 0
+		for ; i < len(point); i++ {
+			if point[i]++; point[i] < len(t.params[i].values) {
+				break
+			}
+			point[i] = 0
+		}
+		if i == len(point) {
+			return best
+		}
+	}
+}
+
+// hillClimb starts restarts times from a random point drawn from rng and moves to the best
+// neighbor, one grid step along one parameter, until none improves; it returns the best point
+// any climb reached.
+func (t *tuner) hillClimb(rng *rand.Rand, restarts int) []int {
+	var best []int
+	for r := 0; r < restarts; r++ {
+		point := make([]int, len(t.params))
+		for i, p := range t.params {
+			point[i] = rng.Intn(len(p.values))
+		}
+		for moved := true; moved; {
+			moved = false
+			next := point
+			for i := range point {
+				for _, step := range []int{-1, 1} {
+					v := point[i] + step
+					if v < 0 || v >= len(t.params[i].values) {
+						continue
+					}
+					neighbor := append([]int(nil), point...)
+					neighbor[i] = v
+					if t.score(neighbor) < t.score(next) {
+						next, moved = neighbor, true
+					}
+				}
+			}
+			point = next
+		}
+		if best == nil || t.score(point) < t.score(best) {
+			best = point
+		}
+	}
+
+	return best
+}
+
+// autotune searches each algorithm's parameters for the lowest objective over workloads, by grid
+// search or, when hill is set, seeded hill-climbing with restarts.
+func autotune(selected []algorithm, workloads [][]Process, objective weightedObjective, hill bool, rng *rand.Rand, restarts int) ([]tuneResult, error) {
+	results := make([]tuneResult, len(selected))
+	for i, a := range selected {
+		t := newTuner(a, workloads, objective)
+		results[i] = tuneResult{Name: a.name, DefaultScore: t.scoreOptions(Options{})}
+		if t.err != nil {
+			return nil, t.err
+		}
+		if len(t.params) == 0 {
+			results[i].Score = results[i].DefaultScore
+			continue
+		}
+		search := t.grid
+		if hill {
+			search = func() []int { return t.hillClimb(rng, restarts) }
+		}
+		best := search()
+		if t.err != nil {
+			return nil, t.err
+		}
+		results[i].Params = t.params
+		results[i].Score = t.score(best)
+		results[i].Evaluations = len(t.scores)
+		for j, p := range t.params {
+			results[i].Best = append(results[i].Best, p.values[best[j]])
+		}
+	}
+
+	return results, nil
+}
+
+// config formats the best configuration as name=value pairs.
+func (r tuneResult) config() string {
+	if len(r.Params) == 0 {
+		return "(nothing to tune)"
+	}
+	pairs := make([]string, len(r.Params))
+	for i, p := range r.Params {
+		pairs[i] = fmt.Sprintf("%s=%g", p.name, r.Best[i])
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+func outputAutotune(w io.Writer, objective weightedObjective, results []tuneResult) {
+	_, _ = fmt.Fprintf(w, "Best configurations for %s\n", objective)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Configuration", "Score", "Default score", "Evaluations"})
+	for _, r := range results {
+		table.Append([]string{r.Name, r.config(), fmt.Sprintf("%.2f", r.Score), fmt.Sprintf("%.2f", r.DefaultScore), fmt.Sprint(r.Evaluations)})
+	}
+	table.Render()
+}
+
+func outputPlainAutotune(w io.Writer, results []tuneResult) {
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "autotune: algorithm %s, configuration %s, score %.2f, default score %.2f, evaluations %d\n",
+			r.Name, r.config(), r.Score, r.DefaultScore, r.Evaluations)
+	}
+}
+
+// runAutotune is the autotune subcommand: it searches the tunable parameters of each selected
+// algorithm for the configuration that minimizes an objective averaged over workload files.
+func runAutotune(args []string) error {
+	fs := flag.NewFlagSet("autotune", flag.ExitOnError)
+	algo := fs.String("algo", "rr,mlq,wrr,drr,sjf-predicted", "comma-separated `names` of the algorithms to tune")
+	goal := fs.String("objective", "avgWait", "weighted `sum` of metrics to minimize, as for the main -objective flag")
+	search := fs.String("search", "grid", "search `strategy`: grid tries every combination, hill climbs from random starts")
+	seed := fs.Int64("seed", 0, "random `seed` for -search hill (default random, reported on stderr)")
+	restarts := fs.Int("restarts", 5, "number of random starting points for -search hill")
+	delim := fs.String("delimiter", ",", "field `separator` of the workload files")
+	plain := fs.Bool("plain", false, "print labeled key: value lines instead of a table")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("%w: usage: autotune [-algo names] [-objective sum] [-search grid|hill] workload.csv...", ErrInvalidArgs)
+	}
+	if *search != "grid" && *search != "hill" {
+		return fmt.Errorf("%w: unknown -search %q, want grid or hill", ErrInvalidArgs, *search)
+	}
+	if *restarts < 1 {
+		return fmt.Errorf("%w: -restarts must be at least 1", ErrInvalidArgs)
+	}
+	selected, err := selectAlgorithms(strings.Split(*algo, ","))
+	if err != nil {
+		return err
+	}
+	objective, err := parseObjective(*goal)
+	if err != nil {
+		return err
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		return err
+	}
+	paths, err := expandWorkloadArgs(fs.Args())
+	if err != nil {
+		return err
+	}
+	workloads := make([][]Process, len(paths))
+	for i, path := range paths {
+		if workloads[i], err = loadWorkloadFile(path, delimiter); err != nil {
+			return fmt.Errorf("%w: in %s", err, path)
+		}
+	}
+	if *search == "hill" && *seed == 0 {
+		*seed = time.Now().UnixNano()
+		_, _ = fmt.Fprintf(os.Stderr, "seed: %d\n", *seed)
+	}
+
+	results, err := autotune(selected, workloads, objective, *search == "hill", rand.New(rand.NewSource(*seed)), *restarts)
+	if err != nil {
+		return err
+	}
+	if *plain {
+		outputPlainAutotune(os.Stdout, results)
+	} else {
+		outputAutotune(os.Stdout, objective, results)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_autotune(t *testing.T) {
+	t.Parallel()
+	workloads := [][]Process{
+		{{ProcessID: 1, BurstDuration: 9}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 2}},
+		{{ProcessID: 1, BurstDuration: 6}, {ProcessID: 2, BurstDuration: 6}},
+	}
+	objective := weightedObjective{{1, "avgWait"}}
+	rr := *findAlgorithm("rr")
+	fcfs := *findAlgorithm("fcfs")
+
+	grid, err := autotune([]algorithm{rr, fcfs}, workloads, objective, false, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// brute force over the same quanta for the expected optimum
+	want := -1.0
+	for _, q := range tunables[0].values {
+		var total float64
+		for _, processes := range workloads {
+			total += runAlgorithm(rr, io.Discard, processes, Options{Quantum: int64(q)}).AveWait
+		}
+		if s := total / 2; want < 0 || s < want {
+			want = s
+		}
+	}
+	if grid[0].Score != want || grid[0].Evaluations != len(tunables[0].values) {
+		t.Errorf("grid rr = %+v, want score %g after %d evaluations", grid[0], want, len(tunables[0].values))
+	}
+	if grid[1].Params != nil || grid[1].Score != grid[1].DefaultScore || grid[1].config() != "(nothing to tune)" {
+		t.Errorf("grid fcfs = %+v, want nothing tuned", grid[1])
+	}
+
+	a, err := autotune([]algorithm{rr}, workloads, objective, true, rand.New(rand.NewSource(7)), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := autotune([]algorithm{rr}, workloads, objective, true, rand.New(rand.NewSource(7)), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a[0].Best, b[0].Best) || a[0].Score != b[0].Score {
+		t.Errorf("hill climbing with one seed gave %+v and %+v", a[0], b[0])
+	}
+	if a[0].Score < want {
+		t.Errorf("hill climbing scored %g, below the grid optimum %g", a[0].Score, want)
+	}
+}
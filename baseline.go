@@ -0,0 +1,291 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// defaultBaselineStore is where -baseline-version reads, and -save-baseline writes, the results
+// of earlier releases.
+const defaultBaselineStore = "baselines"
+
+// baselineDownloadTimeout bounds fetching a baseline from a store that is a URL.
+const baselineDownloadTimeout = 30 * time.Second
+
+// maxBaselineDownload bounds the size of a baseline fetched from a URL, so a wrong or hostile
+// store cannot fill memory. Saved results of the largest workloads stay well under it.
+const maxBaselineDownload = 64 << 20
+
+// loadResults reads saved results: JSON ({"results": [...]}, as -format json writes them) when
+// the file ends in .json, and the protobuf ResultSet of result.proto otherwise. Either may be
+// gzipped by ending its name in .gz.
+func loadResults(path string) ([]Result, error) {
+	r, closeIn, err := openInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading results", err)
+	}
+	in, err := io.ReadAll(r)
+	_ = closeIn()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading results", err)
+	}
+
+	return decodeResults(path, in)
+}
+
+// decodeResults decodes results read from path, as loadResults does.
+func decodeResults(path string, in []byte) ([]Result, error) {
+	if isJSONResults(path) {
+		var response simulateResponse
+		if err := json.Unmarshal(in, &response); err != nil {
+			return nil, fmt.Errorf("%w: %w: decoding JSON results", ErrInvalidArgs, err)
+		}
+		return response.Results, nil
+	}
+	results, err := unmarshalResults(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: decoding protobuf results", ErrInvalidArgs, err)
+	}
+
+	return results, nil
+}
+
+// isJSONResults reports whether a results file is JSON rather than protobuf, by its extension.
+func isJSONResults(path string) bool {
+	return strings.EqualFold(filepath.Ext(strings.TrimSuffix(path, gzipExt)), ".json")
+}
+
+// isURL reports whether a baseline store is an http(s) URL rather than a directory.
+func isURL(store string) bool {
+	return strings.HasPrefix(store, "http://") || strings.HasPrefix(store, "https://")
+}
+
+// baselinePath locates the baseline of release version for workload in store, a directory or an
+// http(s) URL: <store>/<version>/<workload's name without extensions>.json, gzipped if
+// compressed is set. Baselines are keyed by name, so the same workload file should be used
+// in every release.
+func baselinePath(store, version, workload string, compressed bool) (string, error) {
+	if version == "" || version == "." || version == ".." || strings.ContainsAny(version, `/\`) {
+		return "", fmt.Errorf("%w: invalid baseline version %q", ErrInvalidArgs, version)
+	}
+	if workload == stdinName {
+		return "", fmt.Errorf("%w: baselines are stored by workload name, so the workload must come from a file", ErrInvalidArgs)
+	}
+	name := strings.TrimSuffix(filepath.Base(workload), gzipExt)
+	name = strings.TrimSuffix(name, filepath.Ext(name)) + ".json"
+	if compressed {
+		name += gzipExt
+	}
+	if isURL(store) {
+		return strings.TrimSuffix(store, "/") + "/" + url.PathEscape(version) + "/" + url.PathEscape(name), nil
+	}
+
+	return filepath.Join(store, version, name), nil
+}
+
+// loadBaselineVersion returns the results release version stored in store for workload and
+// where they came from. From a directory, a gzipped baseline is read when there is no plain one;
+// from a URL, the plain one is downloaded.
+func loadBaselineVersion(store, version, workload string) ([]Result, string, error) {
+	path, err := baselinePath(store, version, workload, false)
+	if err != nil {
+		return nil, "", err
+	}
+	if isURL(store) {
+		results, err := downloadResults(path, maxBaselineDownload)
+		return results, path, err
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if gz, _ := baselinePath(store, version, workload, true); fileExists(gz) {
+			path = gz
+		}
+	}
+	results, err := loadResults(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", fmt.Errorf("%w: no baseline of version %s for %s in %s", fs.ErrNotExist, version, filepath.Base(workload), store)
+	}
+
+	return results, path, err
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// downloadResults fetches JSON results from an http(s) URL, failing when the body is over limit
+// bytes.
+func downloadResults(rawURL string, limit int64) ([]Result, error) {
+	client := &http.Client{Timeout: baselineDownloadTimeout}
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: downloading baseline", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: downloading baseline %s: %s", ErrInvalidArgs, rawURL, resp.Status)
+	}
+	// one byte past the limit tells a body over it from one exactly at it
+	in, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: downloading baseline", err)
+	}
+	if int64(len(in)) > limit {
+		return nil, fmt.Errorf("%w: baseline %s is over the %d byte download limit", ErrInvalidArgs, rawURL, limit)
+	}
+
+	return decodeResults(rawURL, in)
+}
+
+// saveBaseline stores results in the directory store as release version's baseline for
+// workload, as -format json writes them, and returns the file written.
+func saveBaseline(store, version, workload string, results []Result) (string, error) {
+	if isURL(store) {
+		return "", fmt.Errorf("%w: baselines can only be saved to a directory, not %s", ErrInvalidArgs, store)
+	}
+	path, err := baselinePath(store, version, workload, false)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("%w: creating baseline directory", err)
+	}
+	w, closeFn, err := createOutput(path, false)
+	if err != nil {
+		return "", err
+	}
+	if err := json.NewEncoder(w).Encode(simulateResponse{Results: results}); err != nil {
+		_ = closeFn()
+		return "", fmt.Errorf("%w: writing baseline", err)
+	}
+
+	return path, closeFn()
+}
+
+// baselineDiff is how one algorithm's result differs from the same algorithm's in a baseline.
+// Diverged is the first tick at which the two schedules differ, or -1 if they are identical.
+type baselineDiff struct {
+	Name              string
+	InBaseline, InRun bool
+	Diverged          int64
+	Wait              float64
+	Turnaround        float64
+	Response          float64
+	Switches          int
+}
+
+// changed reports whether the algorithm behaves differently from the baseline.
+func (d baselineDiff) changed() bool {
+	return !d.InBaseline || !d.InRun || d.Diverged >= 0 || d.Wait != 0 || d.Turnaround != 0 || d.Response != 0 || d.Switches != 0
+}
+
+// diffBaseline matches results to baseline by algorithm name. The differences are the run's
+// metrics minus the baseline's; algorithms only one side ran come last, in baseline order.
+func diffBaseline(baseline, results []Result) []baselineDiff {
+	old := make(map[string]Result, len(baseline))
+	for _, r := range baseline {
+		old[r.Name] = r
+	}
+	ran := make(map[string]bool, len(results))
+	var diffs []baselineDiff
+	for _, r := range results {
+		ran[r.Name] = true
+		b, ok := old[r.Name]
+		if !ok {
+			diffs = append(diffs, baselineDiff{Name: r.Name, InRun: true, Diverged: -1})
+			continue
+		}
+		diffs = append(diffs, baselineDiff{
+			Name:       r.Name,
+			InBaseline: true,
+			InRun:      true,
+			Diverged:   divergence(b.Gantt, r.Gantt),
+			Wait:       r.AveWait - b.AveWait,
+			Turnaround: r.AveTurnaround - b.AveTurnaround,
+			Response:   r.AveResponse - b.AveResponse,
+			Switches:   r.ContextSwitches - b.ContextSwitches,
+		})
+	}
+	for _, b := range baseline {
+		if !ran[b.Name] {
+			diffs = append(diffs, baselineDiff{Name: b.Name, InBaseline: true, Diverged: -1})
+		}
+	}
+
+	return diffs
+}
+
+// divergence returns the first tick at which two gantt charts run different processes, or -1 if
+// they match slice for slice. Why a slice ended is not compared.
+func divergence(a, b []TimeSlice) int64 {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a):
+			return b[i].Start
+		case i >= len(b):
+			return a[i].Start
+		case a[i].PID != b[i].PID || a[i].Start != b[i].Start:
+			return min(a[i].Start, b[i].Start)
+		case a[i].Stop != b[i].Stop:
+			return min(a[i].Stop, b[i].Stop)
+		}
+	}
+
+	return -1
+}
+
+// status sums up a baselineDiff in a word or two.
+func (d baselineDiff) status() string {
+	switch {
+	case !d.InBaseline:
+		return "new"
+	case !d.InRun:
+		return "not run"
+	case d.changed():
+		return "changed"
+	default:
+		return "same"
+	}
+}
+
+func outputBaselineDiff(w io.Writer, path string, diffs []baselineDiff) {
+	_, _ = fmt.Fprintf(w, "Changes since baseline %s\n", path)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Status", "Schedule diverges at", consoleText("Δ Avg wait"), consoleText("Δ Avg turnaround"), consoleText("Δ Avg response"), consoleText("Δ Switches")})
+	for _, d := range diffs {
+		row := []string{d.Name, d.status(), "", "", "", "", ""}
+		if d.InBaseline && d.InRun {
+			if d.Diverged >= 0 {
+				row[2] = fmt.Sprint(d.Diverged)
+			}
+			row[3] = fmt.Sprintf("%+.2f", d.Wait)
+			row[4] = fmt.Sprintf("%+.2f", d.Turnaround)
+			row[5] = fmt.Sprintf("%+.2f", d.Response)
+			row[6] = fmt.Sprintf("%+d", d.Switches)
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func outputPlainBaselineDiff(w io.Writer, diffs []baselineDiff) {
+	for _, d := range diffs {
+		if !d.InBaseline || !d.InRun {
+			_, _ = fmt.Fprintf(w, "baseline: algorithm %s, status %s\n", d.Name, d.status())
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "baseline: algorithm %s, status %s, diverges at %d, wait %+.2f, turnaround %+.2f, response %+.2f, switches %+d\n",
+			d.Name, d.status(), d.Diverged, d.Wait, d.Turnaround, d.Response, d.Switches)
+	}
+}
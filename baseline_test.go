@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_divergence(t *testing.T) {
+	t.Parallel()
+	base := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}}
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int64
+	}{
+		{name: "same", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4, Reason: "completed"}, {PID: 2, Start: 4, Stop: 6}}, want: -1},
+		{name: "other process", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 3, Start: 4, Stop: 6}}, want: 4},
+		{name: "shorter slice", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 6}}, want: 2},
+		{name: "extra slice", gantt: append(base[:2:2], TimeSlice{PID: 3, Start: 6, Stop: 7}), want: 6},
+		{name: "missing slice", gantt: base[:1], want: 4},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := divergence(base, tt.gantt); got != tt.want {
+				t.Errorf("divergence() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_diffBaseline(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}}
+	baseline := []Result{
+		{Name: "fcfs", Gantt: gantt, AveWait: 2, AveTurnaround: 5},
+		{Name: "rr", Gantt: gantt, AveWait: 2, AveTurnaround: 5, ContextSwitches: 1},
+		{Name: "old", Gantt: gantt},
+	}
+	results := []Result{
+		{Name: "fcfs", Gantt: gantt, AveWait: 2, AveTurnaround: 5},
+		{Name: "rr", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}}, AveWait: 1.5, AveTurnaround: 4.5, ContextSwitches: 2},
+		{Name: "new", Gantt: gantt},
+	}
+	want := []baselineDiff{
+		{Name: "fcfs", InBaseline: true, InRun: true, Diverged: -1},
+		{Name: "rr", InBaseline: true, InRun: true, Diverged: 2, Wait: -0.5, Turnaround: -0.5, Switches: 1},
+		{Name: "new", InRun: true, Diverged: -1},
+		{Name: "old", InBaseline: true, Diverged: -1},
+	}
+	got := diffBaseline(baseline, results)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("diffBaseline() = %+v, want %+v", got, want)
+	}
+	var statuses []string
+	for _, d := range got {
+		statuses = append(statuses, d.status())
+	}
+	if want := []string{"same", "changed", "new", "not run"}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("status() = %v, want %v", statuses, want)
+	}
+}
+
+func Test_baselinePath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		store      string
+		version    string
+		workload   string
+		compressed bool
+		want       string
+		wantErr    bool
+	}{
+		{name: "directory", store: "baselines", version: "v1.4.0", workload: "labs/week3.csv", want: filepath.Join("baselines", "v1.4.0", "week3.json")},
+		{name: "gzipped workload", store: "baselines", version: "v1.4.0", workload: "week3.csv.gz", want: filepath.Join("baselines", "v1.4.0", "week3.json")},
+		{name: "compressed", store: "b", version: "2024", workload: "w.csv", compressed: true, want: filepath.Join("b", "2024", "w.json.gz")},
+		{name: "URL", store: "https://example.edu/sched/", version: "v1 rc", workload: "w.csv", want: "https://example.edu/sched/v1%20rc/w.json"},
+		{name: "version with a separator", store: "b", version: "../x", workload: "w.csv", wantErr: true},
+		{name: "stdin", store: "b", version: "v1", workload: stdinName, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := baselinePath(tt.store, tt.version, tt.workload, tt.compressed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("baselinePath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("baselinePath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_saveBaseline(t *testing.T) {
+	t.Parallel()
+	results := []Result{{Name: "fcfs", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}}, AveWait: 2, AveTurnaround: 5}}
+	store := t.TempDir()
+	path, err := saveBaseline(store, "v1.4.0", "week3.csv", results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(store, "v1.4.0", "week3.json"); path != want {
+		t.Errorf("saveBaseline() wrote %s, want %s", path, want)
+	}
+
+	got, from, err := loadBaselineVersion(store, "v1.4.0", "other/dir/week3.csv")
+	if err != nil || from != path || !reflect.DeepEqual(got, results) {
+		t.Errorf("loadBaselineVersion() = %+v from %s, %v, want %+v from %s", got, from, err, results, path)
+	}
+	if _, _, err := loadBaselineVersion(store, "v1.3.0", "week3.csv"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("loadBaselineVersion() of a missing version error = %v, want %v", err, fs.ErrNotExist)
+	}
+
+	// the same store served over HTTP is downloaded from
+	srv := httptest.NewServer(http.FileServer(http.Dir(store)))
+	defer srv.Close()
+	if got, _, err := loadBaselineVersion(srv.URL, "v1.4.0", "week3.csv"); err != nil || !reflect.DeepEqual(got, results) {
+		t.Errorf("loadBaselineVersion() over HTTP = %+v, %v, want %+v", got, err, results)
+	}
+	if _, _, err := loadBaselineVersion(srv.URL, "v1.3.0", "week3.csv"); err == nil {
+		t.Error("loadBaselineVersion() over HTTP of a missing version succeeded")
+	}
+}
+
+func Test_downloadResults_limit(t *testing.T) {
+	t.Parallel()
+	body := `{"results": [{"name": "fcfs"}]}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, body)
+	}))
+	defer srv.Close()
+	rawURL := srv.URL + "/week3.json"
+
+	if got, err := downloadResults(rawURL, int64(len(body))); err != nil || len(got) != 1 || got[0].Name != "fcfs" {
+		t.Errorf("downloadResults() at the limit = %+v, %v, want the fcfs result", got, err)
+	}
+	if _, err := downloadResults(rawURL, int64(len(body))-1); !errors.Is(err, ErrInvalidArgs) || !strings.Contains(err.Error(), "download limit") {
+		t.Errorf("downloadResults() over the limit error = %v, want %v naming the download limit", err, ErrInvalidArgs)
+	}
+}
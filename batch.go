@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// batchReport is one workload file's outcome in a batch run; a file that fails to load keeps
+	// its error and has no results.
+	batchReport struct {
+		File    string   `json:"file"`
+		Results []Result `json:"results,omitempty"`
+		Error   string   `json:"error,omitempty"`
+	}
+	// batchAggregate averages one algorithm's per-file averages, weighting every file equally.
+	batchAggregate struct {
+		Name          string  `json:"name"`
+		Files         int     `json:"files"`
+		AveWait       float64 `json:"aveWait"`
+		AveTurnaround float64 `json:"aveTurnaround"`
+		AveResponse   float64 `json:"aveResponse"`
+	}
+	batchResponse struct {
+		Files     []batchReport    `json:"files"`
+		Aggregate []batchAggregate `json:"aggregate"`
+	}
+)
+
+// expandWorkloadArgs expands the glob patterns among args, so quoted patterns work where the shell
+// does not expand them; a pattern matching nothing is an error.
+func expandWorkloadArgs(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: workload pattern %q", ErrInvalidArgs, err, arg)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w: workload pattern %q matches no files", ErrInvalidArgs, arg)
+		}
+		paths = append(paths, matches...)
+	}
+
+	return paths, nil
+}
+
+// runBatch runs selected over every workload file, writing a labeled report per file and then an
+// aggregate summary; quiet leaves each file with only its comparison. A file that cannot be loaded
+// is reported and skipped so one bad submission does not stop the rest; only a resource limit ends
+// the batch early. With strict, a file using features a selected algorithm ignores counts as one
+// that cannot be loaded. A non-empty htmlDir also gets the HTML reports of every file and an index.
+func runBatch(w io.Writer, paths []string, delimiter rune, selected []algorithm, opts Options, guard *resourceGuard, jobs int, format string, plain, quiet, strict bool, htmlDir string) error {
+	var reports []batchReport
+	for _, path := range paths {
+		report, err := runBatchFile(w, path, delimiter, selected, opts, guard, jobs, format, plain, quiet, strict)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+	}
+	aggregate := aggregateBatch(selected, reports)
+	if htmlDir != "" {
+		workloads := make([]htmlWorkload, len(reports))
+		for i, r := range reports {
+			workloads[i] = htmlWorkload{Name: r.File, Results: r.Results, Error: r.Error}
+		}
+		if err := writeHTMLReports(htmlDir, workloads); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case format == "json":
+		if err := json.NewEncoder(w).Encode(batchResponse{Files: reports, Aggregate: aggregate}); err != nil {
+			return fmt.Errorf("%w: writing JSON results", err)
+		}
+	case plain:
+		outputPlainBatchSummary(w, reports, aggregate)
+	default:
+		outputBatchSummary(w, reports, aggregate)
+	}
+
+	return nil
+}
+
+// runBatchFile loads and schedules one file of a batch.
+func runBatchFile(w io.Writer, path string, delimiter rune, selected []algorithm, opts Options, guard *resourceGuard, jobs int, format string, plain, quiet, strict bool) (batchReport, error) {
+	report := batchReport{File: path}
+	if format != "json" {
+		_, _ = fmt.Fprintf(w, "==> %s <==\n", path)
+	}
+	processes, err := loadWorkloadFile(path, delimiter)
+	if err == nil && strict {
+		err = checkFeatures(selected, processes)
+	}
+	if err != nil {
+		report.Error = err.Error()
+		if format != "json" {
+			_, _ = fmt.Fprintf(w, "error: %v\n\n", err)
+		}
+		return report, nil
+	}
+
+	term, _ := w.(*os.File)
+	for _, run := range runConcurrently(selected, processes, opts, guard, jobs, format == "json" || plain || quiet, term) {
+		if run.err != nil {
+			return report, fmt.Errorf("%w: in %s", run.err, path)
+		}
+		if run.report != nil {
+			if _, err := run.report.WriteTo(w); err != nil {
+				return report, fmt.Errorf("%w: writing report", err)
+			}
+		}
+		result := run.result
+		if plain && !quiet {
+			outputPlain(w, result)
+		}
+		report.Results = append(report.Results, result)
+	}
+	if format != "json" {
+		outputFeatureWarnings(w, report.Results)
+	}
+	switch {
+	case format == "json":
+	case plain:
+		outputPlainComparison(w, report.Results)
+		_, _ = fmt.Fprintln(w)
+	default:
+		outputComparison(w, report.Results)
+		_, _ = fmt.Fprintln(w)
+	}
+
+	return report, nil
+}
+
+// loadWorkloadFile opens and parses one workload file, gzipped if its name ends in .gz.
+func loadWorkloadFile(path string, delimiter rune) ([]Process, error) {
+	r, closeIn, err := openInput(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error opening scheduling file", err)
+	}
+	defer func() { _ = closeIn() }()
+
+	return loadProcessesDelimited(r, delimiter)
+}
+
+// aggregateBatch averages each algorithm's results over the files that loaded.
+func aggregateBatch(selected []algorithm, reports []batchReport) []batchAggregate {
+	aggregate := make([]batchAggregate, len(selected))
+	for i, a := range selected {
+		aggregate[i].Name = a.name
+		for _, report := range reports {
+			if i >= len(report.Results) {
+				continue
+			}
+			r := report.Results[i]
+			aggregate[i].Files++
+			aggregate[i].AveWait += r.AveWait
+			aggregate[i].AveTurnaround += r.AveTurnaround
+			aggregate[i].AveResponse += r.AveResponse
+		}
+		if n := float64(aggregate[i].Files); n > 0 {
+			aggregate[i].AveWait /= n
+			aggregate[i].AveTurnaround /= n
+			aggregate[i].AveResponse /= n
+		}
+	}
+
+	return aggregate
+}
+
+// failedFiles counts the reports whose workload could not be loaded.
+func failedFiles(reports []batchReport) int {
+	var failed int
+	for _, r := range reports {
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// outputBatchSummary prints the averages of every algorithm across the batch.
+func outputBatchSummary(w io.Writer, reports []batchReport, aggregate []batchAggregate) {
+	_, _ = fmt.Fprintf(w, "Batch summary (%d files, %d failed)\n", len(reports), failedFiles(reports))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Files", "Mean avg wait", "Mean avg turnaround", "Mean avg response"})
+	for _, a := range aggregate {
+		table.Append([]string{
+			a.Name,
+			fmt.Sprint(a.Files),
+			fmt.Sprintf("%.2f", a.AveWait),
+			fmt.Sprintf("%.2f", a.AveTurnaround),
+			fmt.Sprintf("%.2f", a.AveResponse),
+		})
+	}
+	table.Render()
+}
+
+// outputPlainBatchSummary is the -plain form of outputBatchSummary.
+func outputPlainBatchSummary(w io.Writer, reports []batchReport, aggregate []batchAggregate) {
+	_, _ = fmt.Fprintf(w, "batch: files %d, failed %d\n", len(reports), failedFiles(reports))
+	for _, a := range aggregate {
+		_, _ = fmt.Fprintf(w, "aggregate: %s, files %d, mean average wait %.2f, mean average turnaround %.2f, mean average response %.2f\n",
+			a.Name, a.Files, a.AveWait, a.AveTurnaround, a.AveResponse)
+	}
+}
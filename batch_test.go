@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_expandWorkloadArgs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for _, name := range []string{"a.csv", "b.csv", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr error
+	}{
+		{name: "plain names", args: []string{"x.csv", "-"}, want: []string{"x.csv", "-"}},
+		{name: "pattern", args: []string{filepath.Join(dir, "*.csv")}, want: []string{filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")}},
+		{name: "no match", args: []string{filepath.Join(dir, "*.json")}, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := expandWorkloadArgs(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expandWorkloadArgs() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandWorkloadArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runBatch(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.csv":   "1,4,0\n2,2,0\n",
+		"b.csv":   "1,2,0\n2,6,0\n",
+		"bad.csv": "1,x,0\n",
+	}
+	var paths []string
+	for _, name := range []string{"a.csv", "b.csv", "bad.csv"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(files[name]), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	selected, err := selectAlgorithms([]string{"fcfs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runBatch(&out, paths, ',', selected, Options{}, newResourceGuard(0, 0), 2, "text", true, false, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"==> " + paths[2] + " <==\nerror: line 1, column 2 (burst)",
+		"batch: files 3, failed 1\n",
+		// fcfs waits 2 in a.csv and 1 in b.csv
+		"aggregate: fcfs, files 2, mean average wait 1.50, mean average turnaround 5.00, mean average response 1.50\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runBatch() output lacks %q:\n%s", want, out.String())
+		}
+	}
+
+	var quiet bytes.Buffer
+	if err := runBatch(&quiet, paths, ',', selected, Options{}, newResourceGuard(0, 0), 2, "text", true, true, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(quiet.String(), "slice:") || !strings.Contains(quiet.String(), "batch: files 3, failed 1\n") {
+		t.Errorf("runBatch() quiet output has per-process detail or lacks the summary:\n%s", quiet.String())
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// groupSummary averages the copies of one bulk row.
+type groupSummary struct {
+	group                      int64
+	copies                     int
+	wait, turnaround, response float64
+}
+
+// groupSummaries averages each group's per-process metrics, in order of first appearance.
+// Processes outside any bulk row form a group of their own, keyed by PID.
+func groupSummaries(processes []Process, gantt []TimeSlice) []groupSummary {
+	times := processTimesFromGantt(processes, gantt)
+	index := make(map[int64]int)
+	var groups []groupSummary
+	for i, p := range processes {
+		key := p.Group
+		if key == 0 {
+			key = p.ProcessID
+		}
+		j, ok := index[key]
+		if !ok {
+			j = len(groups)
+			index[key] = j
+			groups = append(groups, groupSummary{group: key})
+		}
+		g := &groups[j]
+		g.copies++
+		g.wait += times.wait[i]
+		g.turnaround += times.turnaround[i]
+		g.response += times.response[i]
+	}
+	for i := range groups {
+		n := float64(groups[i].copies)
+		groups[i].wait /= n
+		groups[i].turnaround /= n
+		groups[i].response /= n
+	}
+
+	return groups
+}
+
+// outputGroups prints each algorithm's averages per bulk-arrival group, so homogeneous copies read
+// as one row instead of k.
+func outputGroups(w io.Writer, processes []Process, results []Result) {
+	_, _ = fmt.Fprintln(w, "Groups")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Group", "Copies", "Avg wait", "Avg turnaround", "Avg response"})
+	for _, r := range results {
+		for _, g := range groupSummaries(processes, r.Gantt) {
+			table.Append([]string{
+				r.Name,
+				fmt.Sprint(g.group),
+				fmt.Sprint(g.copies),
+				fmt.Sprintf("%.2f", g.wait),
+				fmt.Sprintf("%.2f", g.turnaround),
+				fmt.Sprintf("%.2f", g.response),
+			})
+		}
+	}
+	table.Render()
+}
+
+// outputPlainGroups is the -plain form of outputGroups.
+func outputPlainGroups(w io.Writer, processes []Process, results []Result) {
+	for _, r := range results {
+		for _, g := range groupSummaries(processes, r.Gantt) {
+			_, _ = fmt.Fprintf(w, "group: %s group %d, copies %d, average wait %.2f, average turnaround %.2f, average response %.2f\n",
+				r.Name, g.group, g.copies, g.wait, g.turnaround, g.response)
+		}
+	}
+}
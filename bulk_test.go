@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadProcesses_bulk(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
+		want    []Process
+		wantErr error
+	}{
+		{
+			name: "positional count",
+			in:   "7,2,0,1,3\n9,4,1,0,1\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 2, Priority: 1, Group: 7},
+				{ProcessID: 2, BurstDuration: 2, Priority: 1, Group: 7},
+				{ProcessID: 3, BurstDuration: 2, Priority: 1, Group: 7},
+				{ProcessID: 4, BurstDuration: 4, ArrivalTime: 1, Group: 9},
+			},
+		},
+		{
+			name: "header without pid",
+			in:   "burst,arrival,count\n2,0,2\n5,3,1\n",
+			want: []Process{
+				{ProcessID: 1, BurstDuration: 2, Group: 1},
+				{ProcessID: 2, BurstDuration: 2, Group: 1},
+				{ProcessID: 3, BurstDuration: 5, ArrivalTime: 3, Group: 2},
+			},
+		},
+		{name: "zero count", in: "1,2,0,0,0\n", wantErr: errBadCount},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadProcesses(strings.NewReader(tt.in))
+			if !reflect.DeepEqual(got, tt.want) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("loadProcesses() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_groupSummaries(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2, Group: 7},
+		{ProcessID: 2, BurstDuration: 2, Group: 7},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}
+	gantt := []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 5}}
+	want := []groupSummary{
+		{group: 7, copies: 2, wait: 1, turnaround: 3, response: 1},
+		{group: 3, copies: 1, wait: 3, turnaround: 4, response: 3},
+	}
+	if got := groupSummaries(processes, gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupSummaries() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// capabilityNote names what no registered policy supports, so combinations that need it are not
+// tried.
+const capabilityNote = "No policy schedules by deadline (tardiness is reported for all), and the simulator models one CPU without I/O."
+
+// capabilities lists which features a, as registered, supports, in capabilityHeader order.
+// Every policy holds processes back until their dependencies complete.
+func capabilities(a algorithm) []bool {
+	return []bool{a.preemptive, a.priorities, a.weights, a.quantum, a.nonWorkConserving, true}
+}
+
+var capabilityHeader = []string{"Preemptive", "Priorities", "Weights", "Quantum", "Idling", "Dependencies"}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// outputCapabilities prints a feature matrix of algorithms, one row per algorithm.
+func outputCapabilities(w io.Writer, algorithms []algorithm) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append([]string{"Algorithm", "Title"}, capabilityHeader...))
+	for _, a := range algorithms {
+		row := []string{a.name, a.title}
+		for _, c := range capabilities(a) {
+			row = append(row, yesNo(c))
+		}
+		table.Append(row)
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, capabilityNote)
+}
+
+func outputPlainCapabilities(w io.Writer, algorithms []algorithm) {
+	for _, a := range algorithms {
+		_, _ = fmt.Fprintf(w, "algorithm: %s", a.name)
+		for i, c := range capabilities(a) {
+			_, _ = fmt.Fprintf(w, ", %s %s", strings.ToLower(capabilityHeader[i]), yesNo(c))
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputPlainCapabilities(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputPlainCapabilities(&w, []algorithm{
+		{name: "fcfs"},
+		{name: "mlq", quantum: true, preemptive: true, priorities: true},
+		{name: "drr", quantum: true, weights: true},
+	})
+	want := "algorithm: fcfs, preemptive no, priorities no, weights no, quantum no, idling no, dependencies yes\n" +
+		"algorithm: mlq, preemptive yes, priorities yes, weights no, quantum yes, idling no, dependencies yes\n" +
+		"algorithm: drr, preemptive no, priorities no, weights yes, quantum yes, idling no, dependencies yes\n"
+	if w.String() != want {
+		t.Errorf("outputPlainCapabilities() = %q, want %q", w.String(), want)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tieBreakers order two ready processes by one criterion: negative when a should run before b,
+// zero when the criterion cannot tell them apart.
+var tieBreakers = map[string]func(a, b chainCandidate) int64{
+	"priority": func(a, b chainCandidate) int64 { return a.Priority - b.Priority },
+	"sjf":      func(a, b chainCandidate) int64 { return a.BurstDuration - b.BurstDuration },
+	"ljf":      func(a, b chainCandidate) int64 { return b.BurstDuration - a.BurstDuration },
+	"srtf":     func(a, b chainCandidate) int64 { return a.remaining - b.remaining },
+	"fifo":     func(a, b chainCandidate) int64 { return a.ArrivalTime - b.ArrivalTime },
+	"lifo":     func(a, b chainCandidate) int64 { return b.ArrivalTime - a.ArrivalTime },
+	"pid":      func(a, b chainCandidate) int64 { return a.ProcessID - b.ProcessID },
+}
+
+// chainCandidate is a ready process as a tie-breaker sees it.
+type chainCandidate struct {
+	Process
+	remaining int64
+}
+
+// chainPolicy is a scheduling policy composed from an ordered list of tie-breakers.
+type chainPolicy struct {
+	keys       []string
+	preemptive bool
+}
+
+// parseChain reads a spec such as "priority,then=sjf,then=fifo": each element names a tie-breaker
+// consulted only when the ones before it tie, and "preemptive" re-decides as processes arrive instead of
+// running the chosen process to completion. Remaining ties go to workload order.
+func parseChain(spec string) (chainPolicy, error) {
+	var policy chainPolicy
+	for _, key := range strings.Split(spec, ",") {
+		key = strings.TrimPrefix(strings.TrimSpace(key), "then=")
+		switch _, ok := tieBreakers[key]; {
+		case key == "preemptive":
+			policy.preemptive = true
+		case ok:
+			policy.keys = append(policy.keys, key)
+		default:
+			return chainPolicy{}, fmt.Errorf("%w: unknown tie-breaker %q in -chain (want priority, sjf, ljf, srtf, fifo, lifo, pid or preemptive)", ErrInvalidArgs, key)
+		}
+	}
+	if len(policy.keys) == 0 {
+		return chainPolicy{}, fmt.Errorf("%w: -chain needs at least one tie-breaker", ErrInvalidArgs)
+	}
+
+	return policy, nil
+}
+
+// algorithm registers the policy so it runs and compares like the built-in ones.
+func (c chainPolicy) algorithm() algorithm {
+	title := "Chain: " + strings.Join(c.keys, ", then ")
+	if c.preemptive {
+		title += " (preemptive)"
+	}
+	a := algorithm{name: "chain", title: title, schedule: c.schedule, preemptive: c.preemptive}
+	for _, k := range c.keys {
+		a.priorities = a.priorities || k == "priority"
+	}
+
+	return a
+}
+
+// before reports whether a should be dispatched ahead of b.
+func (c chainPolicy) before(a, b chainCandidate) bool {
+	for _, key := range c.keys {
+		if d := tieBreakers[key](a, b); d != 0 {
+			return d < 0
+		}
+	}
+
+	return false
+}
+
+// schedule runs processes under the policy, outputting the same report as the built-in schedulers.
+// A preemptive chain re-decides at every arrival, the only time a process can overtake the running
+// one.
+func (c chainPolicy) schedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		ready: func(remaining []int64) readySet {
+			return newPriorityQueue(func(a, b int) bool {
+				ca, cb := chainCandidate{processes[a], remaining[a]}, chainCandidate{processes[b], remaining[b]}
+				if c.before(ca, cb) || c.before(cb, ca) {
+					return c.before(ca, cb)
+				}
+				return a < b
+			})
+		},
+		preemptive: c.preemptive,
+	}))
+}
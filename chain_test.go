@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_parseChain(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    chainPolicy
+		wantErr error
+	}{
+		{name: "single", spec: "fifo", want: chainPolicy{keys: []string{"fifo"}}},
+		{
+			name: "then chain",
+			spec: "priority, then=sjf,then=fifo",
+			want: chainPolicy{keys: []string{"priority", "sjf", "fifo"}},
+		},
+		{
+			name: "preemptive",
+			spec: "preemptive,srtf",
+			want: chainPolicy{keys: []string{"srtf"}, preemptive: true},
+		},
+		{name: "unknown", spec: "priority,then=lottery", wantErr: ErrInvalidArgs},
+		{name: "no keys", spec: "preemptive", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseChain(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseChain() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseChain() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_chainPolicy_schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name string
+		spec string
+		want func(io.Writer, string, []Process) Result
+	}{
+		{name: "fifo is fcfs", spec: "fifo", want: FCFSSchedule},
+		{name: "preemptive priority then srtf is sjf-priority", spec: "preemptive,priority,then=srtf", want: SJFPrioritySchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := parseChain(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := policy.schedule(io.Discard, "", processes, Options{})
+			want := tt.want(io.Discard, "", processes)
+			if !reflect.DeepEqual(got.Gantt, want.Gantt) || got.AveWait != want.AveWait {
+				t.Errorf("schedule() = %v (wait %v), want %v (wait %v)", got.Gantt, got.AveWait, want.Gantt, want.AveWait)
+			}
+		})
+	}
+}
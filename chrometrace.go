@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// chromeTickMicros is how many microseconds, the unit of the trace event format, a tick lasts in
+// a Chrome trace, so that a tick reads as a millisecond in the viewer.
+const chromeTickMicros = 1000
+
+type (
+	// chromeEvent is one entry of the Chrome trace event format: a complete slice (X), an
+	// instant (i) or metadata naming a lane (M).
+	chromeEvent struct {
+		Name  string         `json:"name"`
+		Phase string         `json:"ph"`
+		Time  int64          `json:"ts"`
+		Dur   int64          `json:"dur,omitempty"`
+		PID   int            `json:"pid"`
+		TID   int64          `json:"tid"`
+		Scope string         `json:"s,omitempty"`
+		Args  map[string]any `json:"args,omitempty"`
+	}
+	chromeTrace struct {
+		TraceEvents     []chromeEvent `json:"traceEvents"`
+		DisplayTimeUnit string        `json:"displayTimeUnit"`
+	}
+)
+
+// chromeEvents lays results out for chrome://tracing or Perfetto: each algorithm is a process
+// holding a thread per PID, whose slices are the time it ran and whose arrival is an instant.
+func chromeEvents(processes []Process, results []Result) []chromeEvent {
+	var events []chromeEvent
+	for i, r := range results {
+		lane := i + 1
+		events = append(events,
+			chromeEvent{Name: "process_name", Phase: "M", PID: lane, Args: map[string]any{"name": r.Name + ": " + r.Title}},
+			chromeEvent{Name: "process_sort_index", Phase: "M", PID: lane, Args: map[string]any{"sort_index": lane}},
+		)
+		for _, p := range processes {
+			name := fmt.Sprintf("P%d", p.ProcessID)
+			events = append(events,
+				chromeEvent{Name: "thread_name", Phase: "M", PID: lane, TID: p.ProcessID, Args: map[string]any{"name": name}},
+				chromeEvent{Name: "arrival", Phase: "i", Time: p.ArrivalTime * chromeTickMicros, PID: lane, TID: p.ProcessID, Scope: "t"},
+			)
+		}
+		for _, s := range r.Gantt {
+			events = append(events, chromeEvent{
+				Name:  fmt.Sprintf("P%d", s.PID),
+				Phase: "X",
+				Time:  s.Start * chromeTickMicros,
+				Dur:   (s.Stop - s.Start) * chromeTickMicros,
+				PID:   lane,
+				TID:   s.PID,
+				Args:  map[string]any{"start": s.Start, "stop": s.Stop, "reason": s.Reason},
+			})
+		}
+	}
+
+	return events
+}
+
+// writeChromeTrace writes every result's schedule to path as Chrome trace JSON, gzipped if the
+// name ends in .gz.
+func writeChromeTrace(path string, processes []Process, results []Result) error {
+	w, closeFn, err := createOutput(path, false)
+	if err != nil {
+		return fmt.Errorf("%w: creating Chrome trace file", err)
+	}
+	if err := outputChromeTrace(w, processes, results); err != nil {
+		_ = closeFn()
+		return err
+	}
+
+	return closeFn()
+}
+
+func outputChromeTrace(w io.Writer, processes []Process, results []Result) error {
+	trace := chromeTrace{TraceEvents: chromeEvents(processes, results), DisplayTimeUnit: "ms"}
+	if err := json.NewEncoder(w).Encode(trace); err != nil {
+		return fmt.Errorf("%w: writing Chrome trace", err)
+	}
+
+	return nil
+}
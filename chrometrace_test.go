@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func Test_outputChromeTrace(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 1, BurstDuration: 2}}
+	results := []Result{{Name: "fcfs", Title: "FCFS", Gantt: []TimeSlice{{PID: 1, Start: 1, Stop: 3, Reason: endCompletion}}}}
+	var w bytes.Buffer
+	if err := outputChromeTrace(&w, processes, results); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"traceEvents":[` +
+		`{"name":"process_name","ph":"M","ts":0,"pid":1,"tid":0,"args":{"name":"fcfs: FCFS"}},` +
+		`{"name":"process_sort_index","ph":"M","ts":0,"pid":1,"tid":0,"args":{"sort_index":1}},` +
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":1,"args":{"name":"P1"}},` +
+		`{"name":"arrival","ph":"i","ts":1000,"pid":1,"tid":1,"s":"t"},` +
+		`{"name":"P1","ph":"X","ts":1000,"dur":2000,"pid":1,"tid":1,"args":{"reason":"completion","start":1,"stop":3}}` +
+		`],"displayTimeUnit":"ms"}` + "\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputChromeTrace() =\n%s\nwant\n%s", got, want)
+	}
+	var trace chromeTrace
+	if err := json.Unmarshal(w.Bytes(), &trace); err != nil {
+		t.Errorf("Chrome trace is not valid JSON: %v", err)
+	}
+}
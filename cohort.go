@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// cohort summarizes the processes whose arrival falls in [from, to); a negative to means no upper
+// bound.
+type cohort struct {
+	from, to                   int64
+	count                      int
+	wait, turnaround, response float64
+}
+
+// label names the cohort's arrival range.
+func (c cohort) label() string {
+	if c.to < 0 {
+		return fmt.Sprintf("%d+", c.from)
+	}
+
+	return fmt.Sprintf("%d-%d", c.from, c.to-1)
+}
+
+// arrivalCohorts splits a result's per-process metrics at the ascending arrival-time boundaries and
+// averages each cohort. Empty cohorts are kept so every algorithm reports the same rows.
+func arrivalCohorts(processes []Process, gantt []TimeSlice, boundaries []int64) []cohort {
+	cohorts := make([]cohort, len(boundaries)+1)
+	var from int64
+	for i := range cohorts {
+		cohorts[i].from, cohorts[i].to = from, -1
+		if i < len(boundaries) {
+			cohorts[i].to = boundaries[i]
+			from = boundaries[i]
+		}
+	}
+
+	times := processTimesFromGantt(processes, gantt)
+	for i, p := range processes {
+		c := &cohorts[len(boundaries)]
+		for j, b := range boundaries {
+			if p.ArrivalTime < b {
+				c = &cohorts[j]
+				break
+			}
+		}
+		c.count++
+		c.wait += times.wait[i]
+		c.turnaround += times.turnaround[i]
+		c.response += times.response[i]
+	}
+	for i := range cohorts {
+		if n := float64(cohorts[i].count); n > 0 {
+			cohorts[i].wait /= n
+			cohorts[i].turnaround /= n
+			cohorts[i].response /= n
+		}
+	}
+
+	return cohorts
+}
+
+// outputCohorts prints each algorithm's averages per arrival cohort, showing whether a policy
+// favors processes that arrive early, while the system is empty, over later ones.
+func outputCohorts(w io.Writer, processes []Process, results []Result, boundaries []int64) {
+	_, _ = fmt.Fprintln(w, "Arrival cohorts")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Arrivals", "Processes", "Avg wait", "Avg turnaround", "Avg response"})
+	for _, r := range results {
+		for _, c := range arrivalCohorts(processes, r.Gantt, boundaries) {
+			table.Append([]string{
+				r.Name,
+				c.label(),
+				fmt.Sprint(c.count),
+				fmt.Sprintf("%.2f", c.wait),
+				fmt.Sprintf("%.2f", c.turnaround),
+				fmt.Sprintf("%.2f", c.response),
+			})
+		}
+	}
+	table.Render()
+}
+
+// outputPlainCohorts is the -plain form of outputCohorts.
+func outputPlainCohorts(w io.Writer, processes []Process, results []Result, boundaries []int64) {
+	for _, r := range results {
+		for _, c := range arrivalCohorts(processes, r.Gantt, boundaries) {
+			_, _ = fmt.Fprintf(w, "cohort: %s arrivals %s, processes %d, average wait %.2f, average turnaround %.2f, average response %.2f\n",
+				r.Name, c.label(), c.count, c.wait, c.turnaround, c.response)
+		}
+	}
+}
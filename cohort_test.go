@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_arrivalCohorts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6},
+	}
+	gantt := []TimeSlice{{PID: 1, Stop: 5}, {PID: 2, Start: 5, Stop: 14}, {PID: 3, Start: 14, Stop: 20}}
+	tests := []struct {
+		name       string
+		boundaries []int64
+		want       []cohort
+	}{
+		{
+			name: "single cohort",
+			want: []cohort{{from: 0, to: -1, count: 3, wait: 10.0 / 3, turnaround: 10, response: 10.0 / 3}},
+		},
+		{
+			name:       "early and late",
+			boundaries: []int64{4},
+			want: []cohort{
+				{from: 0, to: 4, count: 2, wait: 1, turnaround: 8, response: 1},
+				{from: 4, to: -1, count: 1, wait: 8, turnaround: 14, response: 8},
+			},
+		},
+		{
+			name:       "empty cohort",
+			boundaries: []int64{10},
+			want: []cohort{
+				{from: 0, to: 10, count: 3, wait: 10.0 / 3, turnaround: 10, response: 10.0 / 3},
+				{from: 10, to: -1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := arrivalCohorts(processes, gantt, tt.boundaries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("arrivalCohorts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputPlainCohorts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+	}
+	results := []Result{{Name: "fcfs", Gantt: []TimeSlice{{PID: 1, Stop: 5}, {PID: 2, Start: 5, Stop: 14}}}}
+	var w bytes.Buffer
+	outputPlainCohorts(&w, processes, results, []int64{2})
+	want := "cohort: fcfs arrivals 0-1, processes 1, average wait 0.00, average turnaround 5.00, average response 0.00\n" +
+		"cohort: fcfs arrivals 2+, processes 1, average wait 2.00, average turnaround 11.00, average response 2.00\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputPlainCohorts() = %q, want %q", got, want)
+	}
+}
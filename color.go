@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// colorPIDs turns on per-PID ANSI colors in gantt charts and schedule tables; main sets it from
+// -no-color and whether stdout is a terminal.
+var colorPIDs bool
+
+// pidPalette holds the ANSI color numbers, red through cyan, that PIDs cycle through, so a PID
+// has the same color in every chart and table.
+var pidPalette = []int{1, 2, 3, 4, 5, 6}
+
+// ansiColor returns pid's ANSI color number.
+func ansiColor(pid int64) int {
+	return pidPalette[(pid-1)%int64(len(pidPalette))]
+}
+
+// paintSlice draws text in black on pid's color, for a gantt bar.
+func paintSlice(pid int64, text string) string {
+	return fmt.Sprintf("\x1b[30;4%dm%s\x1b[0m", ansiColor(pid), text)
+}
+
+// rowColors colors every cell of a schedule row of columns cells in pid's color.
+func rowColors(pid int64, columns int) []tablewriter.Colors {
+	colors := make([]tablewriter.Colors, columns)
+	for i := range colors {
+		colors[i] = tablewriter.Colors{30 + ansiColor(pid)}
+	}
+
+	return colors
+}
+
+// colorEnabled reports whether output to f should be colored: f must be a terminal, and neither
+// -no-color nor the environment may turn colors off.
+func colorEnabled(f *os.File, noColor bool) bool {
+	fi, err := f.Stat()
+	if noColor || err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	_, disabled := os.LookupEnv("NO_COLOR")
+
+	return colorFor(os.Getenv("TERM"), disabled)
+}
+
+// colorFor decides from the environment of a terminal: NO_COLOR (https://no-color.org), set to
+// anything, and a dumb terminal both turn colors off.
+func colorFor(term string, noColorSet bool) bool {
+	return !noColorSet && term != "dumb"
+}
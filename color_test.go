@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func Test_colorFor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		term       string
+		noColorSet bool
+		want       bool
+	}{
+		{name: "terminal", term: "xterm-256color", want: true},
+		{name: "NO_COLOR", term: "xterm-256color", noColorSet: true, want: false},
+		{name: "dumb terminal", term: "dumb", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := colorFor(tt.term, tt.noColorSet); got != tt.want {
+				t.Errorf("colorFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_colorEnabled(t *testing.T) {
+	t.Parallel()
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if colorEnabled(f, false) {
+		t.Error("colorEnabled() = true for a regular file, want false")
+	}
+}
+
+func Test_ansiColor(t *testing.T) {
+	t.Parallel()
+	if ansiColor(1) == ansiColor(2) {
+		t.Error("ansiColor() gives PIDs 1 and 2 the same color")
+	}
+	if got, want := ansiColor(int64(len(pidPalette))+1), ansiColor(1); got != want {
+		t.Errorf("ansiColor() after the palette wraps = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// averageResponse is the mean time from arrival to first dispatch, taken from each PID's first slice.
+func averageResponse(processes []Process, gantt []TimeSlice) float64 {
+	return mean(processTimesFromGantt(processes, gantt).response)
+}
+
+// contextSwitches counts the times the CPU moves from one process to a different one.
+func contextSwitches(gantt []TimeSlice) int {
+	var switches int
+	for i := 1; i < len(gantt); i++ {
+		if gantt[i].PID != gantt[i-1].PID {
+			switches++
+		}
+	}
+
+	return switches
+}
+
+// cpuUsage returns how long the CPU sat idle between time 0 and the last completion, and the
+// percentage of that span it spent running processes.
+func cpuUsage(gantt []TimeSlice) (idle int64, utilization float64) {
+	var busy, end int64
+	for _, s := range gantt {
+		busy += s.Stop - s.Start
+		if s.Stop > end {
+			end = s.Stop
+		}
+	}
+	if end == 0 {
+		return 0, 0
+	}
+
+	return end - busy, float64(busy) / float64(end) * 100
+}
+
+// outputComparison prints one row per algorithm so results can be compared at a glance.
+func outputComparison(w io.Writer, results []Result) {
+	_, _ = fmt.Fprintln(w, "Comparison")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Avg wait", "Avg turnaround", "Avg response", "Throughput", "Switches", "Idle", "Utilization", "Fairness"})
+	for _, r := range results {
+		table.Append([]string{
+			comparisonName(r),
+			fmt.Sprintf("%.2f", r.AveWait),
+			fmt.Sprintf("%.2f", r.AveTurnaround),
+			fmt.Sprintf("%.2f", r.AveResponse),
+			fmt.Sprintf("%.2f/t", r.AveThroughput),
+			fmt.Sprint(r.ContextSwitches),
+			fmt.Sprint(r.IdleTime),
+			fmt.Sprintf("%.1f%%", r.Utilization),
+			fmt.Sprintf("%.3f", r.Fairness),
+		})
+	}
+	table.Render()
+}
+
+// outputPlainComparison is the -plain form of outputComparison.
+func outputPlainComparison(w io.Writer, results []Result) {
+	for _, r := range results {
+		_, _ = fmt.Fprintf(w, "summary: %s, average wait %.2f, average turnaround %.2f, average response %.2f, throughput %.2f/t, context switches %d, idle %d, utilization %.1f%%, fairness %.3f\n",
+			comparisonName(r), r.AveWait, r.AveTurnaround, r.AveResponse, r.AveThroughput, r.ContextSwitches, r.IdleTime, r.Utilization, r.Fairness)
+	}
+}
+
+// comparisonName names r in a comparison, flagging a run cut short, whose averages cover only the
+// processes it finished.
+func comparisonName(r Result) string {
+	if r.Unfinished > 0 {
+		return fmt.Sprintf("%s (%d unfinished)", r.Name, r.Unfinished)
+	}
+
+	return r.Name
+}
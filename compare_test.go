@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_averageResponse(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+	}
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 13}, {PID: 1, Start: 13, Stop: 16}}
+	if got, want := averageResponse(processes, gantt), 0.5; got != want {
+		t.Errorf("averageResponse() = %v, want %v", got, want)
+	}
+}
+
+func Test_contextSwitches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int
+	}{
+		{name: "empty"},
+		{name: "single", gantt: []TimeSlice{{PID: 1, Stop: 5}}},
+		{name: "same pid back to back", gantt: []TimeSlice{{PID: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 4}}},
+		{
+			name:  "alternating",
+			gantt: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 5}},
+			want:  2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := contextSwitches(tt.gantt); got != tt.want {
+				t.Errorf("contextSwitches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cpuUsage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		gantt           []TimeSlice
+		wantIdle        int64
+		wantUtilization float64
+	}{
+		{name: "empty"},
+		{name: "busy", gantt: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}}, wantUtilization: 100},
+		{
+			name:            "late start and gap",
+			gantt:           []TimeSlice{{PID: 1, Start: 1, Stop: 3}, {PID: 2, Start: 5, Stop: 8}},
+			wantIdle:        3,
+			wantUtilization: 62.5,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			idle, utilization := cpuUsage(tt.gantt)
+			if idle != tt.wantIdle || utilization != tt.wantUtilization {
+				t.Errorf("cpuUsage() = %v, %v, want %v, %v", idle, utilization, tt.wantIdle, tt.wantUtilization)
+			}
+		})
+	}
+}
+
+func Test_outputComparison(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputComparison(&w, []Result{{Name: "fcfs", AveWait: 3.333, AveTurnaround: 10, AveResponse: 3.333, AveThroughput: 0.15, ContextSwitches: 2, IdleTime: 3, Utilization: 62.5, Fairness: 0.8}})
+	want := "| fcfs      |     3.33 |          10.00 |         3.33 | 0.15/t     |        2 |    3 | 62.5%       |    0.800 |"
+	if got := w.String(); !strings.Contains(got, want) {
+		t.Errorf("outputComparison() = %v, want to contain %v", got, want)
+	}
+}
+
+func Test_outputPlainComparison(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputPlainComparison(&w, []Result{{Name: "fcfs", AveWait: 3.333, AveTurnaround: 10, AveResponse: 3.333, AveThroughput: 0.15, ContextSwitches: 2, IdleTime: 3, Utilization: 62.5, Fairness: 0.8}})
+	want := "summary: fcfs, average wait 3.33, average turnaround 10.00, average response 3.33, throughput 0.15/t, context switches 2, idle 3, utilization 62.5%, fairness 0.800\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputPlainComparison() = %q, want %q", got, want)
+	}
+}
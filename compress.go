@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// gzipExt marks output files that are written gzip-compressed.
+const gzipExt = ".gz"
+
+// createOutput creates the export file at path, streaming it through gzip when path ends in .gz
+// or compress is set, in which case .gz is appended if missing. The returned close must be called
+// once the output is written; it flushes the compressor before closing the file.
+func createOutput(path string, compress bool) (io.Writer, func() error, error) {
+	gz := compress || strings.HasSuffix(path, gzipExt)
+	if gz && !strings.HasSuffix(path, gzipExt) {
+		path += gzipExt
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: creating %s", err, path)
+	}
+	buf := bufio.NewWriter(f)
+	if !gz {
+		return buf, func() error { return closeOutput(path, buf.Flush(), f) }, nil
+	}
+	zw := gzip.NewWriter(buf)
+	closeFn := func() error {
+		err := zw.Close()
+		if err == nil {
+			err = buf.Flush()
+		}
+		return closeOutput(path, err, f)
+	}
+
+	return zw, closeFn, nil
+}
+
+// closeOutput closes f, reporting flushErr first as the earlier failure.
+func closeOutput(path string, flushErr error, f *os.File) error {
+	closeErr := f.Close()
+	if flushErr != nil {
+		return fmt.Errorf("%w: writing %s", flushErr, path)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("%w: closing %s", closeErr, path)
+	}
+
+	return nil
+}
+
+// openInput opens the file at path for reading, decompressing it when path ends in .gz.
+func openInput(path string) (io.Reader, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: opening %s", err, path)
+	}
+	r, err := decompressInput(f, path)
+	if err != nil {
+		_ = f.Close()
+		return nil, nil, err
+	}
+
+	return r, f.Close, nil
+}
+
+// decompressInput returns r, read from the file at path, through gzip when path ends in .gz.
+func decompressInput(r io.Reader, path string) (io.Reader, error) {
+	if !strings.HasSuffix(path, gzipExt) {
+		return r, nil
+	}
+	zr, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: reading %s", ErrInvalidArgs, err, path)
+	}
+
+	return zr, nil
+}
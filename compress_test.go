@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_createOutput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		path     string
+		compress bool
+		written  string
+		gzipped  bool
+	}{
+		{name: "plain", path: "out.csv", written: "out.csv"},
+		{name: "gz extension", path: "out.csv.gz", written: "out.csv.gz", gzipped: true},
+		{name: "compress flag", path: "out.csv", compress: true, written: "out.csv.gz", gzipped: true},
+		{name: "compress flag with gz extension", path: "out.csv.gz", compress: true, written: "out.csv.gz", gzipped: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			w, closeFn, err := createOutput(filepath.Join(dir, tt.path), tt.compress)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = fmt.Fprint(w, "a,b\n1,2\n")
+			if err := closeFn(); err != nil {
+				t.Fatal(err)
+			}
+			raw, err := os.ReadFile(filepath.Join(dir, tt.written))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := raw
+			if tt.gzipped {
+				zr, err := gzip.NewReader(bytes.NewReader(raw))
+				if err != nil {
+					t.Fatalf("%s is not gzip: %v", tt.written, err)
+				}
+				if got, err = io.ReadAll(zr); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if string(got) != "a,b\n1,2\n" {
+				t.Errorf("%s holds %q, want %q", tt.written, got, "a,b\n1,2\n")
+			}
+		})
+	}
+}
+
+func Test_openInput(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "results.json.gz")
+	w, closeFn, err := createOutput(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fmt.Fprint(w, "{}")
+	if err := closeFn(); err != nil {
+		t.Fatal(err)
+	}
+	r, closeIn, err := openInput(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = closeIn() }()
+	if got, err := io.ReadAll(r); err != nil || string(got) != "{}" {
+		t.Errorf("openInput() reads %q, %v, want %q", got, err, "{}")
+	}
+}
+
+func Test_loadWorkload_gzip(t *testing.T) {
+	t.Parallel()
+	cfg := defaultGeneratorConfig
+	cfg.Count = 20
+	processes := generateWorkload(rand.New(rand.NewSource(1)), cfg)
+	path := filepath.Join(t.TempDir(), "w.csv.gz")
+	w, closeFn, err := createOutput(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeWorkloadCSV(w, processes); err != nil {
+		t.Fatal(err)
+	}
+	if err := closeFn(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the way the main command reads it
+	f, closeFile, err := openProcessingFile("sched", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeFile()
+	r, err := decompressInput(f, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadProcessesDelimited(r, ',')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, processes) {
+		t.Errorf("loaded %v, want %v", got, processes)
+	}
+	result := runAlgorithm(*findAlgorithm("rr"), io.Discard, got, Options{Quantum: 2})
+	if result.Unfinished != 0 || len(result.Gantt) == 0 {
+		t.Errorf("rr over the gzipped workload left %d processes unfinished", result.Unfinished)
+	}
+
+	// and the way the subcommands do
+	if got, err := loadWorkloadFile(path, ','); err != nil || !reflect.DeepEqual(got, processes) {
+		t.Errorf("loadWorkloadFile() = %v, %v, want %v", got, err, processes)
+	}
+}
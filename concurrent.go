@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// reportBuffer holds the report of an algorithm running alongside others until it is copied out
+// in order. term is the file the report ends up on, if it goes straight to one, so the gantt
+// chart can use the inline images of a terminal there.
+type reportBuffer struct {
+	bytes.Buffer
+	term *os.File
+}
+
+// algorithmRun is one algorithm's part of a concurrent run: its result, the report it wrote,
+// unless reports were discarded, and the resource limit that cut it short, if any.
+type algorithmRun struct {
+	result Result
+	report *reportBuffer
+	err    error
+}
+
+// runConcurrently runs the selected algorithms over processes through guard, up to jobs at once,
+// and returns their runs in selected order however they finish. The schedulers only read the
+// workload, so they share it. Each writes its report to its own buffer, or to io.Discard when
+// discard is set.
+func runConcurrently(selected []algorithm, processes []Process, opts Options, guard *resourceGuard, jobs int, discard bool, term *os.File) []algorithmRun {
+	runs := make([]algorithmRun, len(selected))
+	slots := make(chan struct{}, max(jobs, 1))
+	var wg sync.WaitGroup
+	for i, a := range selected {
+		i, a := i, a
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			var w io.Writer = io.Discard
+			if !discard {
+				runs[i].report = &reportBuffer{term: term}
+				w = runs[i].report
+			}
+			var scheduleErr error
+			runs[i].result, runs[i].err = guard.run(func(ctx context.Context) Result {
+				ctx, done := startProgress(ctx, a.name, processes)
+				defer done()
+				result, err := runAlgorithmChecked(a, w, processes, opts.WithContext(ctx))
+				scheduleErr = err
+				return result
+			})
+			// set only once the run is over, so only read when the guard did not cut it short
+			if runs[i].err == nil {
+				runs[i].err = scheduleErr
+			}
+		}()
+	}
+	wg.Wait()
+
+	return runs
+}
+
+// terminalOf returns the file output to w lands on directly, or nil.
+func terminalOf(w io.Writer) *os.File {
+	switch w := w.(type) {
+	case *os.File:
+		return w
+	case *reportBuffer:
+		return w.term
+	}
+
+	return nil
+}
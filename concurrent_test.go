@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func Test_runConcurrently(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8, Priority: 3, Weight: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 9, Priority: 4, Weight: 3},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 5, Priority: 2},
+	}
+	for _, jobs := range []int{1, 4, len(algorithms)} {
+		runs := runConcurrently(algorithms, processes, Options{}, newResourceGuard(0, 0), jobs, false, nil)
+		for i, a := range algorithms {
+			var report bytes.Buffer
+			want := runAlgorithm(a, &report, processes, Options{})
+			if runs[i].err != nil || !reflect.DeepEqual(runs[i].result, want) {
+				t.Errorf("jobs %d: run %d = %+v, %v, want %s's result", jobs, i, runs[i].result, runs[i].err, a.name)
+			}
+			if runs[i].report.String() != report.String() {
+				t.Errorf("jobs %d: %s report =\n%s\nwant\n%s", jobs, a.name, runs[i].report, &report)
+			}
+		}
+	}
+
+	runs := runConcurrently(algorithms[:1], processes, Options{}, newResourceGuard(0, 0), 1, true, nil)
+	if runs[0].report != nil {
+		t.Errorf("runConcurrently() kept a report with discard set")
+	}
+}
+
+func Test_terminalOf(t *testing.T) {
+	t.Parallel()
+	if terminalOf(os.Stdout) != os.Stdout || terminalOf(&reportBuffer{term: os.Stdout}) != os.Stdout {
+		t.Error("terminalOf() lost the file behind a writer that ends up on it")
+	}
+	if terminalOf(io.Discard) != nil || terminalOf(&reportBuffer{}) != nil {
+		t.Error("terminalOf() found a file behind a writer that has none")
+	}
+}
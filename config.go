@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// configInput is the config key naming the workload file, used when none is given on the
+// command line.
+const configInput = "input"
+
+// loadConfig applies the run configuration at path to fs; see applyConfig.
+func loadConfig(fs *flag.FlagSet, path string) (input string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: opening config", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return applyConfig(fs, f)
+}
+
+// applyConfig reads a flat TOML file of `key = value` lines, where each key is a flag name, and
+// sets the flags that were not given on the command line, so explicit flags win. Values may be
+// strings, booleans, numbers or arrays of strings, which become comma-separated lists:
+//
+//	input = "workload.csv"
+//	algo = ["fcfs", "rr"]
+//	timeout = "5s"
+//	plain = true
+//
+// The input key names the workload file. Tables ([section]) are not supported.
+func applyConfig(fs *flag.FlagSet, r io.Reader) (input string, err error) {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") {
+			return "", fmt.Errorf("%w: config line %d: tables are not supported, keys go at the top level", ErrInvalidArgs, line)
+		}
+		key, raw, ok := strings.Cut(text, "=")
+		if !ok {
+			return "", fmt.Errorf("%w: config line %d: want key = value", ErrInvalidArgs, line)
+		}
+		key = strings.TrimSpace(key)
+		value, err := parseConfigValue(strings.TrimSpace(raw))
+		if err != nil {
+			return "", fmt.Errorf("%w: config line %d (%s): %w", ErrInvalidArgs, line, key, err)
+		}
+		switch {
+		case key == configInput:
+			input = value
+		case fs.Lookup(key) == nil:
+			return "", fmt.Errorf("%w: config line %d: unknown key %q (keys are flag names)", ErrInvalidArgs, line, key)
+		case explicit[key]:
+			// the command line overrides the file
+		default:
+			if err := fs.Set(key, value); err != nil {
+				return "", fmt.Errorf("%w: config line %d (%s): %w", ErrInvalidArgs, line, key, err)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return "", fmt.Errorf("%w: reading config", err)
+	}
+
+	return input, nil
+}
+
+// parseConfigValue turns a TOML value into the string form its flag accepts, dropping any
+// trailing comment.
+func parseConfigValue(raw string) (string, error) {
+	if strings.HasPrefix(raw, "[") {
+		end := strings.LastIndex(raw, "]")
+		if end < 0 {
+			return "", errors.New("unterminated array")
+		}
+		var items []string
+		for _, item := range strings.Split(raw[1:end], ",") {
+			if item = strings.TrimSpace(item); item == "" {
+				continue
+			}
+			v, err := parseConfigValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, v)
+		}
+		return strings.Join(items, ","), nil
+	}
+	if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+		end := 1
+		for ; end < len(raw) && raw[end] != raw[0]; end++ {
+			if raw[end] == '\\' && raw[0] == '"' {
+				end++
+			}
+		}
+		if end >= len(raw) {
+			return "", errors.New("unterminated string")
+		}
+		quoted := raw[:end+1]
+		if raw[0] == '\'' {
+			// literal strings take no escapes
+			return quoted[1 : len(quoted)-1], nil
+		}
+		return strconv.Unquote(quoted)
+	}
+	value, _, _ := strings.Cut(raw, "#")
+
+	return strings.TrimSpace(value), nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_applyConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		args      []string
+		config    string
+		want      map[string]string
+		wantInput string
+		wantErr   error
+	}{
+		{
+			name: "values",
+			config: `# experiment
+input = "runs/day1.csv"
+algo = ["fcfs", 'rr']   # two policies
+plain = true
+timeout = "5s"
+`,
+			want:      map[string]string{"algo": "fcfs,rr", "plain": "true", "timeout": "5s"},
+			wantInput: "runs/day1.csv",
+		},
+		{
+			name:   "command line wins",
+			args:   []string{"-algo", "sjf"},
+			config: `algo = "fcfs"`,
+			want:   map[string]string{"algo": "sjf", "plain": "false", "timeout": "0s"},
+		},
+		{name: "escaped quote", config: `algo = "a\"b" # c`, want: map[string]string{"algo": `a"b`, "plain": "false", "timeout": "0s"}},
+		{name: "unknown key", config: "quantum = 4", wantErr: ErrInvalidArgs},
+		{name: "bad value", config: "plain = maybe", wantErr: ErrInvalidArgs},
+		{name: "table", config: "[run]\nalgo = \"fcfs\"", wantErr: ErrInvalidArgs},
+		{name: "unterminated", config: `algo = "fcfs`, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.String("algo", "", "")
+			fs.Bool("plain", false, "")
+			fs.Duration("timeout", 0, "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			input, err := applyConfig(fs, strings.NewReader(tt.config))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyConfig() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got := make(map[string]string)
+			fs.VisitAll(func(f *flag.Flag) { got[f.Name] = f.Value.String() })
+			if !reflect.DeepEqual(got, tt.want) || input != tt.wantInput {
+				t.Errorf("applyConfig() = %v, input %q; want %v, input %q", got, input, tt.want, tt.wantInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// conformanceSuite is the conformance kit: scenario workloads in conformance/*.csv, each with a
+// golden file of what every built-in policy makes of it at default options. It is built into the
+// binary so a fork or plugin can prove itself compatible without the source tree; after an
+// intended change to a built-in policy, regenerate it with golden -update conformance.
+//
+//go:embed conformance/*.csv conformance/*.golden.json
+var conformanceSuite embed.FS
+
+// conformanceCase is the outcome of one scenario of the suite.
+type conformanceCase struct {
+	name string
+	// err says how the implementation diverged from the policy, or is nil if it conformed.
+	err error
+}
+
+// checkConformance runs a over every scenario in suite and compares each result with the one
+// expected of policy, a built-in policy name. A scenario whose schedule is invalid or panics, as a
+// buggy plugin's may, fails rather than ending the run.
+func checkConformance(suite fs.FS, a algorithm, policy string) ([]conformanceCase, error) {
+	paths, err := fs.Glob(suite, "conformance/*.csv")
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing conformance scenarios", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: the conformance suite has no scenarios", ErrInvalidArgs)
+	}
+
+	cases := make([]conformanceCase, 0, len(paths))
+	for _, p := range paths {
+		in, err := fs.ReadFile(suite, p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading %s", err, p)
+		}
+		processes, err := loadProcessesDelimited(bytes.NewReader(in), ',')
+		if err != nil {
+			return nil, fmt.Errorf("%w: in %s", err, p)
+		}
+		in, err = fs.ReadFile(suite, goldenPath(p))
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading %s", err, goldenPath(p))
+		}
+		golden, err := decodeGolden(goldenPath(p), in)
+		if err != nil {
+			return nil, err
+		}
+		want, ok := golden[policy]
+		if !ok {
+			return nil, fmt.Errorf("%w: the conformance suite has no expected results for policy %q", ErrInvalidArgs, policy)
+		}
+
+		c := conformanceCase{name: strings.TrimSuffix(path.Base(p), ".csv")}
+		result, err := runConforming(a, processes)
+		if err == nil {
+			// the implementation is compared under the name of the policy it claims to be
+			result.Name = policy
+			err = compareGolden(goldenPath(p), map[string]goldenResult{policy: want}, map[string]Result{policy: result})
+		}
+		c.err = err
+		cases = append(cases, c)
+	}
+
+	return cases, nil
+}
+
+// runConforming runs a at default options, turning an invalid schedule or a panic into an error.
+func runConforming(a algorithm, processes []Process) (result Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrGoldenMismatch, r)
+		}
+	}()
+
+	return runAlgorithmChecked(a, io.Discard, processes, Options{})
+}
+
+// runConformance is the conformance subcommand: it checks an implementation, typically one loaded
+// with -plugin, against the built-in conformance suite, expecting it to schedule every scenario
+// exactly as the built-in policy it claims to implement does.
+func runConformance(args []string) error {
+	fs := flag.NewFlagSet("conformance", flag.ExitOnError)
+	plugins := fs.String("plugin", "", "comma-separated Go plugin `files` to load schedulers from before checking")
+	policy := fs.String("policy", "", "built-in `policy` whose expected results to check against (default the implementation's own name)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: conformance [-plugin files] [-policy name] implementation", ErrInvalidArgs)
+	}
+	if *plugins != "" {
+		if err := registerPlugins(*plugins); err != nil {
+			return err
+		}
+	}
+	a := findAlgorithm(fs.Arg(0))
+	if a == nil {
+		return fmt.Errorf("%w: unknown implementation %q", ErrInvalidArgs, fs.Arg(0))
+	}
+	if *policy == "" {
+		*policy = a.name
+	}
+
+	cases, err := checkConformance(conformanceSuite, *a, *policy)
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, c := range cases {
+		if c.err != nil {
+			failed++
+			_, _ = fmt.Printf("FAIL %s: %v\n", c.name, c.err)
+			continue
+		}
+		_, _ = fmt.Printf("ok %s\n", c.name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %s does not conform to %s in %d of %d scenarios", ErrGoldenMismatch, a.name, *policy, failed, len(cases))
+	}
+	_, _ = fmt.Printf("%s conforms to %s in all %d scenarios\n", a.name, *policy, len(cases))
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// Test_conformance checks that every built-in policy passes its own conformance suite, so the
+// kit shipped in the binary never disagrees with the policies it describes.
+func Test_conformance(t *testing.T) {
+	t.Parallel()
+	for _, a := range algorithms {
+		a := a
+		t.Run(a.name, func(t *testing.T) {
+			t.Parallel()
+			cases, err := checkConformance(conformanceSuite, a, a.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range cases {
+				if c.err != nil {
+					t.Errorf("%s: %v", c.name, c.err)
+				}
+			}
+		})
+	}
+}
+
+func Test_checkConformance_failures(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		a    algorithm
+	}{
+		{name: "another policy", a: *findAlgorithm("sjf")},
+		{name: "panicking", a: algorithm{name: "broken", schedule: func(io.Writer, string, []Process, Options) Result {
+			panic("invalid schedule")
+		}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cases, err := checkConformance(conformanceSuite, tt.a, "fcfs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var failed int
+			for _, c := range cases {
+				if c.err != nil {
+					failed++
+					if !errors.Is(c.err, ErrGoldenMismatch) {
+						t.Errorf("%s: error %v does not wrap ErrGoldenMismatch", c.name, c.err)
+					}
+				}
+			}
+			if failed == 0 {
+				t.Error("checkConformance() passed every scenario, want failures")
+			}
+		})
+	}
+
+	if _, err := checkConformance(conformanceSuite, *findAlgorithm("fcfs"), "lottery"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("checkConformance() of a policy with no expected results error = %v, want ErrInvalidArgs", err)
+	}
+}
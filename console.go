@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+var (
+	// legacyConsole makes terminal output safe for the classic Windows console: no ANSI colors
+	// or inline images, and ASCII stand-ins for the few non-ASCII characters in reports, which
+	// its OEM code page would garble. main sets it from -console.
+	legacyConsole bool
+	// crlfFiles ends the lines of the text files the simulator writes with \r\n, for Windows
+	// tools that expect it; main and the subcommands writing CSV set it from -crlf.
+	crlfFiles bool
+)
+
+// asciiStandIns replaces the non-ASCII characters that reports and the TUI print.
+var asciiStandIns = strings.NewReplacer("Δ", "+/-", "±", "+/-", "•", "|", "←", "<-", "→", "->")
+
+// consoleText returns s as the console should show it: unchanged, or asciiText for a legacy one.
+func consoleText(s string) string {
+	if !legacyConsole {
+		return s
+	}
+
+	return asciiText(s)
+}
+
+// asciiText replaces every non-ASCII character of s by a stand-in, or else by ?.
+func asciiText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0x7f {
+			return '?'
+		}
+		return r
+	}, asciiStandIns.Replace(s))
+}
+
+// parseConsole resolves -console: modern and legacy force the mode, and auto detects a legacy
+// console from the platform and environment.
+func parseConsole(mode string) (bool, error) {
+	switch mode {
+	case "modern":
+		return false, nil
+	case "legacy":
+		return true, nil
+	case "auto":
+		return isLegacyConsole(runtime.GOOS, os.Getenv), nil
+	}
+
+	return false, fmt.Errorf("%w: unknown -console %q, want auto, modern or legacy", ErrInvalidArgs, mode)
+}
+
+// isLegacyConsole guesses whether output goes to the classic Windows console host. Windows
+// Terminal, ConEmu, VS Code, mintty and the like all announce themselves in the environment and
+// handle UTF-8 and escape sequences, so only a Windows process with none of them is legacy.
+func isLegacyConsole(goos string, getenv func(string) string) bool {
+	if goos != "windows" {
+		return false
+	}
+
+	return getenv("WT_SESSION") == "" && getenv("TERM_PROGRAM") == "" && getenv("TERM") == "" && getenv("ConEmuANSI") != "ON"
+}
+
+// crlfWriter writes through to w with every \n turned into \r\n.
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (c crlfWriter) Write(p []byte) (int, error) {
+	if _, err := c.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// textFile wraps w, a text file being written, to convert its line endings for crlfFiles.
+func textFile(w io.Writer) io.Writer {
+	if crlfFiles {
+		return crlfWriter{w: w}
+	}
+
+	return w
+}
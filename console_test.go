@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_isLegacyConsole(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		goos string
+		env  map[string]string
+		want bool
+	}{
+		{name: "linux", goos: "linux", want: false},
+		{name: "conhost", goos: "windows", want: true},
+		{name: "Windows Terminal", goos: "windows", env: map[string]string{"WT_SESSION": "1f2e"}, want: false},
+		{name: "ConEmu", goos: "windows", env: map[string]string{"ConEmuANSI": "ON"}, want: false},
+		{name: "mintty", goos: "windows", env: map[string]string{"TERM": "xterm"}, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string { return tt.env[key] }
+			if got := isLegacyConsole(tt.goos, getenv); got != tt.want {
+				t.Errorf("isLegacyConsole() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_asciiText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in, want string
+	}{
+		{in: "Δ Avg wait", want: "+/- Avg wait"},
+		{in: "jitter ±2", want: "jitter +/-2"},
+		{in: "space pause • tab/←/→ switch", want: "space pause | tab/<-/-> switch"},
+		{in: "P1 ✓", want: "P1 ?"},
+	}
+	for _, tt := range tests {
+		if got := asciiText(tt.in); got != tt.want {
+			t.Errorf("asciiText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func Test_crlfWriter(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	in := "pid,burst\n1,5\n"
+	n, err := crlfWriter{w: &b}.Write([]byte(in))
+	if err != nil || n != len(in) {
+		t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(in))
+	}
+	if got, want := b.String(), "pid,burst\r\n1,5\r\n"; got != want {
+		t.Errorf("Write() wrote %q, want %q", got, want)
+	}
+}
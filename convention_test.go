@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"testing"
+)
+
+func Test_applyConvention(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		args       []string
+		convention string
+		wantTie    string
+		wantErr    error
+	}{
+		{name: "preset", convention: "tanenbaum", wantTie: "preempted"},
+		{name: "explicit flag wins", args: []string{"-rr-tie", "arrival"}, convention: "tanenbaum", wantTie: "arrival"},
+		{name: "unknown", convention: "knuth", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			tie := fs.String("rr-tie", "arrival", "")
+			fs.String("rr-rounding", "exact", "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := applyConvention(fs, tt.convention)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("applyConvention() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && *tie != tt.wantTie {
+				t.Errorf("-rr-tie = %q, want %q", *tie, tt.wantTie)
+			}
+		})
+	}
+}
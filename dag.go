@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// dependencies tracks which processes still wait on predecessors during a simulation. A nil
+// *dependencies stands for a workload without any, where every arrival is ready at once.
+type dependencies struct {
+	// pending counts the unfinished predecessors of each process, by workload index
+	pending    []int
+	dependents [][]int
+	// held marks processes that have arrived but still wait on predecessors
+	held []bool
+}
+
+// newDependencies indexes the Depends lists of processes, or returns nil when there are none.
+func newDependencies(processes []Process) *dependencies {
+	index := make(map[int64]int, len(processes))
+	found := false
+	for i, p := range processes {
+		index[p.ProcessID] = i
+		found = found || len(p.Depends) > 0
+	}
+	if !found {
+		return nil
+	}
+	d := &dependencies{
+		pending:    make([]int, len(processes)),
+		dependents: make([][]int, len(processes)),
+		held:       make([]bool, len(processes)),
+	}
+	for i, p := range processes {
+		for _, pid := range p.Depends {
+			d.pending[i]++
+			d.dependents[index[pid]] = append(d.dependents[index[pid]], i)
+		}
+	}
+
+	return d
+}
+
+// arrive reports whether process i is ready on arrival, and holds it otherwise.
+func (d *dependencies) arrive(i int) bool {
+	if d == nil || d.pending[i] == 0 {
+		return true
+	}
+	d.held[i] = true
+
+	return false
+}
+
+// complete records that process i finished and returns the held processes that are now ready.
+func (d *dependencies) complete(i int) []int {
+	if d == nil {
+		return nil
+	}
+	var ready []int
+	for _, j := range d.dependents[i] {
+		if d.pending[j]--; d.pending[j] == 0 && d.held[j] {
+			d.held[j] = false
+			ready = append(ready, j)
+		}
+	}
+
+	return ready
+}
+
+// parseDepends reads a depends field: PIDs separated by semicolons or spaces, e.g. "1;3".
+func parseDepends(s string) ([]int64, error) {
+	var pids []int64
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == ' ' }) {
+		pid, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		pids = append(pids, pid)
+	}
+
+	return pids, nil
+}
+
+// hasDependencies reports whether any process depends on another.
+func hasDependencies(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.Depends) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// criticalPath returns the chain of PIDs that bounds every schedule's makespan, and that bound:
+// a process can finish no earlier than its burst after both its arrival and its predecessors'
+// earliest finishes, even with a CPU per process. The workload must be a valid DAG.
+func criticalPath(processes []Process) (path []int64, finish int64) {
+	index := make(map[int64]int, len(processes))
+	for i, p := range processes {
+		index[p.ProcessID] = i
+	}
+	earliest := make([]int64, len(processes))
+	via := make([]int, len(processes)) // the predecessor that finishes last, or -1
+	done := make([]bool, len(processes))
+	var visit func(i int) int64
+	visit = func(i int) int64 {
+		if done[i] {
+			return earliest[i]
+		}
+		start, from := processes[i].ArrivalTime, -1
+		for _, pid := range processes[i].Depends {
+			j := index[pid]
+			if f := visit(j); f > start || f == start && from < 0 {
+				start, from = f, j
+			}
+		}
+		earliest[i], via[i], done[i] = start+processes[i].BurstDuration, from, true
+		return earliest[i]
+	}
+	last := -1
+	for i := range processes {
+		if f := visit(i); last < 0 || f > finish {
+			last, finish = i, f
+		}
+	}
+	for i := last; i >= 0; i = via[i] {
+		path = append([]int64{processes[i].ProcessID}, path...)
+	}
+
+	return path, finish
+}
+
+func formatPath(path []int64) string {
+	names := make([]string, len(path))
+	for i, pid := range path {
+		names[i] = fmt.Sprintf("P%d", pid)
+	}
+
+	return strings.Join(names, " -> ")
+}
+
+// outputCriticalPath flags the workload's critical path and how close each algorithm's makespan
+// comes to the bound it sets.
+func outputCriticalPath(w io.Writer, processes []Process, results []Result) {
+	path, bound := criticalPath(processes)
+	_, _ = fmt.Fprintf(w, "Critical path: %s, finishing no earlier than %d\n", formatPath(path), bound)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Makespan", "Over critical path"})
+	for _, r := range results {
+		makespan := makespanOf(r.Gantt)
+		table.Append([]string{r.Name, fmt.Sprint(makespan), fmt.Sprint(makespan - bound)})
+	}
+	table.Render()
+}
+
+func outputPlainCriticalPath(w io.Writer, processes []Process, results []Result) {
+	path, bound := criticalPath(processes)
+	_, _ = fmt.Fprintf(w, "critical path: %s, bound %d\n", formatPath(path), bound)
+	for _, r := range results {
+		makespan := makespanOf(r.Gantt)
+		_, _ = fmt.Fprintf(w, "critical path: algorithm %s, makespan %d, over %d\n", r.Name, makespan, makespan-bound)
+	}
+}
+
+// makespanOf is when the last slice of gantt stops.
+func makespanOf(gantt []TimeSlice) int64 {
+	var end int64
+	for _, s := range gantt {
+		end = max(end, s.Stop)
+	}
+
+	return end
+}
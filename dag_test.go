@@ -0,0 +1,57 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+// dagWorkload is a small precedence graph: 3 needs 1, and 4 needs 2 and 3.
+var dagWorkload = []Process{
+	{ProcessID: 1, BurstDuration: 3, Priority: 3},
+	{ProcessID: 2, BurstDuration: 2, Priority: 2},
+	{ProcessID: 3, BurstDuration: 1, Priority: 1, Depends: []int64{1}},
+	{ProcessID: 4, ArrivalTime: 1, BurstDuration: 4, Priority: 1, Depends: []int64{2, 3}},
+}
+
+func Test_dependencies_allAlgorithms(t *testing.T) {
+	t.Parallel()
+	for _, a := range algorithms {
+		a := a
+		t.Run(a.name, func(t *testing.T) {
+			t.Parallel()
+			r := runAlgorithm(a, io.Discard, dagWorkload, Options{})
+			if err := checkGantt(dagWorkload, r.Gantt); err != nil {
+				t.Errorf("%s ignores dependencies: %v", a.name, err)
+			}
+		})
+	}
+}
+
+func Test_dependencies_sjf(t *testing.T) {
+	t.Parallel()
+	// 3 is the shortest job but waits for 1, and 4 is released only when 3 completes
+	got := runAlgorithm(*findAlgorithm("sjf"), io.Discard, dagWorkload, Options{}).Gantt
+	want := []TimeSlice{
+		{PID: 2, Start: 0, Stop: 2, Reason: endCompletion},
+		{PID: 1, Start: 2, Stop: 5, Reason: endCompletion},
+		{PID: 3, Start: 5, Stop: 6, Reason: endCompletion},
+		{PID: 4, Start: 6, Stop: 10, Reason: endCompletion},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sjf gantt = %v, want %v", got, want)
+	}
+}
+
+func Test_criticalPath(t *testing.T) {
+	t.Parallel()
+	path, bound := criticalPath(dagWorkload)
+	if !reflect.DeepEqual(path, []int64{1, 3, 4}) || bound != 8 {
+		t.Errorf("criticalPath() = %v, %d, want [1 3 4], 8", path, bound)
+	}
+	// without dependencies the bound is the latest single finish
+	path, bound = criticalPath([]Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 4, BurstDuration: 1}})
+	if !reflect.DeepEqual(path, []int64{2}) || bound != 5 {
+		t.Errorf("criticalPath() = %v, %d, want [2], 5", path, bound)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	// registers the pure Go "sqlite" driver, so -db needs no cgo
+	_ "modernc.org/sqlite"
+)
+
+// dbSchema creates the tables -db appends to. Every row is keyed by the run it came from, so runs
+// accumulate in one file and can be compared with plain SQL.
+const dbSchema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	started   TEXT NOT NULL,
+	command   TEXT NOT NULL,
+	workload  TEXT NOT NULL,
+	processes INTEGER NOT NULL,
+	options   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS results (
+	run_id           INTEGER NOT NULL REFERENCES runs (id),
+	algorithm        TEXT NOT NULL,
+	avg_wait         REAL NOT NULL,
+	avg_turnaround   REAL NOT NULL,
+	avg_response     REAL NOT NULL,
+	throughput       REAL NOT NULL,
+	context_switches INTEGER NOT NULL,
+	idle_time        INTEGER NOT NULL,
+	utilization      REAL NOT NULL,
+	unfinished       INTEGER NOT NULL,
+	PRIMARY KEY (run_id, algorithm)
+);
+CREATE TABLE IF NOT EXISTS process_metrics (
+	run_id     INTEGER NOT NULL REFERENCES runs (id),
+	algorithm  TEXT NOT NULL,
+	pid        INTEGER NOT NULL,
+	arrival    INTEGER NOT NULL,
+	burst      INTEGER NOT NULL,
+	priority   INTEGER NOT NULL,
+	wait       INTEGER,
+	response   INTEGER,
+	turnaround INTEGER,
+	exit       INTEGER,
+	PRIMARY KEY (run_id, algorithm, pid)
+);`
+
+// dbRun is what -db records about a run besides its results.
+type dbRun struct {
+	started  time.Time
+	command  []string
+	workload string
+}
+
+// appendRunDB adds run, its options and every result to the SQLite database at path, creating
+// the file and its tables if need be, and returns the new run's ID. It all goes in one
+// transaction, so a failed write leaves no partial run behind. The per-process times of a process
+// a run cut short did not finish are NULL.
+func appendRunDB(path string, run dbRun, processes []Process, opts Options, results []Result) (int64, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return 0, fmt.Errorf("%w: opening results database", err)
+	}
+	defer func() { _ = db.Close() }()
+	if _, err := db.Exec(dbSchema); err != nil {
+		return 0, fmt.Errorf("%w: creating tables in %s", err, path)
+	}
+	options, err := json.Marshal(opts)
+	if err != nil {
+		return 0, fmt.Errorf("%w: encoding options", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("%w: starting transaction", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	res, err := tx.Exec(`INSERT INTO runs (started, command, workload, processes, options) VALUES (?, ?, ?, ?, ?)`,
+		run.started.UTC().Format(time.RFC3339), strings.Join(run.command, " "), run.workload, len(processes), string(options))
+	if err != nil {
+		return 0, fmt.Errorf("%w: recording run", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%w: reading run ID", err)
+	}
+	metrics, err := tx.Prepare(`INSERT INTO process_metrics VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("%w: preparing metrics", err)
+	}
+	defer func() { _ = metrics.Close() }()
+	for _, r := range results {
+		if _, err := tx.Exec(`INSERT INTO results VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			id, r.Name, r.AveWait, r.AveTurnaround, r.AveResponse, r.AveThroughput, r.ContextSwitches, r.IdleTime, r.Utilization, r.Unfinished); err != nil {
+			return 0, fmt.Errorf("%w: recording %s result", err, r.Name)
+		}
+		times := processTimesFromGantt(processes, r.Gantt)
+		_, last := sliceBounds(len(processes), r.Gantt)
+		finished := finishedProcesses(processes, r.Gantt)
+		for i, p := range processes {
+			var wait, response, turnaround, exit any
+			if finished[i] {
+				wait, response, turnaround, exit = int64(times.wait[i]), int64(times.response[i]), int64(times.turnaround[i]), last[p.ProcessID-1]
+			}
+			if _, err := metrics.Exec(id, r.Name, p.ProcessID, p.ArrivalTime, p.BurstDuration, p.Priority, wait, response, turnaround, exit); err != nil {
+				return 0, fmt.Errorf("%w: recording %s metrics", err, r.Name)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%w: committing run to %s", err, path)
+	}
+
+	return id, nil
+}
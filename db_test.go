@@ -0,0 +1,65 @@
+package main
+
+import (
+	"database/sql"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_appendRunDB(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	var results []Result
+	for _, name := range []string{"fcfs", "rr"} {
+		results = append(results, runAlgorithm(*findAlgorithm(name), io.Discard, processes, Options{Quantum: 3}))
+	}
+	// a run cut short after process 1
+	cut := resultFromGantt(io.Discard, "Cut", processes, []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 6}})
+	cut.Name = "sjf"
+	results = append(results, cut)
+
+	path := filepath.Join(t.TempDir(), "results.sqlite")
+	run := dbRun{started: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), command: []string{"sim", "-db", path, "w.csv"}, workload: "w.csv"}
+	for want := int64(1); want <= 2; want++ {
+		id, err := appendRunDB(path, run, processes, Options{Quantum: 3}, results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != want {
+			t.Errorf("appendRunDB() run ID = %d, want %d", id, want)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	var options string
+	var count int
+	if err := db.QueryRow(`SELECT options, (SELECT COUNT(*) FROM process_metrics WHERE run_id = 2) FROM runs WHERE id = 2`).Scan(&options, &count); err != nil {
+		t.Fatal(err)
+	}
+	if options != `{"nonWorkConserving":false,"lookahead":0,"quantum":3}` || count != 6 {
+		t.Errorf("run 2 has options %s and %d process rows, want quantum 3 and 6 rows", options, count)
+	}
+	var wait float64
+	if err := db.QueryRow(`SELECT avg_wait FROM results WHERE run_id = 1 AND algorithm = 'rr'`).Scan(&wait); err != nil {
+		t.Fatal(err)
+	}
+	if wait != results[1].AveWait {
+		t.Errorf("rr avg_wait = %v, want %v", wait, results[1].AveWait)
+	}
+	var exit sql.NullInt64
+	if err := db.QueryRow(`SELECT exit FROM process_metrics WHERE run_id = 1 AND algorithm = 'sjf' AND pid = 2`).Scan(&exit); err != nil {
+		t.Fatal(err)
+	}
+	if exit.Valid {
+		t.Errorf("exit of an unfinished process = %d, want NULL", exit.Int64)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// cancelCheckInterval is how many events simulateEvents handles between checks of its context,
+// which is cheap but not free next to an event, and between reports of its progress.
+const cancelCheckInterval = 1024
+
+// sliceBudget is how many more gantt slices the runs sharing it may start. A hosted request gives
+// one to all of its algorithms, so a run stops as soon as the request's results would grow past
+// the limit instead of after it has built them.
+type sliceBudget struct {
+	left atomic.Int64
+}
+
+func newSliceBudget(slices int) *sliceBudget {
+	b := new(sliceBudget)
+	b.left.Store(int64(slices))
+
+	return b
+}
+
+// take claims one slice, reporting false once the budget is spent.
+func (b *sliceBudget) take() bool { return b.left.Add(-1) >= 0 }
+
+// spent reports whether a run was stopped for want of a slice.
+func (b *sliceBudget) spent() bool { return b.left.Load() < 0 }
+
+// sliceBudgetKey is the context key of the budget simulateEvents draws on, carried like the
+// progress hook.
+type sliceBudgetKey struct{}
+
+// withSliceBudget returns ctx carrying b, from which simulateEvents takes every slice it starts.
+func withSliceBudget(ctx context.Context, b *sliceBudget) context.Context {
+	return context.WithValue(ctx, sliceBudgetKey{}, b)
+}
+
+// sliceBudgetFrom returns the budget ctx carries, or nil.
+func sliceBudgetFrom(ctx context.Context) *sliceBudget {
+	b, _ := ctx.Value(sliceBudgetKey{}).(*sliceBudget)
+	return b
+}
+
+// readySet holds the workload indexes of processes waiting for the CPU, in the order a policy
+// dispatches them.
+type readySet interface {
+	len() int
+	push(i int)
+	// pop removes and returns the process to dispatch next; peek returns it without removing it.
+	// Neither may be called on an empty set.
+	pop() int
+	peek() int
+	// before reports whether a should be dispatched ahead of b, so that a preemptive policy
+	// hands the CPU to a when it arrives while b runs.
+	before(a, b int) bool
+}
+
+// simPolicy is what tells one scheduling discipline from another in simulateEvents. The engine
+// owns time and the order of events, and consults these hooks as they happen.
+type simPolicy struct {
+	// ready builds the ready set. remaining is indexed like the workload and kept current by the
+	// engine, so a set may order processes by it.
+	ready func(remaining []int64) readySet
+	// quantum is the most a process runs per dispatch before it is preempted, or 0 for no limit.
+	quantum int64
+	// charge is how long the CPU is held for a quantum slice that completed after run ticks; nil
+	// means run.
+	charge func(run, quantum int64) int64
+	// limit, when set, may cut the slice of process i shorter than quantum; 0 means no limit.
+	limit func(i int) int64
+	// checkpoint, when set, returns how many more ticks process i may run before the policy must
+	// look at it again, or 0 for no limit. Reaching it is handled like an arrival: the process
+	// keeps the CPU unless the ready set now orders another before it.
+	checkpoint func(i int) int64
+	// ran, when set, is told of every stretch of run ticks process i spends on the CPU.
+	ran func(i int, run int64)
+	// preemptedFirst requeues a process whose quantum expired ahead of any process arriving at
+	// that same instant, rather than behind it.
+	preemptedFirst bool
+	// workloadOrder queues processes in workload order rather than arrival order: each arrives
+	// no earlier than the one listed before it, as fcfs serves the file as one queue.
+	workloadOrder bool
+	// preemptive hands the CPU to an arriving process that the ready set orders before the
+	// running one, which goes back into the set.
+	preemptive bool
+	// idle may keep the CPU idle rather than dispatch next, returning the time to wait until.
+	// ready counts the ready processes and future holds those yet to arrive, in arrival order.
+	idle func(ready int, future []Process, next Process, now int64) (int64, bool)
+}
+
+// simulateEvents runs processes on one CPU under policy and returns the slices each one ran in.
+// It is a discrete-event loop: an arrival adds a process to the ready set, a dispatch hands the
+// CPU to the set's first process, and the running process's slice lasts until it completes, its
+// quantum expires or, under a preemptive policy, an arrival comes before it in the set's order.
+// Arrivals that do not preempt leave the slice running. A process that depends on others is held
+// on arrival until they have all completed, and joins the ready set then.
+//
+// Once ctx is done the loop stops where it is and returns the slices so far, a schedule that
+// leaves some processes unfinished. A progress hook in ctx (see withProgress) is told the time
+// as the loop goes, and a slice budget in ctx (see withSliceBudget) stops the loop the same way
+// once it runs out.
+func simulateEvents(ctx context.Context, processes []Process, policy simPolicy) []TimeSlice {
+	var (
+		now       int64
+		gantt     = make([]TimeSlice, 0, len(processes))
+		remaining = make([]int64, len(processes))
+		byArrival = make([]Process, len(processes))
+		arrivals  []int
+		next      int // arrivals[next] is the next process to arrive
+		ready     = policy.ready(remaining)
+		deps      = newDependencies(processes)
+		running   = -1
+		// sliceLeft is how long the running process may still run before it is preempted, or 0
+		// when nothing but completion or an arrival ends its slice
+		sliceLeft int64
+	)
+	for i := range processes {
+		remaining[i] = processes[i].BurstDuration
+	}
+	if policy.workloadOrder {
+		arrivals = make([]int, len(processes))
+		for i, p := range processes {
+			arrivals[i], byArrival[i] = i, p
+			if i > 0 {
+				byArrival[i].ArrivalTime = max(p.ArrivalTime, byArrival[i-1].ArrivalTime)
+			}
+		}
+	} else {
+		arrivals = arrivalOrder(processes)
+		for k, i := range arrivals {
+			byArrival[k] = processes[i]
+		}
+	}
+	// arrive handles the arrival events before until, and at until when inclusive
+	arrive := func(until int64, inclusive bool) {
+		for next < len(arrivals) && (byArrival[next].ArrivalTime < until || inclusive && byArrival[next].ArrivalTime == until) {
+			if deps.arrive(arrivals[next]) {
+				ready.push(arrivals[next])
+			}
+			next++
+		}
+	}
+
+	progress := progressFrom(ctx)
+	budget := sliceBudgetFrom(ctx)
+	for done, events := 0, 0; done < len(processes); events++ {
+		if events%cancelCheckInterval == 0 {
+			if ctx.Err() != nil {
+				break
+			}
+			if progress != nil {
+				progress(now)
+			}
+		}
+		arrive(now, true)
+		if running < 0 {
+			if ready.len() == 0 {
+				// nothing to dispatch until the next arrival
+				now = byArrival[next].ArrivalTime
+				continue
+			}
+			i := ready.peek()
+			if policy.idle != nil {
+				if until, ok := policy.idle(ready.len(), byArrival[next:], processes[i], now); ok {
+					now = until
+					continue
+				}
+			}
+			// dispatch
+			if budget != nil && !budget.take() {
+				break
+			}
+			ready.pop()
+			running, sliceLeft = i, policy.quantum
+			if policy.limit != nil {
+				if limit := policy.limit(i); limit > 0 && (sliceLeft == 0 || limit < sliceLeft) {
+					sliceLeft = limit
+				}
+			}
+			gantt = append(gantt, TimeSlice{PID: processes[i].ProcessID, Start: now, Stop: now})
+		}
+
+		i := running
+		run := remaining[i]
+		if sliceLeft > 0 {
+			run = min(run, sliceLeft)
+		}
+		if policy.preemptive && next < len(arrivals) {
+			run = min(run, byArrival[next].ArrivalTime-now)
+		}
+		if policy.checkpoint != nil {
+			if until := policy.checkpoint(i); until > 0 {
+				run = min(run, until)
+			}
+		}
+		slice := &gantt[len(gantt)-1]
+		slice.Stop += run
+		remaining[i] -= run
+		now += run
+		expired := false
+		if sliceLeft > 0 {
+			sliceLeft -= run
+			expired = sliceLeft == 0
+		}
+		if policy.ran != nil {
+			policy.ran(i, run)
+		}
+
+		switch {
+		case remaining[i] == 0:
+			// completion
+			done++
+			running = -1
+			for _, d := range deps.complete(i) {
+				ready.push(d)
+			}
+			if policy.quantum > 0 && policy.charge != nil {
+				now = slice.Start + policy.charge(slice.Stop-slice.Start, policy.quantum)
+			}
+		case expired:
+			// quantum expiry
+			arrive(now, !policy.preemptedFirst)
+			ready.push(i)
+			running = -1
+		default:
+			// an arrival or checkpoint, after which another process may come first
+			arrive(now, true)
+			if ready.len() > 0 && ready.before(ready.peek(), i) {
+				ready.push(i)
+				running = -1
+			}
+		}
+	}
+
+	return gantt
+}
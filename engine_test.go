@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_simulateEvents(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 1, Priority: 3},
+	}
+	fifo := func([]int64) readySet { return &ringQueue{} }
+	byPriority := func([]int64) readySet {
+		return newPriorityQueue(func(a, b int) bool { return processes[a].Priority < processes[b].Priority })
+	}
+	tests := []struct {
+		name   string
+		policy simPolicy
+		want   []TimeSlice
+	}{
+		{
+			name:   "arrival order",
+			policy: simPolicy{ready: fifo},
+			want:   []TimeSlice{{PID: 2, Start: 0, Stop: 4}, {PID: 1, Start: 4, Stop: 7}, {PID: 3, Start: 7, Stop: 8}},
+		},
+		{
+			name:   "workload order waits for the first listed",
+			policy: simPolicy{ready: fifo, workloadOrder: true},
+			want:   []TimeSlice{{PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 5, Stop: 9}, {PID: 3, Start: 9, Stop: 10}},
+		},
+		{
+			name:   "preemptive keeps one slice across arrivals that do not preempt",
+			policy: simPolicy{ready: byPriority, preemptive: true},
+			want:   []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 5, Stop: 7}, {PID: 3, Start: 7, Stop: 8}},
+		},
+		{
+			name:   "quantum slices stay separate",
+			policy: simPolicy{ready: fifo, quantum: 2},
+			want: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 6},
+				{PID: 3, Start: 6, Stop: 7}, {PID: 1, Start: 7, Stop: 8},
+			},
+		},
+		{
+			name:   "preempted first at the same instant",
+			policy: simPolicy{ready: fifo, quantum: 2, preemptedFirst: true},
+			// 2 is preempted at 2 just as 1 arrives, so it goes first
+			want: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6},
+				{PID: 3, Start: 6, Stop: 7}, {PID: 1, Start: 7, Stop: 8},
+			},
+		},
+		{
+			name: "idle hook",
+			policy: simPolicy{ready: fifo, idle: func(_ int, future []Process, _ Process, now int64) (int64, bool) {
+				if now == 0 {
+					return future[0].ArrivalTime, true
+				}
+				return 0, false
+			}},
+			want: []TimeSlice{{PID: 2, Start: 2, Stop: 6}, {PID: 1, Start: 6, Stop: 9}, {PID: 3, Start: 9, Stop: 10}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := simulateEvents(context.Background(), processes, tt.policy)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("simulateEvents() = %v, want %v", got, tt.want)
+			}
+			if err := checkGantt(processes, got); err != nil {
+				t.Errorf("simulateEvents() gave an invalid schedule: %v", err)
+			}
+		})
+	}
+}
+
+func Test_simulateEvents_cancelled(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := runAlgorithm(*findAlgorithm("rr"), io.Discard, processes, Options{}.WithContext(ctx))
+	if len(result.Gantt) != 0 || result.Unfinished != 2 {
+		t.Errorf("runAlgorithm() of a cancelled run = %v with %d unfinished, want no slices and 2 unfinished", result.Gantt, result.Unfinished)
+	}
+	if result.AveWait != 0 || result.AveThroughput != 0 {
+		t.Errorf("runAlgorithm() of a cancelled run averaged wait %v, throughput %v, want 0", result.AveWait, result.AveThroughput)
+	}
+}
+
+func Test_simulateEvents_sliceBudget(t *testing.T) {
+	t.Parallel()
+	// rr with quantum 1 alternates the processes every tick, for 100 slices
+	processes := []Process{{ProcessID: 1, BurstDuration: 50}, {ProcessID: 2, BurstDuration: 50}}
+	budget := newSliceBudget(10)
+	ctx := withSliceBudget(context.Background(), budget)
+	result := runAlgorithm(*findAlgorithm("rr"), io.Discard, processes, Options{Quantum: 1}.WithContext(ctx))
+	if len(result.Gantt) != 10 || result.Unfinished != 2 {
+		t.Errorf("runAlgorithm() = %d slices with %d unfinished, want 10 and 2", len(result.Gantt), result.Unfinished)
+	}
+	if !budget.spent() {
+		t.Error("budget.spent() = false after the run was stopped for want of a slice")
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sort"
+)
+
+type (
+	// envProcess is a ready process as an agent sees it. Waited is the time it has spent
+	// waiting since it arrived.
+	envProcess struct {
+		PID       int64 `json:"pid"`
+		Arrival   int64 `json:"arrival"`
+		Burst     int64 `json:"burst"`
+		Remaining int64 `json:"remaining"`
+		Priority  int64 `json:"priority"`
+		Waited    int64 `json:"waited"`
+	}
+	// envObservation is the state an agent picks its next action from: the ready queue in the
+	// order processes joined it, and how many processes have yet to arrive or have completed.
+	envObservation struct {
+		Time      int64        `json:"time"`
+		Ready     []envProcess `json:"ready"`
+		Pending   int          `json:"pending"`
+		Completed int          `json:"completed"`
+	}
+	// envRequest is one line of the environment protocol: reset, with the processes of a new
+	// episode or none to reuse the default workload, or step, dispatching the process Action.
+	envRequest struct {
+		Op        string    `json:"op"`
+		Processes []Process `json:"processes,omitempty"`
+		Action    int64     `json:"action,omitempty"`
+	}
+	// envResponse answers a request. Result, the schedule and metrics of the episode, is set
+	// once it is done.
+	envResponse struct {
+		Observation *envObservation `json:"observation,omitempty"`
+		Reward      float64         `json:"reward"`
+		Done        bool            `json:"done"`
+		Result      *Result         `json:"result,omitempty"`
+		Error       string          `json:"error,omitempty"`
+	}
+)
+
+// schedulingEnv is a Gym-style episode over one workload: each step dispatches a ready process
+// the agent picks, which runs for a quantum, or to completion when quantum is 0, and earns minus
+// the waiting time that accrued meanwhile, so an episode's return is minus the total wait. The
+// clock skips idle time to the next arrival on its own.
+type schedulingEnv struct {
+	processes  []Process
+	quantum    int64
+	time       int64
+	order      []int // workload indexes by arrival
+	next       int   // first process of order yet to arrive
+	ready      []int
+	remaining  []int64
+	completion []int64 // 0 until the process completes
+	completed  int
+	deps       *dependencies
+	gantt      []TimeSlice
+}
+
+func newSchedulingEnv(processes []Process, quantum int64) *schedulingEnv {
+	e := &schedulingEnv{
+		processes:  processes,
+		quantum:    quantum,
+		order:      make([]int, len(processes)),
+		remaining:  make([]int64, len(processes)),
+		completion: make([]int64, len(processes)),
+		deps:       newDependencies(processes),
+	}
+	for i, p := range processes {
+		e.order[i] = i
+		e.remaining[i] = p.BurstDuration
+	}
+	sort.SliceStable(e.order, func(a, b int) bool { return processes[e.order[a]].ArrivalTime < processes[e.order[b]].ArrivalTime })
+	e.settle()
+
+	return e
+}
+
+// admit moves the processes that have arrived by now into the ready queue, or holds them until
+// their predecessors complete.
+func (e *schedulingEnv) admit() {
+	for ; e.next < len(e.order) && e.processes[e.order[e.next]].ArrivalTime <= e.time; e.next++ {
+		if i := e.order[e.next]; e.deps.arrive(i) {
+			e.ready = append(e.ready, i)
+		}
+	}
+}
+
+// settle admits arrivals and, while nothing is ready but the episode is not over, jumps the clock
+// to the next arrival.
+func (e *schedulingEnv) settle() {
+	e.admit()
+	for len(e.ready) == 0 && e.next < len(e.order) {
+		e.time = max(e.time, e.processes[e.order[e.next]].ArrivalTime)
+		e.admit()
+	}
+}
+
+func (e *schedulingEnv) done() bool { return e.completed == len(e.processes) }
+
+// accruedWait is the waiting time every process that has arrived has accumulated so far.
+func (e *schedulingEnv) accruedWait() int64 {
+	var total int64
+	for k := 0; k < e.next; k++ {
+		i := e.order[k]
+		p := e.processes[i]
+		end := e.time
+		if e.completion[i] != 0 {
+			end = e.completion[i]
+		}
+		total += end - p.ArrivalTime - (p.BurstDuration - e.remaining[i])
+	}
+
+	return total
+}
+
+func (e *schedulingEnv) observation() envObservation {
+	obs := envObservation{Time: e.time, Ready: make([]envProcess, len(e.ready)), Pending: len(e.order) - e.next, Completed: e.completed}
+	for k, i := range e.ready {
+		p := e.processes[i]
+		obs.Ready[k] = envProcess{
+			PID:       p.ProcessID,
+			Arrival:   p.ArrivalTime,
+			Burst:     p.BurstDuration,
+			Remaining: e.remaining[i],
+			Priority:  p.Priority,
+			Waited:    e.time - p.ArrivalTime - (p.BurstDuration - e.remaining[i]),
+		}
+	}
+
+	return obs
+}
+
+// step dispatches the ready process pid and returns the reward. A preempted process rejoins the
+// back of the ready queue behind anything that arrived while it ran.
+func (e *schedulingEnv) step(pid int64) (float64, error) {
+	if e.done() {
+		return 0, fmt.Errorf("%w: the episode is over; reset to start another", ErrInvalidArgs)
+	}
+	k := -1
+	for j, i := range e.ready {
+		if e.processes[i].ProcessID == pid {
+			k = j
+		}
+	}
+	if k < 0 {
+		return 0, fmt.Errorf("%w: process %d is not ready", ErrInvalidArgs, pid)
+	}
+	i := e.ready[k]
+	e.ready = append(e.ready[:k], e.ready[k+1:]...)
+
+	before := e.accruedWait()
+	run := e.remaining[i]
+	if e.quantum > 0 {
+		run = min(run, e.quantum)
+	}
+	if n := len(e.gantt); n > 0 && e.gantt[n-1].PID == pid && e.gantt[n-1].Stop == e.time {
+		e.gantt[n-1].Stop += run
+	} else {
+		e.gantt = append(e.gantt, TimeSlice{PID: pid, Start: e.time, Stop: e.time + run})
+	}
+	e.time += run
+	e.remaining[i] -= run
+	e.admit()
+	if e.remaining[i] == 0 {
+		e.completion[i] = e.time
+		e.completed++
+		e.ready = append(e.ready, e.deps.complete(i)...)
+	} else {
+		e.ready = append(e.ready, i)
+	}
+	reward := float64(before - e.accruedWait())
+	e.settle()
+
+	return reward, nil
+}
+
+// result reports the finished episode's schedule like an algorithm's.
+func (e *schedulingEnv) result() Result {
+	r := resultFromGantt(io.Discard, "Agent", e.processes, e.gantt)
+	r.Name = "agent"
+	deriveMetrics(&r, e.processes)
+
+	return r
+}
+
+// serveEnv runs the environment protocol over one connection: a JSON request per line in, a
+// JSON response per line out, until r ends. Bad requests are answered with an error and the
+// session goes on.
+func serveEnv(r io.Reader, w io.Writer, defaults []Process, quantum int64) error {
+	dec, enc := json.NewDecoder(r), json.NewEncoder(w)
+	var env *schedulingEnv
+	for {
+		var req envRequest
+		if err := dec.Decode(&req); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			_ = enc.Encode(envResponse{Error: fmt.Sprintf("decoding request: %v", err)})
+			return fmt.Errorf("%w: decoding environment request", err)
+		}
+
+		var resp envResponse
+		var err error
+		switch req.Op {
+		case "reset":
+			processes := req.Processes
+			if len(processes) == 0 {
+				processes = defaults
+			}
+			if err = validateProcesses(processes); err == nil {
+				env = newSchedulingEnv(processes, quantum)
+			}
+		case "step":
+			if env == nil {
+				err = fmt.Errorf("%w: reset before the first step", ErrInvalidArgs)
+				break
+			}
+			resp.Reward, err = env.step(req.Action)
+		default:
+			err = fmt.Errorf("%w: unknown op %q, want reset or step", ErrInvalidArgs, req.Op)
+		}
+		if err != nil {
+			resp = envResponse{Error: err.Error()}
+		} else {
+			obs := env.observation()
+			resp.Observation, resp.Done = &obs, env.done()
+			if resp.Done {
+				result := env.result()
+				resp.Result = &result
+			}
+		}
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("%w: writing environment response", err)
+		}
+	}
+}
+
+// runEnv is the env subcommand: it exposes the simulator as a reinforcement-learning
+// environment, speaking the protocol of serveEnv on stdin and stdout with -stdio or to every
+// client of a local TCP address otherwise.
+func runEnv(args []string) error {
+	fs := flag.NewFlagSet("env", flag.ExitOnError)
+	addr := fs.String("addr", "127.0.0.1:5555", "TCP `address` to listen on")
+	stdio := fs.Bool("stdio", false, "serve a single session on stdin and stdout instead of listening")
+	quantum := fs.Int64("quantum", 0, "`ticks` a dispatched process runs before the agent decides again (0 runs it to completion)")
+	delim := fs.String("delimiter", ",", "field `separator` of the workload file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return fmt.Errorf("%w: usage: env [-addr host:port | -stdio] [-quantum ticks] [workload.csv]", ErrInvalidArgs)
+	}
+	if *quantum < 0 {
+		return fmt.Errorf("%w: -quantum must not be negative", ErrInvalidArgs)
+	}
+	var defaults []Process
+	if fs.NArg() == 1 {
+		delimiter, err := parseDelimiter(*delim)
+		if err != nil {
+			return err
+		}
+		if defaults, err = loadWorkloadFile(fs.Arg(0), delimiter); err != nil {
+			return err
+		}
+	}
+	if *stdio {
+		return serveEnv(os.Stdin, os.Stdout, defaults, *quantum)
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("%w: listening for environment clients", err)
+	}
+	log.Printf("environment listening on %s", ln.Addr())
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return fmt.Errorf("%w: accepting environment client", err)
+		}
+		go func() {
+			defer func() { _ = conn.Close() }()
+			if err := serveEnv(conn, conn, defaults, *quantum); err != nil {
+				log.Print(err)
+			}
+		}()
+	}
+}
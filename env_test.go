@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_schedulingEnv(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 10, BurstDuration: 1},
+	}
+	tests := []struct {
+		name    string
+		quantum int64
+		actions []int64
+		rewards []float64
+		gantt   []TimeSlice
+	}{
+		{
+			name:    "run to completion",
+			actions: []int64{1, 2, 3},
+			rewards: []float64{-3, 0, 0},
+			gantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}, {PID: 3, Start: 10, Stop: 11}},
+		},
+		{
+			name:    "quantum",
+			quantum: 2,
+			actions: []int64{1, 2, 1, 3},
+			rewards: []float64{-1, -2, 0, 0},
+			gantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}, {PID: 3, Start: 10, Stop: 11}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			env := newSchedulingEnv(processes, tt.quantum)
+			var rewards []float64
+			for _, pid := range tt.actions {
+				reward, err := env.step(pid)
+				if err != nil {
+					t.Fatalf("step(%d) error = %v", pid, err)
+				}
+				rewards = append(rewards, reward)
+			}
+			if !env.done() {
+				t.Fatal("episode not done after every process ran")
+			}
+			if !reflect.DeepEqual(rewards, tt.rewards) {
+				t.Errorf("rewards = %v, want %v", rewards, tt.rewards)
+			}
+			if !reflect.DeepEqual(env.gantt, tt.gantt) {
+				t.Errorf("gantt = %v, want %v", env.gantt, tt.gantt)
+			}
+		})
+	}
+}
+
+func Test_schedulingEnv_invalidAction(t *testing.T) {
+	t.Parallel()
+	env := newSchedulingEnv([]Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 2, ArrivalTime: 5, BurstDuration: 1}}, 0)
+	if _, err := env.step(2); err == nil {
+		t.Error("step() of a process yet to arrive succeeded")
+	}
+	if _, err := env.step(1); err != nil {
+		t.Fatalf("step(1) error = %v", err)
+	}
+	if obs := env.observation(); obs.Time != 5 || len(obs.Ready) != 1 || obs.Ready[0].PID != 2 {
+		t.Errorf("observation() = %+v, want time 5 and process 2 ready", obs)
+	}
+}
+
+func Test_serveEnv(t *testing.T) {
+	t.Parallel()
+	defaults := []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 1}}
+	in := strings.Join([]string{
+		`{"op":"step","action":1}`,
+		`{"op":"reset"}`,
+		`{"op":"step","action":2}`,
+		`{"op":"step","action":1}`,
+		`{"op":"bogus"}`,
+	}, "\n")
+	var out strings.Builder
+	if err := serveEnv(strings.NewReader(in), &out, defaults, 0); err != nil {
+		t.Fatalf("serveEnv() error = %v", err)
+	}
+	var responses []envResponse
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		var resp envResponse
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("decoding %q: %v", scanner.Text(), err)
+		}
+		responses = append(responses, resp)
+	}
+	if len(responses) != 5 {
+		t.Fatalf("got %d responses, want 5", len(responses))
+	}
+	if responses[0].Error == "" || responses[4].Error == "" {
+		t.Error("step before reset and an unknown op did not report errors")
+	}
+	if obs := responses[1].Observation; obs == nil || len(obs.Ready) != 2 || responses[1].Done {
+		t.Errorf("reset response = %+v, want both processes ready", responses[1])
+	}
+	if responses[2].Reward != -1 || responses[2].Done {
+		t.Errorf("first step response = %+v, want reward -1", responses[2])
+	}
+	last := responses[3]
+	if !last.Done || last.Result == nil {
+		t.Fatalf("final step response = %+v, want done with a result", last)
+	}
+	if last.Result.AveWait != 0.5 {
+		t.Errorf("result average wait = %v, want 0.5", last.Result.AveWait)
+	}
+}
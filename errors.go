@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+)
+
+// jsonError is the structured form of a fatal error written with -format json.
+type jsonError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Row     int    `json:"row,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// exitWithError reports err and exits non-zero: as a JSON object on w when format is json,
+// otherwise through the standard logger.
+func exitWithError(w io.Writer, format string, err error) {
+	if format != "json" {
+		log.Fatal(err)
+	}
+	_ = json.NewEncoder(w).Encode(newJSONError(err))
+	os.Exit(1)
+}
+
+// newJSONError classifies err into a stable code, pulling the input position out of it when
+// the error came from parsing.
+func newJSONError(err error) jsonError {
+	e := jsonError{Code: "internal", Message: err.Error()}
+	var (
+		parseErr    *csv.ParseError
+		fieldErr    *fieldError
+		workloadErr *workloadError
+	)
+	switch {
+	case errors.As(err, &parseErr):
+		e.Code, e.Row, e.Column = "invalid_csv", parseErr.Line, parseErr.Column
+	case errors.As(err, &fieldErr):
+		e.Code, e.Row, e.Column = "invalid_csv", fieldErr.Line, fieldErr.Column
+	case errors.As(err, &workloadErr):
+		e.Code, e.Row = "invalid_workload", workloadErr.Row
+	case errors.Is(err, ErrInvalidWorkload):
+		e.Code = "invalid_workload"
+	case errors.Is(err, ErrInvalidSchedule):
+		e.Code = "invalid_schedule"
+	case errors.Is(err, ErrResourceLimit):
+		e.Code = "resource_limit"
+	case errors.Is(err, ErrInvalidArgs):
+		e.Code = "invalid_args"
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrPermission):
+		e.Code = "file_error"
+	}
+
+	return e
+}
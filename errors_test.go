@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_newJSONError(t *testing.T) {
+	t.Parallel()
+	_, csvErr := loadProcesses(strings.NewReader("1,5,0\n2,9\n"))
+	tests := []struct {
+		name string
+		err  error
+		want jsonError
+	}{
+		{
+			name: "invalid args",
+			err:  fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs),
+			want: jsonError{Code: "invalid_args", Message: "invalid arguments: must give a scheduling file to process"},
+		},
+		{
+			name: "missing file",
+			err:  fmt.Errorf("%w: error opening scheduling file", &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}),
+			want: jsonError{Code: "file_error", Message: "open x: file does not exist: error opening scheduling file"},
+		},
+		{
+			name: "bad CSV",
+			err:  csvErr,
+			want: jsonError{Code: "invalid_csv", Message: csvErr.Error(), Row: 2, Column: 1},
+		},
+		{
+			name: "other",
+			err:  errors.New("boom"),
+			want: jsonError{Code: "internal", Message: "boom"},
+		},
+		{
+			name: "bad field",
+			err:  &fieldError{Line: 3, Column: 2, Name: "burst", Value: "x", Err: strconv.ErrSyntax},
+			want: jsonError{Code: "invalid_csv", Message: `line 3, column 2 (burst): invalid value "x": invalid syntax`, Row: 3, Column: 2},
+		},
+		{
+			name: "resource limit",
+			err:  fmt.Errorf("%w: simulation ran past its -timeout", ErrResourceLimit),
+			want: jsonError{Code: "resource_limit", Message: "resource limit exceeded: simulation ran past its -timeout"},
+		},
+		{
+			name: "invalid workload",
+			err:  validateProcesses([]Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 1, BurstDuration: 1}}),
+			want: jsonError{Code: "invalid_workload", Message: "invalid workload: row 2: duplicate process ID 1 (first on row 1)", Row: 2},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := newJSONError(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newJSONError() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// execConfig says how the exec subcommand maps ticks to wall-clock time and which policy it
+	// enforces.
+	execConfig struct {
+		algo    string
+		quantum int64
+		tick    time.Duration
+		shell   string
+		plain   bool
+	}
+	// execStats compares one real command with the process it stood for. Overran is how long the
+	// command kept running after its last scheduled slice, when its burst was too short.
+	execStats struct {
+		Process
+		Command        string
+		SimTurnaround  time.Duration
+		RealTurnaround time.Duration
+		CPU            time.Duration
+		Overran        time.Duration
+		ExitCode       int
+	}
+	// execJob is a started command and the channel closed when it exits.
+	execJob struct {
+		cmd    *exec.Cmd
+		done   chan struct{}
+		exited time.Time
+	}
+)
+
+// loadCommands reads the command column of a workload that has a header naming pid and command,
+// and returns the command of each of processes, indexed like them.
+func loadCommands(r io.Reader, delimiter rune, processes []Process) ([]string, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = delimiter
+	cr.Comment = '#'
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV header", err)
+	}
+	pidPos, cmdPos := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "pid":
+			pidPos = i
+		case "command":
+			cmdPos = i
+		}
+	}
+	if pidPos < 0 || cmdPos < 0 {
+		return nil, fmt.Errorf("%w: exec needs a header naming the pid and command columns", ErrInvalidArgs)
+	}
+	byPID := make(map[int64]string, len(processes))
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		pid, err := strconv.ParseInt(strings.TrimSpace(row[pidPos]), 10, 64)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, &fieldError{Line: line, Column: pidPos + 1, Name: "pid", Value: row[pidPos], Err: err}
+		}
+		byPID[pid] = strings.TrimSpace(row[cmdPos])
+	}
+	commands := make([]string, len(processes))
+	for i, p := range processes {
+		if commands[i] = byPID[p.ProcessID]; commands[i] == "" {
+			return nil, fmt.Errorf("%w: process %d has no command", ErrInvalidArgs, p.ProcessID)
+		}
+	}
+
+	return commands, nil
+}
+
+// execute runs commands, one per process, under the schedule gantt scaled to cfg.tick per tick:
+// each command starts at its first slice, is resumed with SIGCONT when a slice starts and paused
+// with SIGSTOP when it ends, so only the scheduled command runs. A command that exits early leaves
+// the rest of its slice idle; one still running after its last slice is resumed alone, in the
+// order the last slices ended, until it exits.
+func execute(processes []Process, commands []string, gantt []TimeSlice, cfg execConfig) ([]execStats, error) {
+	jobs := make([]*execJob, len(processes))
+	defer func() {
+		for _, j := range jobs {
+			if j != nil && !isDone(j) {
+				_ = killGroup(j.cmd.Process)
+				<-j.done
+			}
+		}
+	}()
+
+	epoch := time.Now()
+	at := func(tick int64) time.Time { return epoch.Add(time.Duration(tick) * cfg.tick) }
+	for _, s := range gantt {
+		i := int(s.PID - 1)
+		time.Sleep(time.Until(at(s.Start)))
+		switch {
+		case jobs[i] == nil:
+			j, err := startJob(cfg.shell, commands[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w: starting process %d", err, s.PID)
+			}
+			jobs[i] = j
+		case isDone(jobs[i]):
+			continue
+		default:
+			if err := signalGroup(jobs[i].cmd.Process, false); err != nil {
+				return nil, fmt.Errorf("%w: resuming process %d", err, s.PID)
+			}
+		}
+		timer := time.NewTimer(time.Until(at(s.Stop)))
+		select {
+		case <-jobs[i].done:
+			timer.Stop()
+			continue
+		case <-timer.C:
+		}
+		if err := signalGroup(jobs[i].cmd.Process, true); err != nil {
+			return nil, fmt.Errorf("%w: pausing process %d", err, s.PID)
+		}
+	}
+
+	// let the commands that outlived their bursts finish, one at a time
+	overran := make([]time.Duration, len(processes))
+	for _, i := range lastSliceOrder(gantt) {
+		j := jobs[i]
+		if isDone(j) {
+			continue
+		}
+		start := time.Now()
+		if err := signalGroup(j.cmd.Process, false); err != nil {
+			return nil, fmt.Errorf("%w: resuming process %d", err, processes[i].ProcessID)
+		}
+		<-j.done
+		overran[i] = time.Since(start)
+	}
+
+	completion := make(map[int64]int64, len(processes))
+	for _, s := range gantt {
+		completion[s.PID] = s.Stop
+	}
+	stats := make([]execStats, len(processes))
+	for i, p := range processes {
+		stats[i] = execStats{
+			Process:       p,
+			Command:       commands[i],
+			SimTurnaround: time.Duration(completion[p.ProcessID]-p.ArrivalTime) * cfg.tick,
+			Overran:       overran[i],
+		}
+		if j := jobs[i]; j != nil {
+			state := j.cmd.ProcessState
+			stats[i].RealTurnaround = j.exited.Sub(at(p.ArrivalTime))
+			stats[i].CPU = state.UserTime() + state.SystemTime()
+			stats[i].ExitCode = state.ExitCode()
+		}
+	}
+
+	return stats, nil
+}
+
+// lastSliceOrder returns the indexes of the processes in gantt in the order their last slices end.
+func lastSliceOrder(gantt []TimeSlice) []int {
+	seen := make(map[int64]bool)
+	var order []int
+	for k := len(gantt) - 1; k >= 0; k-- {
+		if pid := gantt[k].PID; !seen[pid] {
+			seen[pid] = true
+			order = append([]int{int(pid - 1)}, order...)
+		}
+	}
+
+	return order
+}
+
+// startJob starts command through shell in a process group of its own, so pausing it also pauses
+// whatever it spawns.
+func startJob(shell, command string) (*execJob, error) {
+	cmd := exec.Command(shell, "-c", command)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	prepareCommand(cmd)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	j := &execJob{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		_ = cmd.Wait()
+		j.exited = time.Now()
+		close(j.done)
+	}()
+
+	return j, nil
+}
+
+func isDone(j *execJob) bool {
+	select {
+	case <-j.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func outputExec(w io.Writer, name string, stats []execStats) {
+	_, _ = fmt.Fprintf(w, "%s on real commands\n", name)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Command", "Turnaround (simulated)", "Turnaround (real)", "CPU", "Overran", "Exit"})
+	for _, s := range stats {
+		table.Append([]string{
+			fmt.Sprint(s.ProcessID),
+			s.Command,
+			s.SimTurnaround.String(),
+			s.RealTurnaround.Round(time.Millisecond).String(),
+			s.CPU.Round(time.Millisecond).String(),
+			s.Overran.Round(time.Millisecond).String(),
+			fmt.Sprint(s.ExitCode),
+		})
+	}
+	table.Render()
+}
+
+func outputPlainExec(w io.Writer, name string, stats []execStats) {
+	for _, s := range stats {
+		_, _ = fmt.Fprintf(w, "exec: algorithm %s pid %d simulated %s real %s cpu %s overran %s exit %d\n",
+			name, s.ProcessID, s.SimTurnaround, s.RealTurnaround.Round(time.Millisecond),
+			s.CPU.Round(time.Millisecond), s.Overran.Round(time.Millisecond), s.ExitCode)
+	}
+}
+
+// runExec is the experimental exec subcommand: it schedules a workload whose command column names
+// a shell command per process, then enforces that schedule on the real commands and compares
+// their wall-clock turnaround with the simulated one.
+func runExec(args []string) error {
+	cfg := execConfig{algo: "rr", quantum: defaultQuantum, tick: 100 * time.Millisecond, shell: "/bin/sh"}
+	fs := flag.NewFlagSet("exec", flag.ExitOnError)
+	fs.StringVar(&cfg.algo, "algo", cfg.algo, "`name` of the algorithm whose schedule is enforced")
+	fs.Int64Var(&cfg.quantum, "quantum", cfg.quantum, "round-robin time slice in `ticks`")
+	fs.DurationVar(&cfg.tick, "tick", cfg.tick, "wall-clock `duration` of a tick")
+	fs.StringVar(&cfg.shell, "shell", cfg.shell, "`path` of the shell that runs each command with -c")
+	fs.BoolVar(&cfg.plain, "plain", cfg.plain, "print labeled key: value lines instead of a table")
+	delim := fs.String("delimiter", ",", "field `separator` of the workload file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: exec [-algo name] [-quantum ticks] [-tick duration] workload.csv", ErrInvalidArgs)
+	}
+	if !canPause {
+		return fmt.Errorf("%w: exec pauses commands with SIGSTOP, which this platform does not have", ErrInvalidArgs)
+	}
+	a := findAlgorithm(cfg.algo)
+	switch {
+	case a == nil:
+		return fmt.Errorf("%w: unknown algorithm %q", ErrInvalidArgs, cfg.algo)
+	case cfg.tick <= 0:
+		return fmt.Errorf("%w: -tick must be positive", ErrInvalidArgs)
+	case cfg.quantum < 1:
+		return fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs)
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		return err
+	}
+	processes, err := loadWorkloadFile(fs.Arg(0), delimiter)
+	if err != nil {
+		return err
+	}
+	r, closeIn, err := openInput(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%w: error opening scheduling file", err)
+	}
+	defer func() { _ = closeIn() }()
+	commands, err := loadCommands(r, delimiter, processes)
+	if err != nil {
+		return err
+	}
+
+	result, err := runAlgorithmChecked(*a, io.Discard, processes, Options{Quantum: cfg.quantum})
+	if err != nil {
+		return err
+	}
+	stats, err := execute(processes, commands, result.Gantt, cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.plain {
+		outputPlainExec(os.Stdout, a.name, stats)
+	} else {
+		outputExec(os.Stdout, a.name, stats)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// canPause reports whether this platform can pause and resume commands for the exec subcommand.
+const canPause = false
+
+func prepareCommand(*exec.Cmd) {}
+
+func signalGroup(*os.Process, bool) error {
+	return fmt.Errorf("%w: pausing commands needs SIGSTOP", ErrInvalidArgs)
+}
+
+func killGroup(p *os.Process) error {
+	return p.Kill()
+}
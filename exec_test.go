@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadCommands(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1}, {ProcessID: 2}}
+	tests := []struct {
+		name    string
+		in      string
+		want    []string
+		wantErr error
+	}{
+		{name: "any column order", in: "command,burst,pid,arrival\nsleep 1,2,2,0\n\"sh -c 'echo, hi'\",1,1,0\n", want: []string{"sh -c 'echo, hi'", "sleep 1"}},
+		{name: "no command column", in: "pid,burst,arrival\n1,2,0\n2,1,0\n", wantErr: ErrInvalidArgs},
+		{name: "blank command", in: "pid,burst,arrival,command\n1,2,0,true\n2,1,0, \n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadCommands(strings.NewReader(tt.in), ',', processes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadCommands() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadCommands() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
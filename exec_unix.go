@@ -0,0 +1,41 @@
+//go:build unix
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// canPause reports whether this platform can pause and resume commands for the exec subcommand.
+const canPause = true
+
+func prepareCommand(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// signalGroup pauses (SIGSTOP) or resumes (SIGCONT) the process group p leads. A group that has
+// already exited is not an error.
+func signalGroup(p *os.Process, stop bool) error {
+	sig := syscall.SIGCONT
+	if stop {
+		sig = syscall.SIGSTOP
+	}
+
+	return killGroupWith(p, sig)
+}
+
+// killGroup kills the process group p leads.
+func killGroup(p *os.Process) error {
+	return killGroupWith(p, syscall.SIGKILL)
+}
+
+func killGroupWith(p *os.Process, sig syscall.Signal) error {
+	if err := syscall.Kill(-p.Pid, sig); err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+//go:build unix
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_execute(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}
+	// 1 outlives its burst, 2 finishes early and 3 fails
+	commands := []string{"sleep 0.2", "true", "exit 3"}
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 4}, {PID: 3, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 7}}
+	cfg := execConfig{tick: 20 * time.Millisecond, shell: "/bin/sh"}
+
+	stats, err := execute(processes, commands, gantt, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stats[0].SimTurnaround; got != 80*time.Millisecond {
+		t.Errorf("P1 simulated turnaround = %v, want 80ms", got)
+	}
+	// a sleep runs on wall-clock time even while paused, so P1 ends near 200ms, well after its
+	// last slice ends at 80ms
+	if stats[0].Overran < 50*time.Millisecond || stats[0].RealTurnaround < 200*time.Millisecond {
+		t.Errorf("P1 overran %v with real turnaround %v, want it to run on past its burst", stats[0].Overran, stats[0].RealTurnaround)
+	}
+	if stats[1].Overran != 0 || stats[1].RealTurnaround >= stats[1].SimTurnaround {
+		t.Errorf("P2 overran %v with real turnaround %v, want it done inside its first slice", stats[1].Overran, stats[1].RealTurnaround)
+	}
+	if stats[2].ExitCode != 3 {
+		t.Errorf("P3 exit code = %d, want 3", stats[2].ExitCode)
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// tCritical95 holds the two-sided 95% critical values of Student's t for 1 to 30 degrees of
+// freedom; beyond that the normal 1.96 is close enough.
+var tCritical95 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// experimentMetrics are the per-run measurements an experiment summarizes.
+var experimentMetrics = []struct {
+	name  string
+	value func(Result) float64
+}{
+	{"wait", func(r Result) float64 { return r.AveWait }},
+	{"turnaround", func(r Result) float64 { return r.AveTurnaround }},
+	{"response", func(r Result) float64 { return r.AveResponse }},
+	{"switches", func(r Result) float64 { return float64(r.ContextSwitches) }},
+	{"utilization", func(r Result) float64 { return r.Utilization }},
+}
+
+type (
+	experimentOptions struct {
+		gen     generateOptions
+		runs    int
+		algo    string
+		quantum int64
+		plain   bool
+		html    string
+	}
+	// estimate is a metric's mean over an experiment's runs with the half-width of its 95%
+	// confidence interval.
+	estimate struct {
+		mean, half float64
+	}
+	// experimentRow is one algorithm's estimates, in experimentMetrics order.
+	experimentRow struct {
+		name      string
+		estimates []estimate
+	}
+)
+
+func experimentFlags(opts *experimentOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet("experiment", flag.ExitOnError)
+	addGeneratorFlags(fs, &opts.gen)
+	fs.IntVar(&opts.runs, "runs", opts.runs, "number of random workloads to generate and schedule")
+	fs.StringVar(&opts.algo, "algo", opts.algo, "comma-separated `names` of the algorithms to run (default all)")
+	fs.Int64Var(&opts.quantum, "quantum", opts.quantum, "round-robin time slice in `ticks`")
+	fs.BoolVar(&opts.plain, "plain", opts.plain, "print labeled key: value lines instead of a table")
+	fs.StringVar(&opts.html, "html", opts.html, "write an HTML report per workload and algorithm into `dir`, with an index.html summarizing them in a sortable table")
+
+	return fs
+}
+
+// parseExperimentArgs parses experiment's flags, which include every generate flag but -o.
+func parseExperimentArgs(args []string) (experimentOptions, error) {
+	opts := experimentOptions{gen: generateOptions{cfg: defaultGeneratorConfig}, runs: 100, quantum: defaultQuantum}
+	// the generator options parseProfileArgs resets live in opts, so build the flags over all of it
+	err := parseProfileArgs(args, &opts.gen, func(*generateOptions) *flag.FlagSet { return experimentFlags(&opts) })
+	switch {
+	case err != nil:
+		return opts, err
+	case opts.runs < 2:
+		return opts, fmt.Errorf("%w: -runs must be at least 2 for a confidence interval", ErrInvalidArgs)
+	case opts.quantum < 1:
+		return opts, fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs)
+	}
+
+	return opts, nil
+}
+
+// runExperiment schedules -runs random workloads drawn from the generator flags with each
+// algorithm and reports every metric's mean with a 95% confidence interval.
+func runExperiment(args []string) error {
+	opts, err := parseExperimentArgs(args)
+	if err != nil {
+		return err
+	}
+	var names []string
+	if opts.algo != "" {
+		names = strings.Split(opts.algo, ",")
+	}
+	selected, err := selectAlgorithms(names)
+	if err != nil {
+		return err
+	}
+	if opts.gen.seed == 0 {
+		opts.gen.seed = time.Now().UnixNano()
+		_, _ = fmt.Fprintf(os.Stderr, "seed: %d\n", opts.gen.seed)
+	}
+
+	var workloads []htmlWorkload
+	var record func(int, []Result)
+	if opts.html != "" {
+		record = func(run int, results []Result) {
+			workloads = append(workloads, htmlWorkload{Name: fmt.Sprintf("run %d", run+1), Results: results})
+		}
+	}
+	rows, err := experiment(rand.New(rand.NewSource(opts.gen.seed)), opts.gen.cfg, opts.runs, selected, Options{Quantum: opts.quantum}, record)
+	if err != nil {
+		return err
+	}
+	if opts.html != "" {
+		if err := writeHTMLReports(opts.html, workloads); err != nil {
+			return err
+		}
+	}
+	if opts.plain {
+		outputPlainExperiment(os.Stdout, opts.runs, rows)
+	} else {
+		outputExperiment(os.Stdout, opts.runs, rows)
+	}
+
+	return nil
+}
+
+// experiment runs every algorithm over runs workloads drawn from cfg with rng and estimates each
+// metric. A non-nil record is handed every run's results. It stops at the first algorithm that
+// fails, such as a plugin returning an invalid schedule.
+func experiment(rng *rand.Rand, cfg generatorConfig, runs int, selected []algorithm, opts Options, record func(run int, results []Result)) ([]experimentRow, error) {
+	samples := make([][][]float64, len(selected))
+	for i := range samples {
+		samples[i] = make([][]float64, len(experimentMetrics))
+	}
+	for run := 0; run < runs; run++ {
+		processes := generateWorkload(rng, cfg)
+		results := make([]Result, len(selected))
+		for i, a := range selected {
+			result, err := runAlgorithmChecked(a, io.Discard, processes, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%w: experiment run %d", err, run+1)
+			}
+			results[i] = result
+			for m, metric := range experimentMetrics {
+				samples[i][m] = append(samples[i][m], metric.value(results[i]))
+			}
+		}
+		if record != nil {
+			record(run, results)
+		}
+	}
+
+	rows := make([]experimentRow, len(selected))
+	for i, a := range selected {
+		rows[i].name = a.name
+		for _, values := range samples[i] {
+			rows[i].estimates = append(rows[i].estimates, estimateMean(values))
+		}
+	}
+
+	return rows, nil
+}
+
+// estimateMean returns the mean of the samples in values and the half-width of its 95%
+// confidence interval from Student's t and the sample standard deviation.
+func estimateMean(values []float64) estimate {
+	n := len(values)
+	m := mean(values)
+	if n < 2 {
+		return estimate{mean: m}
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - m) * (v - m)
+	}
+	t := 1.96
+	if n-1 <= len(tCritical95) {
+		t = tCritical95[n-2]
+	}
+
+	return estimate{mean: m, half: t * math.Sqrt(squares/float64(n-1)) / math.Sqrt(float64(n))}
+}
+
+// outputExperiment prints each algorithm's estimates.
+func outputExperiment(w io.Writer, runs int, rows []experimentRow) {
+	_, _ = fmt.Fprintf(w, "Experiment (%d workloads, 95%% confidence intervals)\n", runs)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Metric", "Mean", "95% CI"})
+	for _, r := range rows {
+		for m, e := range r.estimates {
+			table.Append([]string{
+				r.name,
+				experimentMetrics[m].name,
+				fmt.Sprintf("%.2f", e.mean),
+				fmt.Sprintf("%.2f to %.2f", e.mean-e.half, e.mean+e.half),
+			})
+		}
+	}
+	table.Render()
+}
+
+// outputPlainExperiment is the -plain form of outputExperiment.
+func outputPlainExperiment(w io.Writer, runs int, rows []experimentRow) {
+	_, _ = fmt.Fprintf(w, "experiment: workloads %d, confidence 95%%\n", runs)
+	for _, r := range rows {
+		for m, e := range r.estimates {
+			_, _ = fmt.Fprintf(w, "estimate: %s, %s, mean %.2f, interval %.2f to %.2f\n",
+				r.name, experimentMetrics[m].name, e.mean, e.mean-e.half, e.mean+e.half)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func Test_estimateMean(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []float64
+		want   estimate
+	}{
+		// sample stddev 1, so the half-width is t(1) / sqrt(2)
+		{name: "two samples", values: []float64{1, 2}, want: estimate{mean: 1.5, half: 12.706 * math.Sqrt(0.5) / math.Sqrt(2)}},
+		{name: "constant", values: []float64{3, 3, 3}, want: estimate{mean: 3}},
+		{name: "one sample", values: []float64{4}, want: estimate{mean: 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := estimateMean(tt.values)
+			if math.Abs(got.mean-tt.want.mean) > 1e-9 || math.Abs(got.half-tt.want.half) > 1e-9 {
+				t.Errorf("estimateMean() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseExperimentArgs(t *testing.T) {
+	t.Parallel()
+	opts, err := parseExperimentArgs([]string{"-profile", "interactive", "-runs", "5", "-n", "7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.runs != 5 || opts.gen.cfg.Count != 7 || opts.gen.cfg.MaxBurst != workloadProfiles["interactive"].MaxBurst {
+		t.Errorf("parseExperimentArgs() = runs %d, count %d, max burst %d, want 5, 7 and the profile's", opts.runs, opts.gen.cfg.Count, opts.gen.cfg.MaxBurst)
+	}
+	if _, err := parseExperimentArgs([]string{"-runs", "1"}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("parseExperimentArgs() with one run error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_experiment(t *testing.T) {
+	t.Parallel()
+	selected, err := selectAlgorithms([]string{"fcfs", "sjf"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultGeneratorConfig
+	cfg.Count = 10
+	rows, err := experiment(rand.New(rand.NewSource(1)), cfg, 20, selected, Options{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || len(rows[0].estimates) != len(experimentMetrics) {
+		t.Fatalf("experiment() = %+v, want 2 rows of %d estimates", rows, len(experimentMetrics))
+	}
+	// over random workloads SJF waits well below FCFS on average
+	if rows[1].estimates[0].mean > rows[0].estimates[0].mean {
+		t.Errorf("experiment() mean wait sjf %.2f > fcfs %.2f", rows[1].estimates[0].mean, rows[0].estimates[0].mean)
+	}
+}
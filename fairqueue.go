@@ -0,0 +1,92 @@
+package main
+
+import "io"
+
+// weight is the share of the CPU p gets under the fair-queueing schedulers; an unset weight
+// counts as 1.
+func weight(p Process) int64 {
+	if p.Weight > 0 {
+		return p.Weight
+	}
+
+	return 1
+}
+
+// wrrSchedule outputs a weighted round-robin schedule of processes: round-robin in one queue, with
+// each process's time slice opts.Quantum times its weight.
+func wrrSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	quantum := opts.Quantum
+	if quantum <= 0 {
+		quantum = defaultQuantum
+	}
+
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		ready:          func([]int64) readySet { return &ringQueue{} },
+		limit:          func(i int) int64 { return quantum * weight(processes[i]) },
+		preemptedFirst: opts.PreemptedFirst,
+	}))
+}
+
+// drrReady is the ready set of deficit round-robin. Each process is a flow whose one packet is
+// its remaining burst: on every visit the head of the queue earns its credit, and is dispatched
+// if its deficit then covers the burst or moved to the back to save up otherwise.
+type drrReady struct {
+	queue     ringQueue
+	remaining []int64
+	credit    func(i int) int64
+	deficit   []int64
+	// credited marks a head that has earned its credit for the current visit
+	credited []bool
+}
+
+// settle rotates the queue until its head can be sent, and returns it. Skipped visits take no
+// time, and every process's deficit grows each round, so this ends.
+func (q *drrReady) settle() int {
+	for {
+		i := q.queue.peek()
+		if !q.credited[i] {
+			q.deficit[i] += q.credit(i)
+			q.credited[i] = true
+		}
+		if q.deficit[i] >= q.remaining[i] {
+			return i
+		}
+		q.credited[i] = false
+		q.queue.push(q.queue.pop())
+	}
+}
+
+func (q *drrReady) len() int             { return q.queue.len() }
+func (q *drrReady) push(i int)           { q.queue.push(i) }
+func (q *drrReady) peek() int            { return q.settle() }
+func (q *drrReady) before(_, _ int) bool { return false }
+
+// pop dispatches the head, which runs to completion, so its flow empties and its deficit resets.
+func (q *drrReady) pop() int {
+	i := q.settle()
+	q.queue.pop()
+	q.deficit[i], q.credited[i] = 0, false
+
+	return i
+}
+
+// drrSchedule outputs a deficit round-robin schedule of processes: each visit credits a process
+// with opts.Quantum times its weight, and a process runs, to completion, once its accumulated
+// credit covers its burst. Short jobs go on their first visit; long ones wait rounds to save up.
+func drrSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	quantum := opts.Quantum
+	if quantum <= 0 {
+		quantum = defaultQuantum
+	}
+
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		ready: func(remaining []int64) readySet {
+			return &drrReady{
+				remaining: remaining,
+				credit:    func(i int) int64 { return quantum * weight(processes[i]) },
+				deficit:   make([]int64, len(processes)),
+				credited:  make([]bool, len(processes)),
+			}
+		},
+	}))
+}
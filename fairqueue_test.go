@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_fairQueueing(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		schedule  func(io.Writer, string, []Process, Options) Result
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			name:     "wrr scales the quantum by weight",
+			schedule: wrrSchedule,
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6, Weight: 2},
+				{ProcessID: 2, BurstDuration: 4},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}, {PID: 1, Start: 6, Stop: 8}, {PID: 2, Start: 8, Stop: 10}},
+		},
+		{
+			name:     "drr runs a job once its deficit covers it",
+			schedule: drrSchedule,
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6},
+				{ProcessID: 2, BurstDuration: 2},
+				{ProcessID: 3, BurstDuration: 3, Weight: 2},
+			},
+			want: []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 5}, {PID: 1, Start: 5, Stop: 11}},
+		},
+		{
+			name:     "drr waits for arrivals",
+			schedule: drrSchedule,
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.schedule(io.Discard, tt.name, tt.processes, Options{Quantum: 2})
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("gantt = %v, want %v", got.Gantt, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ignoredFeatures lists the optional workload fields processes use that a does not schedule by:
+// deadline, which no policy reads; weight, unless a uses weights; and memory, which only the
+// pipeline subcommand models. Priority is left out because the classic input format always
+// carries one, so policies that pass over it are expected to.
+func ignoredFeatures(a algorithm, processes []Process) []string {
+	var deadline, weight, memory bool
+	for _, p := range processes {
+		deadline = deadline || p.Deadline != 0
+		weight = weight || p.Weight != 0
+		memory = memory || p.Memory != 0
+	}
+	var ignored []string
+	if deadline {
+		ignored = append(ignored, "deadline")
+	}
+	if weight && !a.weights {
+		ignored = append(ignored, "weight")
+	}
+	if memory {
+		ignored = append(ignored, "memory")
+	}
+
+	return ignored
+}
+
+// checkFeatures rejects a workload that uses fields some of the selected algorithms ignore,
+// naming each of them, for -strict-features.
+func checkFeatures(selected []algorithm, processes []Process) error {
+	var problems []string
+	for _, a := range selected {
+		if ignored := ignoredFeatures(a, processes); len(ignored) > 0 {
+			problems = append(problems, fmt.Sprintf("%s ignores %s", a.name, strings.Join(ignored, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: the workload uses features a selected algorithm does not support: %s", ErrInvalidArgs, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// outputFeatureWarnings writes a warning line for each result whose algorithm ignored some of
+// the workload's fields.
+func outputFeatureWarnings(w io.Writer, results []Result) {
+	for _, r := range results {
+		if len(r.Ignored) > 0 {
+			_, _ = fmt.Fprintf(w, "warning: algorithm %s, ignores %s\n", r.Name, strings.Join(r.Ignored, ", "))
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_ignoredFeatures(t *testing.T) {
+	t.Parallel()
+	wrr := algorithm{name: "wrr", weights: true}
+	fcfs := algorithm{name: "fcfs"}
+	tests := []struct {
+		name      string
+		a         algorithm
+		processes []Process
+		want      []string
+	}{
+		{name: "plain workload", a: fcfs, processes: []Process{{ProcessID: 1, BurstDuration: 2, Priority: 5}}},
+		{name: "deadline and weight", a: fcfs, processes: []Process{{ProcessID: 1, Deadline: 9}, {ProcessID: 2, Weight: 3}}, want: []string{"deadline", "weight"}},
+		{name: "weight used", a: wrr, processes: []Process{{ProcessID: 1, Weight: 3, Memory: 64}}, want: []string{"memory"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ignoredFeatures(tt.a, tt.processes); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ignoredFeatures() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_checkFeatures(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 2, Weight: 2}}
+	if err := checkFeatures([]algorithm{{name: "wrr", weights: true}}, processes); err != nil {
+		t.Errorf("checkFeatures() = %v, want nil", err)
+	}
+	err := checkFeatures([]algorithm{{name: "wrr", weights: true}, {name: "rr"}}, processes)
+	if !errors.Is(err, ErrInvalidArgs) {
+		t.Fatalf("checkFeatures() = %v, want %v", err, ErrInvalidArgs)
+	}
+	if want := "invalid arguments: the workload uses features a selected algorithm does not support: rr ignores weight"; err.Error() != want {
+		t.Errorf("checkFeatures() = %q, want %q", err, want)
+	}
+}
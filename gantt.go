@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ganttStyle sets how text gantt charts are drawn: scale characters per tick, wrapped to rows of
+// at most width characters, with each PID's bars in its color when color is set.
+type ganttStyle struct {
+	scale float64
+	width int
+	color bool
+}
+
+// ganttLayout is the style outputGantt draws with; main sets it from -gantt-scale and -gantt-width.
+var ganttLayout = ganttStyle{scale: 1, width: 80}
+
+// idlePID marks the slices withIdle inserts for time the CPU spent idle.
+const idlePID = 0
+
+// withIdle returns gantt with a slice of idlePID filling every gap from time 0 onwards, so charts
+// show idle time instead of jumping over it. Results keep only real slices.
+func withIdle(gantt []TimeSlice) []TimeSlice {
+	filled := make([]TimeSlice, 0, len(gantt))
+	var clock int64
+	for _, s := range gantt {
+		if s.Start > clock {
+			filled = append(filled, TimeSlice{PID: idlePID, Start: clock, Stop: s.Start})
+		}
+		filled = append(filled, s)
+		clock = s.Stop
+	}
+
+	return filled
+}
+
+// ganttCell is one bar laid out: its label and the characters between its edges.
+type ganttCell struct {
+	bar   GanttBar
+	label string
+	inner int
+}
+
+// outputTextGantt draws m with each bar as wide as its duration times the scale, widened only as
+// far as its PID and start time need, and idle bars labeled "idle". Each row gets a time axis with
+// the tick of every bar boundary under its edge.
+func outputTextGantt(w io.Writer, m GanttModel, style ganttStyle) {
+	if len(m.Bars) == 0 {
+		_, _ = fmt.Fprint(w, "|\n\n")
+		return
+	}
+
+	cells := make([]ganttCell, len(m.Bars))
+	for i, s := range m.Bars {
+		label := fmt.Sprint(s.PID)
+		if s.Idle {
+			label = "idle"
+		}
+		inner := int(math.Round(float64(s.Stop-s.Start) * style.scale))
+		inner = max(inner, len(label)+2, len(fmt.Sprint(s.Start)))
+		// a slice always fits on a row of its own
+		cells[i] = ganttCell{bar: s, label: label, inner: max(1, min(inner, style.width-2))}
+	}
+
+	for len(cells) > 0 {
+		n, used := 0, 1
+		for n < len(cells) && (n == 0 || used+cells[n].inner+1 <= style.width) {
+			used += cells[n].inner + 1
+			n++
+		}
+		outputGanttRow(w, cells[:n], style.color)
+		cells = cells[n:]
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputGanttRow writes one row of bars, colored if color is set, and the time axis under it.
+func outputGanttRow(w io.Writer, cells []ganttCell, color bool) {
+	var bars, axis strings.Builder
+	bars.WriteString("|")
+	for _, c := range cells {
+		left := (c.inner - len(c.label)) / 2
+		bar := strings.Repeat(" ", left) + c.label + strings.Repeat(" ", c.inner-left-len(c.label))
+		if color && !c.bar.Idle {
+			bar = paintSlice(c.bar.PID, bar)
+		}
+		bars.WriteString(bar + "|")
+
+		start := fmt.Sprint(c.bar.Start)
+		axis.WriteString(start + strings.Repeat(" ", c.inner+1-len(start)))
+	}
+	axis.WriteString(fmt.Sprint(cells[len(cells)-1].bar.Stop))
+	_, _ = fmt.Fprintln(w, bars.String())
+	_, _ = fmt.Fprintln(w, axis.String())
+}
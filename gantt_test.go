@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_outputTextGantt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		style ganttStyle
+		want  string
+	}{
+		{
+			name:  "proportional",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 11}},
+			style: ganttStyle{scale: 1, width: 80},
+			want:  "| 1 |    2     |\n0   1          11\n\n",
+		},
+		{
+			name:  "scaled",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}},
+			style: ganttStyle{scale: 2, width: 80},
+			want:  "|   1    |   2    |\n0        4        8\n\n",
+		},
+		{
+			name:  "wrapped",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 2, Start: 6, Stop: 12}, {PID: 3, Start: 12, Stop: 18}},
+			style: ganttStyle{scale: 1, width: 15},
+			want:  "|  1   |  2   |\n0      6      12\n|  3   |\n12     18\n\n",
+		},
+		{
+			name:  "slice wider than a row",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 100}},
+			style: ganttStyle{scale: 1, width: 10},
+			want:  "|   1    |\n0        100\n\n",
+		},
+		{
+			name:  "idle gaps",
+			gantt: []TimeSlice{{PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 10, Stop: 12}},
+			style: ganttStyle{scale: 1, width: 80},
+			want:  "| idle | 1 | idle | 2 |\n0      2   4      10  12\n\n",
+		},
+		{
+			name:  "colored, idle left plain",
+			gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 8, Stop: 9}},
+			style: ganttStyle{scale: 1, width: 80, color: true},
+			want:  "|\x1b[30;41m 1 \x1b[0m| idle |\x1b[30;42m 2 \x1b[0m|\n0   3      8   9\n\n",
+		},
+		{name: "empty", style: ganttStyle{scale: 1, width: 80}, want: "|\n\n"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputTextGantt(&w, NewGanttModel(tt.gantt), tt.style)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputTextGantt() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_withIdle(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 5, Stop: 6}}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: idlePID, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 6}}
+	if got := withIdle(gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("withIdle() = %v, want %v", got, want)
+	}
+}
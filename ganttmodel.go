@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io"
+	"sort"
+)
+
+type (
+	// GanttModel is a schedule laid out for drawing, leaving out only how it is drawn, so a GUI
+	// can render it without merging slices or finding idle time itself.
+	GanttModel struct {
+		// CPUs is how many processors the bars are spread over; the simulator models one.
+		CPUs int `json:"cpus"`
+		// Start and Stop bound the chart: from time 0 to the end of the last bar.
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+		// Bars cover the chart without gaps, in time order: back-to-back slices of one process are
+		// merged, and the time between them is filled with idle bars.
+		Bars []GanttBar `json:"bars"`
+		// Lanes give each process, in PID order, the indexes in Bars of its bars.
+		Lanes []GanttLane `json:"lanes"`
+	}
+	// GanttBar is a stretch of time on one CPU, either running PID or idle.
+	GanttBar struct {
+		CPU   int   `json:"cpu"`
+		PID   int64 `json:"pid,omitempty"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+		Idle  bool  `json:"idle,omitempty"`
+		// Reason says why the bar ended, as TimeSlice.Reason does; idle bars have none.
+		Reason string `json:"reason,omitempty"`
+	}
+	// GanttLane is one process's row in a per-process view of the chart.
+	GanttLane struct {
+		PID  int64 `json:"pid"`
+		Bars []int `json:"bars"`
+	}
+	// GanttRenderer draws a GanttModel. SetGanttRenderer makes one draw the charts in reports.
+	GanttRenderer interface {
+		RenderGantt(w io.Writer, m GanttModel)
+	}
+)
+
+// NewGanttModel lays out the slices of a schedule for drawing.
+func NewGanttModel(gantt []TimeSlice) GanttModel {
+	m := GanttModel{CPUs: 1, Bars: make([]GanttBar, 0, len(gantt))}
+	lanes := make(map[int64][]int)
+	for _, s := range withIdle(gantt) {
+		if n := len(m.Bars); n > 0 && s.PID != idlePID && m.Bars[n-1].PID == s.PID && m.Bars[n-1].Stop == s.Start {
+			m.Bars[n-1].Stop, m.Bars[n-1].Reason = s.Stop, s.Reason
+			continue
+		}
+		if s.PID != idlePID {
+			lanes[s.PID] = append(lanes[s.PID], len(m.Bars))
+		}
+		m.Bars = append(m.Bars, GanttBar{PID: s.PID, Start: s.Start, Stop: s.Stop, Idle: s.PID == idlePID, Reason: s.Reason})
+	}
+	if len(m.Bars) > 0 {
+		m.Stop = m.Bars[len(m.Bars)-1].Stop
+	}
+	for pid, bars := range lanes {
+		m.Lanes = append(m.Lanes, GanttLane{PID: pid, Bars: bars})
+	}
+	sort.Slice(m.Lanes, func(i, j int) bool { return m.Lanes[i].PID < m.Lanes[j].PID })
+
+	return m
+}
+
+// textGanttRenderer draws the text chart of reports in the ganttLayout style.
+type textGanttRenderer struct{}
+
+func (textGanttRenderer) RenderGantt(w io.Writer, m GanttModel) {
+	outputTextGantt(w, m, ganttLayout)
+}
+
+// ganttRenderer draws the gantt charts in reports that are not shown as terminal images.
+var ganttRenderer GanttRenderer = textGanttRenderer{}
+
+// SetGanttRenderer makes r draw the gantt charts in reports, from an init function like Register;
+// nil restores the text chart.
+func SetGanttRenderer(r GanttRenderer) {
+	if r == nil {
+		r = textGanttRenderer{}
+	}
+	ganttRenderer = r
+}
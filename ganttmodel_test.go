@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_NewGanttModel(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 1, Stop: 3, Reason: endQuantum},
+		{PID: 1, Start: 3, Stop: 4, Reason: endArrival},
+		{PID: 2, Start: 4, Stop: 6, Reason: endCompletion},
+		{PID: 1, Start: 8, Stop: 9, Reason: endCompletion},
+	}
+	want := GanttModel{
+		CPUs: 1,
+		Stop: 9,
+		Bars: []GanttBar{
+			{Stop: 1, Idle: true},
+			{PID: 1, Start: 1, Stop: 4, Reason: endArrival},
+			{PID: 2, Start: 4, Stop: 6, Reason: endCompletion},
+			{Start: 6, Stop: 8, Idle: true},
+			{PID: 1, Start: 8, Stop: 9, Reason: endCompletion},
+		},
+		Lanes: []GanttLane{{PID: 1, Bars: []int{1, 4}}, {PID: 2, Bars: []int{2}}},
+	}
+	if got := NewGanttModel(gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewGanttModel() = %+v, want %+v", got, want)
+	}
+}
+
+// countingRenderer records how many bars it was asked to draw.
+type countingRenderer struct{ bars *int }
+
+func (r countingRenderer) RenderGantt(_ io.Writer, m GanttModel) { *r.bars = len(m.Bars) }
+
+func Test_SetGanttRenderer(t *testing.T) {
+	// swaps the package-wide renderer, so not parallel
+	var bars int
+	SetGanttRenderer(countingRenderer{bars: &bars})
+	defer SetGanttRenderer(nil)
+
+	var out bytes.Buffer
+	outputGantt(&out, []TimeSlice{{PID: 1, Start: 2, Stop: 3}})
+	if bars != 2 {
+		t.Errorf("custom renderer drew %d bars, want 2 (idle then P1)", bars)
+	}
+	if out.String() != "Gantt schedule\n" {
+		t.Errorf("outputGantt() with a custom renderer wrote %q, want only the heading", out.String())
+	}
+}
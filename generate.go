@@ -0,0 +1,352 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// generatorConfig describes a synthetic workload.
+type generatorConfig struct {
+	Count       int
+	ArrivalRate float64 // mean arrivals per tick
+	MinBurst    int64
+	MaxBurst    int64
+	MinPriority int64
+	MaxPriority int64
+	// BatchSize processes arrive together at each arrival instant.
+	BatchSize int
+	// LongFraction of processes draw their burst from [LongMinBurst, LongMaxBurst] instead.
+	LongFraction float64
+	LongMinBurst int64
+	LongMaxBurst int64
+	// Curve, when set, replaces ArrivalRate with a rate that varies over time, interpolated
+	// linearly between points and repeating every CurvePeriod ticks when that is positive.
+	Curve       arrivalCurve
+	CurvePeriod float64
+	// ArrivalDist draws the gaps between arrival instants: uniform (or empty) over twice their
+	// mean, or poisson for exponential gaps, making arrivals a Poisson process.
+	ArrivalDist string
+	// BurstDist draws bursts: uniform (or empty) over [MinBurst, MaxBurst], or exponential with
+	// mean BurstMean, or normal with mean BurstMean and standard deviation BurstStdDev. The last
+	// two are rounded and at least 1.
+	BurstDist   string
+	BurstMean   float64
+	BurstStdDev float64
+}
+
+// Generator distributions besides the default uniform.
+const (
+	distPoisson     = "poisson"
+	distExponential = "exponential"
+	distNormal      = "normal"
+	distUniform     = "uniform"
+)
+
+// ratePoint is the arrival rate, in arrivals per tick, at a given time.
+type ratePoint struct {
+	Time float64
+	Rate float64
+}
+
+// arrivalCurve is a time-varying arrival rate given by points in ascending time order.
+type arrivalCurve []ratePoint
+
+// rateAt interpolates the rate at time t, holding the first and last rates beyond the ends.
+func (c arrivalCurve) rateAt(t float64) float64 {
+	if t <= c[0].Time {
+		return c[0].Rate
+	}
+	for i := 1; i < len(c); i++ {
+		if t < c[i].Time {
+			a, b := c[i-1], c[i]
+			return a.Rate + (b.Rate-a.Rate)*(t-a.Time)/(b.Time-a.Time)
+		}
+	}
+
+	return c[len(c)-1].Rate
+}
+
+// parseArrivalCurve reads time:rate pairs separated by commas, e.g. "0:0.1,12:1,24:0.1", or, with
+// an @ prefix, a file of time,rate rows.
+func parseArrivalCurve(s string) (arrivalCurve, error) {
+	var pairs [][]string
+	if path, ok := strings.CutPrefix(s, "@"); ok {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: opening arrival curve", err)
+		}
+		defer func() { _ = f.Close() }()
+		cr := csv.NewReader(f)
+		cr.Comment = '#'
+		if pairs, err = cr.ReadAll(); err != nil {
+			return nil, fmt.Errorf("%w: reading arrival curve", err)
+		}
+	} else {
+		for _, pair := range strings.Split(s, ",") {
+			pairs = append(pairs, strings.Split(pair, ":"))
+		}
+	}
+
+	curve := make(arrivalCurve, 0, len(pairs))
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("%w: arrival curve point %q must be time:rate", ErrInvalidArgs, strings.Join(pair, ":"))
+		}
+		t, err := strconv.ParseFloat(strings.TrimSpace(pair[0]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: arrival curve time", ErrInvalidArgs, err)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(pair[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w: arrival curve rate", ErrInvalidArgs, err)
+		}
+		curve = append(curve, ratePoint{Time: t, Rate: rate})
+	}
+
+	return curve, nil
+}
+
+// workloadProfiles are named starting points for the generator; explicit flags override them.
+// Profiles leave Count to the default.
+var workloadProfiles = map[string]generatorConfig{
+	"cpu-bound": {
+		ArrivalRate: 0.1, MinBurst: 20, MaxBurst: 100, MinPriority: 20, MaxPriority: 50, BatchSize: 1,
+	},
+	"interactive": {
+		ArrivalRate: 1, MinBurst: 1, MaxBurst: 4, MinPriority: 1, MaxPriority: 20, BatchSize: 1,
+	},
+	"mixed": {
+		ArrivalRate: 0.4, MinBurst: 1, MaxBurst: 4, MinPriority: 1, MaxPriority: 50, BatchSize: 1,
+		LongFraction: 0.2, LongMinBurst: 20, LongMaxBurst: 100,
+	},
+	"bursty": {
+		ArrivalRate: 0.2, MinBurst: 2, MaxBurst: 10, MinPriority: 1, MaxPriority: 50, BatchSize: 5,
+	},
+}
+
+// defaultGeneratorConfig is used when no -profile is given.
+var defaultGeneratorConfig = generatorConfig{
+	Count: 10, ArrivalRate: 0.5, MinBurst: 1, MaxBurst: 10, MinPriority: 1, MaxPriority: 50, BatchSize: 1,
+}
+
+func (c generatorConfig) validate() error {
+	switch {
+	case c.Count < 1:
+		return fmt.Errorf("%w: -n must be at least 1", ErrInvalidArgs)
+	case c.ArrivalRate <= 0:
+		return fmt.Errorf("%w: -rate must be positive", ErrInvalidArgs)
+	case c.MinBurst < 1 || c.MaxBurst < c.MinBurst:
+		return fmt.Errorf("%w: burst range must satisfy 1 <= min <= max", ErrInvalidArgs)
+	case c.MaxPriority < c.MinPriority:
+		return fmt.Errorf("%w: priority range must satisfy min <= max", ErrInvalidArgs)
+	case c.BatchSize < 1:
+		return fmt.Errorf("%w: -batch must be at least 1", ErrInvalidArgs)
+	case c.LongFraction < 0 || c.LongFraction > 1:
+		return fmt.Errorf("%w: -long-fraction must be within [0, 1]", ErrInvalidArgs)
+	case c.LongFraction > 0 && (c.LongMinBurst < 1 || c.LongMaxBurst < c.LongMinBurst):
+		return fmt.Errorf("%w: long burst range must satisfy 1 <= min <= max", ErrInvalidArgs)
+	case c.CurvePeriod < 0:
+		return fmt.Errorf("%w: -curve-period must not be negative", ErrInvalidArgs)
+	case c.ArrivalDist != "" && c.ArrivalDist != distUniform && c.ArrivalDist != distPoisson:
+		return fmt.Errorf("%w: unknown -arrival-dist %q, want uniform or poisson", ErrInvalidArgs, c.ArrivalDist)
+	case c.BurstDist != "" && c.BurstDist != distUniform && c.BurstDist != distExponential && c.BurstDist != distNormal:
+		return fmt.Errorf("%w: unknown -burst-dist %q, want uniform, exponential or normal", ErrInvalidArgs, c.BurstDist)
+	case (c.BurstDist == distExponential || c.BurstDist == distNormal) && c.BurstMean <= 0:
+		return fmt.Errorf("%w: -burst-dist %s needs a positive -burst-mean", ErrInvalidArgs, c.BurstDist)
+	case c.BurstStdDev < 0:
+		return fmt.Errorf("%w: -burst-stddev must not be negative", ErrInvalidArgs)
+	}
+	for i, p := range c.Curve {
+		switch {
+		case p.Rate <= 0:
+			return fmt.Errorf("%w: -curve rates must be positive", ErrInvalidArgs)
+		case p.Time < 0 || i > 0 && p.Time <= c.Curve[i-1].Time:
+			return fmt.Errorf("%w: -curve times must be non-negative and ascending", ErrInvalidArgs)
+		case c.CurvePeriod > 0 && p.Time > c.CurvePeriod:
+			return fmt.Errorf("%w: -curve times must fall within -curve-period", ErrInvalidArgs)
+		}
+	}
+
+	return nil
+}
+
+type generateOptions struct {
+	cfg      generatorConfig
+	profile  string
+	seed     int64
+	out      string
+	compress bool
+}
+
+// parseGenerateArgs parses generate's flags on top of defaults. A -profile replaces the
+// defaults and the arguments are parsed again, so explicit flags win over the profile.
+func parseGenerateArgs(args []string) (generateOptions, error) {
+	opts := generateOptions{cfg: defaultGeneratorConfig}
+	err := parseProfileArgs(args, &opts, generateFlags)
+
+	return opts, err
+}
+
+// parseProfileArgs parses args with the flag set flags builds over opts, twice when a -profile is
+// given so that explicit flags win over the profile, and validates the generator config.
+func parseProfileArgs(args []string, opts *generateOptions, flags func(*generateOptions) *flag.FlagSet) error {
+	if err := flags(opts).Parse(args); err != nil {
+		return err
+	}
+	if opts.profile != "" {
+		profile, ok := workloadProfiles[opts.profile]
+		if !ok {
+			return fmt.Errorf("%w: unknown -profile %q", ErrInvalidArgs, opts.profile)
+		}
+		profile.Count = defaultGeneratorConfig.Count
+		opts.cfg = profile
+		if err := flags(opts).Parse(args); err != nil {
+			return err
+		}
+	}
+
+	return opts.cfg.validate()
+}
+
+func generateFlags(opts *generateOptions) *flag.FlagSet {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	addGeneratorFlags(fs, opts)
+	fs.StringVar(&opts.out, "o", opts.out, "write the workload to `file` instead of stdout, gzipped if it ends in .gz")
+	fs.BoolVar(&opts.compress, "compress", opts.compress, "gzip the -o file, adding .gz to its name")
+	fs.BoolVar(&crlfFiles, "crlf", false, "end lines with \\r\\n for Windows tools")
+
+	return fs
+}
+
+// addGeneratorFlags defines the flags that shape generated workloads on fs.
+func addGeneratorFlags(fs *flag.FlagSet, opts *generateOptions) {
+	cfg := &opts.cfg
+	fs.IntVar(&cfg.Count, "n", cfg.Count, "number of processes")
+	fs.Float64Var(&cfg.ArrivalRate, "rate", cfg.ArrivalRate, "mean arrivals per tick")
+	fs.Int64Var(&cfg.MinBurst, "burst-min", cfg.MinBurst, "shortest burst duration")
+	fs.Int64Var(&cfg.MaxBurst, "burst-max", cfg.MaxBurst, "longest burst duration")
+	fs.Int64Var(&cfg.MinPriority, "priority-min", cfg.MinPriority, "highest priority (lowest number)")
+	fs.Int64Var(&cfg.MaxPriority, "priority-max", cfg.MaxPriority, "lowest priority (highest number)")
+	fs.IntVar(&cfg.BatchSize, "batch", cfg.BatchSize, "processes arriving together at each arrival instant")
+	fs.Float64Var(&cfg.LongFraction, "long-fraction", cfg.LongFraction, "fraction of processes drawing a long burst")
+	fs.Int64Var(&cfg.LongMinBurst, "long-burst-min", cfg.LongMinBurst, "shortest long burst duration")
+	fs.Int64Var(&cfg.LongMaxBurst, "long-burst-max", cfg.LongMaxBurst, "longest long burst duration")
+	fs.Func("curve", "time-varying arrival rate as `time:rate` pairs, e.g. 0:0.1,12:1,24:0.1, or @file of time,rate rows (overrides -rate)", func(s string) error {
+		curve, err := parseArrivalCurve(s)
+		cfg.Curve = curve
+		return err
+	})
+	fs.Float64Var(&cfg.CurvePeriod, "curve-period", cfg.CurvePeriod, "repeat the -curve every `ticks` (0 holds its last rate)")
+	fs.StringVar(&cfg.ArrivalDist, "arrival-dist", cfg.ArrivalDist, "`distribution` of the gaps between arrivals: uniform (the default) or poisson for exponential gaps")
+	fs.StringVar(&cfg.BurstDist, "burst-dist", cfg.BurstDist, "`distribution` of bursts: uniform over -burst-min..-burst-max (the default), exponential or normal")
+	fs.Float64Var(&cfg.BurstMean, "burst-mean", cfg.BurstMean, "mean burst in `ticks` for -burst-dist exponential or normal")
+	fs.Float64Var(&cfg.BurstStdDev, "burst-stddev", cfg.BurstStdDev, "standard deviation of bursts in `ticks` for -burst-dist normal")
+	fs.StringVar(&opts.profile, "profile", opts.profile, "start from a named `profile`: cpu-bound, interactive, mixed or bursty")
+	fs.Int64Var(&opts.seed, "seed", opts.seed, "random `seed` for a reproducible workload (default random, reported on stderr)")
+}
+
+// runGenerate writes a synthetic workload CSV to stdout or -o.
+func runGenerate(args []string) error {
+	opts, err := parseGenerateArgs(args)
+	if err != nil {
+		return err
+	}
+	if opts.seed == 0 {
+		opts.seed = time.Now().UnixNano()
+		_, _ = fmt.Fprintf(os.Stderr, "seed: %d\n", opts.seed)
+	}
+
+	processes := generateWorkload(rand.New(rand.NewSource(opts.seed)), opts.cfg)
+	if opts.out == "" {
+		return writeWorkloadCSV(os.Stdout, processes)
+	}
+	w, closeFn, err := createOutput(opts.out, opts.compress)
+	if err != nil {
+		return fmt.Errorf("%w: creating workload file", err)
+	}
+	if err := writeWorkloadCSV(w, processes); err != nil {
+		_ = closeFn()
+		return err
+	}
+
+	return closeFn()
+}
+
+// generateWorkload draws cfg.Count processes with PIDs 1..n. Batches of BatchSize arrive
+// together with gaps, drawn from ArrivalDist, that keep the mean rate at ArrivalRate, or at the
+// Curve's rate where the previous batch arrived; bursts are drawn from BurstDist and priorities
+// are uniform over their range.
+func generateWorkload(rng *rand.Rand, cfg generatorConfig) []Process {
+	processes := make([]Process, cfg.Count)
+	var clock float64
+	for i := range processes {
+		if i > 0 && i%cfg.BatchSize == 0 {
+			mean := float64(cfg.BatchSize) / cfg.rateAt(clock)
+			if cfg.ArrivalDist == distPoisson {
+				clock += rng.ExpFloat64() * mean
+			} else {
+				clock += rng.Float64() * 2 * mean
+			}
+		}
+		var burst int64
+		switch cfg.BurstDist {
+		case distExponential:
+			burst = max(int64(math.Round(rng.ExpFloat64()*cfg.BurstMean)), 1)
+		case distNormal:
+			burst = max(int64(math.Round(cfg.BurstMean+rng.NormFloat64()*cfg.BurstStdDev)), 1)
+		default:
+			burst = cfg.MinBurst + rng.Int63n(cfg.MaxBurst-cfg.MinBurst+1)
+		}
+		if cfg.LongFraction > 0 && rng.Float64() < cfg.LongFraction {
+			burst = cfg.LongMinBurst + rng.Int63n(cfg.LongMaxBurst-cfg.LongMinBurst+1)
+		}
+		processes[i] = Process{
+			ProcessID:     int64(i + 1),
+			ArrivalTime:   int64(clock),
+			BurstDuration: burst,
+			Priority:      cfg.MinPriority + rng.Int63n(cfg.MaxPriority-cfg.MinPriority+1),
+		}
+	}
+
+	return processes
+}
+
+// rateAt is the mean number of arrivals per tick at time t.
+func (c generatorConfig) rateAt(t float64) float64 {
+	if len(c.Curve) == 0 {
+		return c.ArrivalRate
+	}
+	if c.CurvePeriod > 0 {
+		t = math.Mod(t, c.CurvePeriod)
+	}
+
+	return c.Curve.rateAt(t)
+}
+
+// writeWorkloadCSV writes processes in the <ProcessID>,<Burst>,<Arrival>,<Priority> input format.
+func writeWorkloadCSV(w io.Writer, processes []Process) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = crlfFiles
+	for _, p := range processes {
+		_ = cw.Write([]string{
+			strconv.FormatInt(p.ProcessID, 10),
+			strconv.FormatInt(p.BurstDuration, 10),
+			strconv.FormatInt(p.ArrivalTime, 10),
+			strconv.FormatInt(p.Priority, 10),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("%w: writing workload CSV", err)
+	}
+
+	return nil
+}
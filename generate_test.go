@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_generateWorkload(t *testing.T) {
+	t.Parallel()
+	cfg := generatorConfig{Count: 200, ArrivalRate: 0.5, MinBurst: 2, MaxBurst: 6, MinPriority: 1, MaxPriority: 3, BatchSize: 1}
+	processes := generateWorkload(rand.New(rand.NewSource(1)), cfg)
+
+	if len(processes) != cfg.Count {
+		t.Fatalf("generateWorkload() returned %d processes, want %d", len(processes), cfg.Count)
+	}
+	for i, p := range processes {
+		if p.ProcessID != int64(i+1) {
+			t.Errorf("process %d has PID %d", i, p.ProcessID)
+		}
+		if i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime {
+			t.Errorf("process %d arrives before its predecessor", p.ProcessID)
+		}
+		if p.BurstDuration < cfg.MinBurst || p.BurstDuration > cfg.MaxBurst {
+			t.Errorf("process %d burst %d out of range", p.ProcessID, p.BurstDuration)
+		}
+		if p.Priority < cfg.MinPriority || p.Priority > cfg.MaxPriority {
+			t.Errorf("process %d priority %d out of range", p.ProcessID, p.Priority)
+		}
+	}
+	// 199 gaps with mean 2 ticks
+	if last := processes[len(processes)-1].ArrivalTime; last < 300 || last > 500 {
+		t.Errorf("last arrival = %d, want roughly 400", last)
+	}
+}
+
+func Test_generateWorkload_distributions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                 string
+		cfg                  generatorConfig
+		wantGap, wantBurst   float64
+		wantStdDev, tolerant float64
+	}{
+		{
+			name:    "poisson arrivals, exponential bursts",
+			cfg:     generatorConfig{ArrivalRate: 0.5, ArrivalDist: distPoisson, BurstDist: distExponential, BurstMean: 8},
+			wantGap: 2, wantBurst: 8, wantStdDev: 8, tolerant: 0.1,
+		},
+		{
+			name:    "normal bursts",
+			cfg:     generatorConfig{ArrivalRate: 0.25, BurstDist: distNormal, BurstMean: 20, BurstStdDev: 3},
+			wantGap: 4, wantBurst: 20, wantStdDev: 3, tolerant: 0.1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := tt.cfg
+			cfg.Count, cfg.MinBurst, cfg.MaxBurst, cfg.MinPriority, cfg.MaxPriority, cfg.BatchSize = 20000, 1, 1, 1, 1, 1
+			if err := cfg.validate(); err != nil {
+				t.Fatal(err)
+			}
+			processes := generateWorkload(rand.New(rand.NewSource(1)), cfg)
+			bursts := make([]float64, len(processes))
+			for i, p := range processes {
+				if p.BurstDuration < 1 {
+					t.Fatalf("process %d has burst %d", p.ProcessID, p.BurstDuration)
+				}
+				bursts[i] = float64(p.BurstDuration)
+			}
+			gap := float64(processes[len(processes)-1].ArrivalTime) / float64(len(processes)-1)
+			d := describe(bursts)
+			near := func(got, want float64) bool { return math.Abs(got-want) <= tt.tolerant*want }
+			if !near(gap, tt.wantGap) || !near(mean(bursts), tt.wantBurst) || !near(d.StdDev, tt.wantStdDev) {
+				t.Errorf("mean gap %.2f, burst %.2f, stddev %.2f, want about %.2f, %.2f, %.2f",
+					gap, mean(bursts), d.StdDev, tt.wantGap, tt.wantBurst, tt.wantStdDev)
+			}
+		})
+	}
+}
+
+func Test_writeWorkloadCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	var w bytes.Buffer
+	if err := writeWorkloadCSV(&w, processes); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadProcesses(&w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, processes) {
+		t.Errorf("round trip = %v, want %v", got, processes)
+	}
+}
+
+func Test_generatorConfig_validate(t *testing.T) {
+	t.Parallel()
+	valid := generatorConfig{Count: 1, ArrivalRate: 1, MinBurst: 1, MaxBurst: 1, MinPriority: 1, MaxPriority: 1, BatchSize: 1}
+	if err := valid.validate(); err != nil {
+		t.Errorf("validate() = %v, want nil", err)
+	}
+	for _, change := range []func(c *generatorConfig){
+		func(c *generatorConfig) { c.MaxBurst = 0 },
+		func(c *generatorConfig) { c.ArrivalDist = "gamma" },
+		func(c *generatorConfig) { c.BurstDist = distExponential },
+		func(c *generatorConfig) { c.BurstDist, c.BurstMean, c.BurstStdDev = distNormal, 5, -1 },
+	} {
+		invalid := valid
+		change(&invalid)
+		if err := invalid.validate(); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("validate(%+v) = %v, want %v", invalid, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func Test_generateWorkload_seed(t *testing.T) {
+	t.Parallel()
+	for name, cfg := range workloadProfiles {
+		cfg.Count = 50
+		a := generateWorkload(rand.New(rand.NewSource(42)), cfg)
+		b := generateWorkload(rand.New(rand.NewSource(42)), cfg)
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("profile %s: same seed produced different workloads", name)
+		}
+		if err := cfg.validate(); err != nil {
+			t.Errorf("profile %s: %v", name, err)
+		}
+	}
+}
+
+func Test_generateWorkload_batches(t *testing.T) {
+	t.Parallel()
+	cfg := workloadProfiles["bursty"]
+	cfg.Count = 10
+	processes := generateWorkload(rand.New(rand.NewSource(7)), cfg)
+	for i := 1; i < len(processes); i++ {
+		if i%cfg.BatchSize != 0 && processes[i].ArrivalTime != processes[i-1].ArrivalTime {
+			t.Errorf("process %d left its batch", processes[i].ProcessID)
+		}
+	}
+}
+
+func Test_parseGenerateArgs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    generatorConfig
+		wantErr error
+	}{
+		{
+			name: "defaults",
+			want: generatorConfig{Count: 10, ArrivalRate: 0.5, MinBurst: 1, MaxBurst: 10, MinPriority: 1, MaxPriority: 50, BatchSize: 1},
+		},
+		{
+			name: "profile with override",
+			args: []string{"-burst-max", "50", "-profile", "cpu-bound", "-n", "3"},
+			want: generatorConfig{Count: 3, ArrivalRate: 0.1, MinBurst: 20, MaxBurst: 50, MinPriority: 20, MaxPriority: 50, BatchSize: 1},
+		},
+		{
+			name:    "unknown profile",
+			args:    []string{"-profile", "gpu"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseGenerateArgs(tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got.cfg, tt.want) {
+				t.Errorf("parseGenerateArgs() = %+v, want %+v", got.cfg, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseArrivalCurve(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "curve.csv")
+	if err := os.WriteFile(path, []byte("# diurnal\n0,0.1\n12,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		in      string
+		want    arrivalCurve
+		wantErr error
+	}{
+		{name: "inline", in: "0:0.1, 12:1", want: arrivalCurve{{0, 0.1}, {12, 1}}},
+		{name: "file", in: "@" + path, want: arrivalCurve{{0, 0.1}, {12, 1}}},
+		{name: "missing rate", in: "0:0.1,12", wantErr: ErrInvalidArgs},
+		{name: "bad number", in: "0:fast", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseArrivalCurve(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseArrivalCurve() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && tt.wantErr == nil {
+				t.Errorf("parseArrivalCurve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_generatorConfig_rateAt(t *testing.T) {
+	t.Parallel()
+	cfg := generatorConfig{ArrivalRate: 0.5, Curve: arrivalCurve{{0, 0.2}, {10, 1}, {20, 0.2}}, CurvePeriod: 20}
+	for _, tt := range []struct{ t, want float64 }{{0, 0.2}, {5, 0.6}, {10, 1}, {25, 0.6}} {
+		if got := cfg.rateAt(tt.t); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("rateAt(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+	if got := (generatorConfig{ArrivalRate: 0.5}).rateAt(7); got != 0.5 {
+		t.Errorf("rateAt() without curve = %v, want 0.5", got)
+	}
+}
+
+func Test_generateWorkload_curve(t *testing.T) {
+	t.Parallel()
+	// busy for the first 100 ticks of every 200, nearly idle for the rest
+	cfg := generatorConfig{
+		Count: 400, MinBurst: 1, MaxBurst: 1, BatchSize: 1,
+		Curve: arrivalCurve{{0, 2}, {99, 2}, {100, 0.05}, {199, 0.05}}, CurvePeriod: 200,
+	}
+	var busy int
+	for _, p := range generateWorkload(rand.New(rand.NewSource(1)), cfg) {
+		if p.ArrivalTime%200 < 100 {
+			busy++
+		}
+	}
+	if busy < 350 {
+		t.Errorf("%d of 400 arrivals fell in the busy half, want most of them", busy)
+	}
+}
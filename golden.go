@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// goldenExt is the suffix of the golden file that sits next to each fixture workload.
+const goldenExt = ".golden.json"
+
+// ErrGoldenMismatch is wrapped by CompareGolden when results differ from their golden file.
+var ErrGoldenMismatch = errors.New("results differ from the golden file")
+
+// goldenResult is what a golden file locks in for one algorithm: its schedule, including why
+// each slice ended, and its averages.
+type goldenResult struct {
+	Gantt           []TimeSlice `json:"gantt"`
+	AveWait         float64     `json:"aveWait"`
+	AveTurnaround   float64     `json:"aveTurnaround"`
+	AveResponse     float64     `json:"aveResponse"`
+	ContextSwitches int         `json:"contextSwitches"`
+}
+
+// RunAll schedules processes with every registered algorithm at its default options, keyed by
+// algorithm name. It fails on the first algorithm whose schedule is invalid.
+func RunAll(processes []Process) (map[string]Result, error) {
+	results := make(map[string]Result, len(algorithms))
+	for _, a := range algorithms {
+		result, err := runAlgorithmChecked(a, io.Discard, processes, Options{})
+		if err != nil {
+			return nil, err
+		}
+		results[a.name] = result
+	}
+
+	return results, nil
+}
+
+// WriteGolden writes results to the golden file at path, replacing it.
+func WriteGolden(path string, results map[string]Result) error {
+	golden := make(map[string]goldenResult, len(results))
+	for name, r := range results {
+		golden[name] = goldenResult{Gantt: r.Gantt, AveWait: r.AveWait, AveTurnaround: r.AveTurnaround, AveResponse: r.AveResponse, ContextSwitches: r.ContextSwitches}
+	}
+	// map keys marshal sorted, so regenerating an unchanged file leaves it byte for byte the same
+	js, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: encoding golden file", err)
+	}
+	if err := os.WriteFile(path, append(js, '\n'), 0o644); err != nil {
+		return fmt.Errorf("%w: writing golden file", err)
+	}
+
+	return nil
+}
+
+// CompareGolden checks results against the golden file at path and returns an error wrapping
+// ErrGoldenMismatch that names every algorithm whose schedule or averages changed, or that only
+// one side has.
+func CompareGolden(path string, results map[string]Result) error {
+	in, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: reading golden file", err)
+	}
+	golden, err := decodeGolden(path, in)
+	if err != nil {
+		return err
+	}
+
+	return compareGolden(path, golden, results)
+}
+
+func decodeGolden(path string, in []byte) (map[string]goldenResult, error) {
+	var golden map[string]goldenResult
+	if err := json.Unmarshal(in, &golden); err != nil {
+		return nil, fmt.Errorf("%w: %w: decoding golden file %s", ErrInvalidArgs, err, path)
+	}
+
+	return golden, nil
+}
+
+// compareGolden is CompareGolden against the decoded golden file at path.
+func compareGolden(path string, golden map[string]goldenResult, results map[string]Result) error {
+	var want, got []Result
+	for name, g := range golden {
+		want = append(want, Result{Name: name, Gantt: g.Gantt, AveWait: g.AveWait, AveTurnaround: g.AveTurnaround, AveResponse: g.AveResponse, ContextSwitches: g.ContextSwitches})
+	}
+	for _, r := range results {
+		got = append(got, r)
+	}
+	// by name, so the problems are listed in the same order every run
+	sort.Slice(want, func(i, j int) bool { return want[i].Name < want[j].Name })
+	sort.Slice(got, func(i, j int) bool { return got[i].Name < got[j].Name })
+	var problems []string
+	for _, d := range diffBaseline(want, got) {
+		switch {
+		case !d.changed():
+		case !d.InBaseline:
+			problems = append(problems, fmt.Sprintf("%s is not in the golden file", d.Name))
+		case !d.InRun:
+			problems = append(problems, fmt.Sprintf("%s is in the golden file but did not run", d.Name))
+		case d.Diverged >= 0:
+			problems = append(problems, fmt.Sprintf("%s schedule diverges at t=%d", d.Name, d.Diverged))
+		default:
+			problems = append(problems, fmt.Sprintf("%s averages changed by wait %+.2f, turnaround %+.2f, response %+.2f, switches %+d",
+				d.Name, d.Wait, d.Turnaround, d.Response, d.Switches))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%w %s: %s", ErrGoldenMismatch, path, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// goldenFixtures lists the fixture workloads in dir: every .csv file, whose golden file is the
+// same name with goldenExt in place of .csv.
+func goldenFixtures(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing fixtures", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%w: no .csv fixtures in %s", ErrInvalidArgs, dir)
+	}
+
+	return paths, nil
+}
+
+func goldenPath(fixture string) string {
+	return strings.TrimSuffix(fixture, ".csv") + goldenExt
+}
+
+// runGolden is the golden subcommand: it runs every algorithm over each fixture in a directory
+// and checks the results against the fixture's golden file, or rewrites the golden files with
+// -update, so CI can catch any change to a schedule.
+func runGolden(args []string) error {
+	fs := flag.NewFlagSet("golden", flag.ExitOnError)
+	update := fs.Bool("update", false, "rewrite the golden files from the current results instead of checking them")
+	delim := fs.String("delimiter", ",", "field `separator` of the fixture workloads")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return fmt.Errorf("%w: usage: golden [-update] [dir]", ErrInvalidArgs)
+	}
+	dir := "testdata"
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		return err
+	}
+	fixtures, err := goldenFixtures(dir)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, fixture := range fixtures {
+		processes, err := loadWorkloadFile(fixture, delimiter)
+		if err != nil {
+			return fmt.Errorf("%w: in %s", err, fixture)
+		}
+		results, err := RunAll(processes)
+		if err != nil {
+			return fmt.Errorf("%w: in %s", err, fixture)
+		}
+		if *update {
+			if err := WriteGolden(goldenPath(fixture), results); err != nil {
+				return err
+			}
+			_, _ = fmt.Printf("updated %s\n", goldenPath(fixture))
+			continue
+		}
+		if err := CompareGolden(goldenPath(fixture), results); err != nil {
+			failed++
+			_, _ = fmt.Printf("FAIL %s: %v\n", fixture, err)
+			continue
+		}
+		_, _ = fmt.Printf("ok %s\n", fixture)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d fixtures (run golden -update to accept the new results)", ErrGoldenMismatch, failed, len(fixtures))
+	}
+
+	return nil
+}
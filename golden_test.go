@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Test_golden locks in every algorithm's schedule of the fixtures in testdata; after an
+// intended change, accept the new schedules with go run . golden -update.
+func Test_golden(t *testing.T) {
+	t.Parallel()
+	fixtures, err := goldenFixtures("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		fixture := fixture
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			t.Parallel()
+			processes, err := loadWorkloadFile(fixture, ',')
+			if err != nil {
+				t.Fatal(err)
+			}
+			results, err := RunAll(processes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := CompareGolden(goldenPath(fixture), results); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func Test_CompareGolden(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}}
+	path := filepath.Join(t.TempDir(), "w"+goldenExt)
+	results, err := RunAll(processes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteGolden(path, results); err != nil {
+		t.Fatal(err)
+	}
+	if err := CompareGolden(path, results); err != nil {
+		t.Fatalf("CompareGolden() of the same results = %v", err)
+	}
+
+	delete(results, "fcfs")
+	rr := results["rr"]
+	rr.Gantt = []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}}
+	results["rr"] = rr
+	sjf := results["sjf"]
+	sjf.AveWait += 1
+	results["sjf"] = sjf
+	err = CompareGolden(path, results)
+	if !errors.Is(err, ErrGoldenMismatch) {
+		t.Fatalf("CompareGolden() error = %v, want ErrGoldenMismatch", err)
+	}
+	for _, want := range []string{"rr schedule diverges at t=2", "sjf averages changed by wait +1.00", "fcfs is in the golden file but did not run"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("CompareGolden() error %q lacks %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gRPC status codes the Simulator service answers with.
+const (
+	grpcOK                = 0
+	grpcCancelled         = 1
+	grpcInvalidArgument   = 3
+	grpcNotFound          = 5
+	grpcResourceExhausted = 8
+	grpcUnimplemented     = 12
+	grpcInternal          = 13
+)
+
+// maxStoredWorkloads bounds how many submitted workloads serve keeps in memory.
+const maxStoredWorkloads = 1000
+
+// grpcError is an error carrying the gRPC status code it is reported with.
+type grpcError struct {
+	code int
+	err  error
+}
+
+func (e grpcError) Error() string { return e.err.Error() }
+func (e grpcError) Unwrap() error { return e.err }
+
+// workloadStore holds the workloads submitted with SubmitWorkload, by an ID derived from their
+// content so submitting the same workload twice returns the same ID.
+type workloadStore struct {
+	mu        sync.Mutex
+	workloads map[string][]Process
+}
+
+var submitted = &workloadStore{workloads: make(map[string][]Process)}
+
+func (s *workloadStore) put(processes []Process) (string, error) {
+	js, err := json.Marshal(processes)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(js)
+	id := hex.EncodeToString(sum[:8])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.workloads[id]; !ok && len(s.workloads) >= maxStoredWorkloads {
+		return "", grpcError{grpcResourceExhausted, fmt.Errorf("%w: %d workloads are already stored", ErrInvalidArgs, maxStoredWorkloads)}
+	}
+	s.workloads[id] = processes
+
+	return id, nil
+}
+
+func (s *workloadStore) get(id string) ([]Process, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	processes, ok := s.workloads[id]
+
+	return processes, ok
+}
+
+// handleGRPC serves the Simulator service of simulator.proto: gRPC over HTTP/2, which serve
+// accepts unencrypted next to HTTP/1. Messages are protobuf encoded by hand like -format proto,
+// and compressed messages are refused. Simulations run within limits, as over HTTP.
+func handleGRPC(limits simulationLimits) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			http.Error(w, "gRPC requests must be POSTs of application/grpc", http.StatusUnsupportedMediaType)
+			return
+		}
+		w.Header().Set("Content-Type", "application/grpc")
+		msg, err := readGRPCMessage(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err == nil {
+			switch method := strings.TrimPrefix(r.URL.Path, "/scheduler.Simulator/"); method {
+			case "SubmitWorkload":
+				err = grpcSubmitWorkload(w, msg)
+			case "RunSimulation":
+				err = grpcRunSimulation(r.Context(), w, msg, limits)
+			case "StreamEvents":
+				err = grpcStreamEvents(r.Context(), w, msg, limits)
+			default:
+				err = grpcError{grpcUnimplemented, fmt.Errorf("unknown method %q", r.URL.Path)}
+			}
+		}
+		writeGRPCStatus(w, err)
+	}
+}
+
+// readGRPCMessage reads the single length-prefixed message of a unary request.
+func readGRPCMessage(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, grpcError{grpcInvalidArgument, fmt.Errorf("%w: reading message prefix", err)}
+	}
+	if prefix[0] != 0 {
+		return nil, grpcError{grpcUnimplemented, errors.New("compressed messages are not supported")}
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxRequestBytes {
+		return nil, grpcError{grpcResourceExhausted, fmt.Errorf("message of %d bytes is over the %d byte limit", size, maxRequestBytes)}
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		return nil, grpcError{grpcInvalidArgument, fmt.Errorf("%w: reading message", err)}
+	}
+
+	return msg, nil
+}
+
+func writeGRPCMessage(w io.Writer, msg []byte) error {
+	b := binary.BigEndian.AppendUint32([]byte{0}, uint32(len(msg)))
+	_, err := w.Write(append(b, msg...))
+
+	return err
+}
+
+// writeGRPCStatus ends the call with the status of err in the trailers.
+func writeGRPCStatus(w http.ResponseWriter, err error) {
+	code, message := grpcOK, ""
+	if err != nil {
+		code, message = grpcInternal, err.Error()
+		var ge grpcError
+		if errors.As(err, &ge) {
+			code = ge.code
+		}
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", url.PathEscape(message))
+	}
+}
+
+func grpcSubmitWorkload(w io.Writer, msg []byte) error {
+	processes, err := unmarshalWorkload(msg)
+	if err != nil {
+		return grpcError{grpcInvalidArgument, err}
+	}
+	if err := validateProcesses(processes); err != nil {
+		return grpcError{grpcInvalidArgument, err}
+	}
+	id, err := submitted.put(processes)
+	if err != nil {
+		return err
+	}
+
+	return writeGRPCMessage(w, appendString(nil, 1, id))
+}
+
+func grpcRunSimulation(ctx context.Context, w io.Writer, msg []byte, limits simulationLimits) error {
+	results, err := grpcSimulate(ctx, msg, limits)
+	if err != nil {
+		return err
+	}
+
+	return writeGRPCMessage(w, marshalResults(results))
+}
+
+func grpcStreamEvents(ctx context.Context, w http.ResponseWriter, msg []byte, limits simulationLimits) error {
+	results, err := grpcSimulate(ctx, msg, limits)
+	if err != nil {
+		return err
+	}
+	rc := http.NewResponseController(w)
+	for _, r := range results {
+		for _, s := range r.Gantt {
+			event := appendBytes(appendString(nil, 1, r.Name), 2, marshalTimeSlice(s))
+			if err := writeGRPCMessage(w, event); err != nil {
+				return err
+			}
+		}
+		r.Gantt = nil
+		if err := writeGRPCMessage(w, appendBytes(appendString(nil, 1, r.Name), 3, marshalResult(r))); err != nil {
+			return err
+		}
+		_ = rc.Flush()
+	}
+
+	return nil
+}
+
+// grpcSimulate decodes a SimulationRequest and runs it within limits as POST /simulate would, until
+// ctx is done.
+func grpcSimulate(ctx context.Context, msg []byte, limits simulationLimits) ([]Result, error) {
+	req, id, err := unmarshalSimulationRequest(msg)
+	if err != nil {
+		return nil, grpcError{grpcInvalidArgument, err}
+	}
+	if id != "" {
+		var ok bool
+		if req.Processes, ok = submitted.get(id); !ok {
+			return nil, grpcError{grpcNotFound, fmt.Errorf("%w: no workload %q was submitted", ErrInvalidArgs, id)}
+		}
+	}
+	results, err := simulate(ctx, req, limits)
+	if ctx.Err() != nil {
+		return nil, grpcError{grpcCancelled, err}
+	}
+	if err != nil {
+		metrics.reject()
+		if errors.Is(err, ErrRequestTooLarge) || errors.Is(err, ErrResourceLimit) {
+			return nil, grpcError{grpcResourceExhausted, err}
+		}
+		if errors.Is(err, ErrInvalidSchedule) {
+			// the request was fine; a loaded scheduler was not
+			return nil, grpcError{grpcInternal, err}
+		}
+		return nil, grpcError{grpcInvalidArgument, err}
+	}
+	metrics.observe(req.Processes, results)
+
+	return results, nil
+}
+
+// unmarshalSimulationRequest decodes a SimulationRequest, returning its workload ID when it
+// names a submitted workload rather than carrying one.
+func unmarshalSimulationRequest(b []byte) (simulateRequest, string, error) {
+	var req simulateRequest
+	var id string
+	err := eachField(b, func(f protoField) error {
+		var err error
+		switch f.num {
+		case 1:
+			id = string(f.data)
+		case 2:
+			req.Processes, err = unmarshalWorkload(f.data)
+		case 3:
+			req.Algorithms = append(req.Algorithms, string(f.data))
+		case 4:
+			err = unmarshalOptions(f.data, &req.Options)
+		case 5:
+			req.StrictFeatures = f.v != 0
+		}
+		return err
+	})
+
+	return req, id, err
+}
+
+// unmarshalOptions decodes the Options message of simulator.proto. Test_unmarshalOptions checks
+// that it sets every exported field of Options, so a new option needs a field there too.
+func unmarshalOptions(b []byte, opts *Options) error {
+	return eachField(b, func(f protoField) error {
+		switch f.num {
+		case 1:
+			opts.Quantum = int64(f.v)
+		case 2:
+			opts.Foreground = int64(f.v)
+		case 3:
+			opts.Alpha = f.double()
+		case 4:
+			opts.InitialGuess = int64(f.v)
+		case 5:
+			opts.Rounding = string(f.data)
+		case 6:
+			opts.InterQueue = string(f.data)
+		case 7:
+			opts.PreemptedFirst = f.v != 0
+		case 8:
+			opts.NonWorkConserving = f.v != 0
+		case 9:
+			opts.Lookahead = int64(f.v)
+		case 10:
+			opts.ForegroundSlice = int64(f.v)
+		case 11:
+			opts.BackgroundSlice = int64(f.v)
+		}
+		return nil
+	})
+}
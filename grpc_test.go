@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// grpcCall makes a unary or server-streaming call over h2c and returns the response messages and
+// the grpc-status trailer.
+func grpcCall(t *testing.T, srv *httptest.Server, method string, msg []byte) ([][]byte, string) {
+	t.Helper()
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	var body bytes.Buffer
+	if err := writeGRPCMessage(&body, msg); err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/scheduler.Simulator/"+method, &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages [][]byte
+	for len(data) >= 5 {
+		size := binary.BigEndian.Uint32(data[1:5])
+		messages, data = append(messages, data[5:5+size]), data[5+size:]
+	}
+
+	return messages, resp.Trailer.Get("Grpc-Status")
+}
+
+func Test_handleGRPC(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewUnstartedServer(newServeMux(defaultSimulationLimits()))
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	defer srv.Close()
+
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1, Depends: []int64{1}},
+	}
+	workload := marshalWorkload(processes)
+	got, err := unmarshalWorkload(workload)
+	if err != nil || !reflect.DeepEqual(got, processes) {
+		t.Fatalf("unmarshalWorkload() = %+v, %v, want %+v", got, err, processes)
+	}
+
+	messages, status := grpcCall(t, srv, "SubmitWorkload", workload)
+	if status != "0" || len(messages) != 1 {
+		t.Fatalf("SubmitWorkload status %s with %d messages, want 0 with 1", status, len(messages))
+	}
+	var id string
+	_ = eachField(messages[0], func(f protoField) error { id = string(f.data); return nil })
+	if again, _ := grpcCall(t, srv, "SubmitWorkload", workload); !bytes.Equal(again[0], messages[0]) {
+		t.Error("submitting the same workload twice gave different IDs")
+	}
+
+	request := appendString(appendString(appendString(nil, 1, id), 3, "fcfs"), 3, "rr")
+	messages, status = grpcCall(t, srv, "RunSimulation", request)
+	if status != "0" || len(messages) != 1 {
+		t.Fatalf("RunSimulation status %s with %d messages, want 0 with 1", status, len(messages))
+	}
+	results, err := unmarshalResults(messages[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Name != "fcfs" || results[1].Name != "rr" {
+		t.Fatalf("RunSimulation results = %+v, want fcfs then rr", results)
+	}
+
+	request = appendBytes(appendString(nil, 3, "fcfs"), 2, workload)
+	messages, status = grpcCall(t, srv, "StreamEvents", request)
+	if want := len(results[0].Gantt) + 1; status != "0" || len(messages) != want {
+		t.Fatalf("StreamEvents status %s with %d events, want 0 with %d", status, len(messages), want)
+	}
+	var kinds []int
+	for _, event := range messages {
+		_ = eachField(event, func(f protoField) error {
+			if f.num != 1 {
+				kinds = append(kinds, f.num)
+			}
+			return nil
+		})
+	}
+	if want := []int{2, 2, 3}; !reflect.DeepEqual(kinds, want) {
+		t.Errorf("StreamEvents event kinds = %v, want %v", kinds, want)
+	}
+
+	for _, tt := range []struct {
+		method, want string
+		msg          []byte
+	}{
+		{method: "RunSimulation", msg: appendString(nil, 1, "missing"), want: "5"},
+		{method: "RunSimulation", msg: appendBytes(appendString(nil, 3, "lottery"), 2, workload), want: "3"},
+		{method: "SubmitWorkload", msg: nil, want: "3"},
+		{method: "Cancel", msg: nil, want: "12"},
+		{method: "RunSimulation", msg: appendBytes(appendString(nil, 3, "rr"), 2, marshalWorkload([]Process{{ProcessID: 1, BurstDuration: 1e18}})), want: "8"},
+	} {
+		if _, status := grpcCall(t, srv, tt.method, tt.msg); status != tt.want {
+			t.Errorf("%s(% x) status = %s, want %s", tt.method, tt.msg, status, tt.want)
+		}
+	}
+}
+
+func Test_unmarshalOptions(t *testing.T) {
+	t.Parallel()
+	proto, err := os.ReadFile("simulator.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	block := regexp.MustCompile(`(?s)message Options \{(.*?)\n\}`).FindSubmatch(proto)
+	if block == nil {
+		t.Fatal("simulator.proto has no Options message")
+	}
+	// proto field numbers by the JSON name of the Options field they carry
+	numbers := map[string]int{}
+	for _, m := range regexp.MustCompile(`(?m)^\s*\w+ (\w+) = (\d+);`).FindAllSubmatch(block[1], -1) {
+		words := strings.Split(string(m[1]), "_")
+		for i := 1; i < len(words); i++ {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+		numbers[strings.Join(words, "")], _ = strconv.Atoi(string(m[2]))
+	}
+
+	typ := reflect.TypeOf(Options{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		num, ok := numbers[name]
+		if !ok {
+			t.Errorf("Options.%s has no field in the Options message of simulator.proto", field.Name)
+			continue
+		}
+		delete(numbers, name)
+
+		var msg []byte
+		want := reflect.New(field.Type).Elem()
+		switch field.Type.Kind() {
+		case reflect.Bool:
+			msg = binary.AppendUvarint(appendTag(nil, num, wireVarint), 1)
+			want.SetBool(true)
+		case reflect.Int64:
+			msg = appendInt(nil, num, -3)
+			want.SetInt(-3)
+		case reflect.Float64:
+			msg = appendDouble(nil, num, 0.25)
+			want.SetFloat(0.25)
+		case reflect.String:
+			msg = appendString(nil, num, "x")
+			want.SetString("x")
+		default:
+			t.Fatalf("Options.%s has kind %s, which the test cannot encode", field.Name, field.Type.Kind())
+		}
+		var got Options
+		if err := unmarshalOptions(msg, &got); err != nil {
+			t.Fatal(err)
+		}
+		if v := reflect.ValueOf(got).Field(i); !reflect.DeepEqual(v.Interface(), want.Interface()) {
+			t.Errorf("unmarshalOptions() of field %d set Options.%s to %v, want %v", num, field.Name, v, want)
+		}
+	}
+	for name, num := range numbers {
+		t.Errorf("field %d of the Options message, %s, matches no Options field", num, name)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// errAnswerDiffers reports a hand-drawn gantt chart that does not match the simulator's.
+var errAnswerDiffers = errors.New("answer differs from the simulated schedule")
+
+// parseHandGantt reads a hand-computed gantt chart as pid:start-stop entries separated by spaces,
+// commas, semicolons or newlines, in any order, e.g. "P1:0-3, P2:3-5". PIDs may carry a P prefix.
+func parseHandGantt(r io.Reader) ([]TimeSlice, error) {
+	text, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading answer", err)
+	}
+	entries := strings.FieldsFunc(string(text), func(c rune) bool {
+		return c == ',' || c == ';' || c == ' ' || c == '\t' || c == '\n' || c == '\r'
+	})
+	gantt := make([]TimeSlice, 0, len(entries))
+	for _, entry := range entries {
+		pid, span, ok := strings.Cut(entry, ":")
+		start, stop, ok2 := strings.Cut(span, "-")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("%w: answer entry %q is not pid:start-stop", ErrInvalidArgs, entry)
+		}
+		var s TimeSlice
+		var errs [3]error
+		s.PID, errs[0] = strconv.ParseInt(strings.TrimPrefix(strings.TrimPrefix(pid, "P"), "p"), 10, 64)
+		s.Start, errs[1] = strconv.ParseInt(start, 10, 64)
+		s.Stop, errs[2] = strconv.ParseInt(stop, 10, 64)
+		if err := errors.Join(errs[:]...); err != nil {
+			return nil, fmt.Errorf("%w: answer entry %q: %w", ErrInvalidArgs, entry, err)
+		}
+		if s.Start < 0 || s.Stop <= s.Start {
+			return nil, fmt.Errorf("%w: answer entry %q must stop after it starts", ErrInvalidArgs, entry)
+		}
+		gantt = append(gantt, s)
+	}
+
+	return gantt, nil
+}
+
+// ganttTimeline returns the PID running in each tick from 0 until the end of gantt, idlePID where
+// nothing runs, and whether any two slices claim the same tick.
+func ganttTimeline(gantt []TimeSlice) ([]int64, bool) {
+	var end int64
+	for _, s := range gantt {
+		end = max(end, s.Stop)
+	}
+	timeline := make([]int64, end)
+	overlap := false
+	for _, s := range gantt {
+		for t := s.Start; t < s.Stop; t++ {
+			overlap = overlap || timeline[t] != idlePID
+			timeline[t] = s.PID
+		}
+	}
+
+	return timeline, overlap
+}
+
+// diffGantt compares a hand-drawn gantt chart with the one algorithm name produced for processes
+// and explains the first tick where they part ways. It returns -1 when they match.
+func diffGantt(name string, processes []Process, simulated, answer []TimeSlice) (int64, string) {
+	want, _ := ganttTimeline(simulated)
+	// past the simulated end one tick is enough to disagree, however far the answer goes on
+	clipped := make([]TimeSlice, len(answer))
+	for i, s := range answer {
+		clipped[i] = s
+		clipped[i].Stop = min(s.Stop, int64(len(want))+1)
+		clipped[i].Start = min(s.Start, clipped[i].Stop)
+	}
+	got, overlap := ganttTimeline(clipped)
+	if overlap {
+		return 0, "two slices in the answer overlap; a single CPU runs one process at a time"
+	}
+	byPID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byPID[p.ProcessID] = p
+	}
+	ran := make(map[int64]int64, len(processes))
+	at := func(timeline []int64, t int) int64 {
+		if t < len(timeline) {
+			return timeline[t]
+		}
+		return idlePID
+	}
+
+	for t := 0; t < max(len(want), len(got)); t++ {
+		w, g := at(want, t), at(got, t)
+		if w == g {
+			ran[g]++
+			continue
+		}
+		head := fmt.Sprintf("at t=%d the answer %s but %s %s", t, describeTick(g), name, describeTick(w))
+		p, known := byPID[g]
+		var why string
+		switch {
+		case g != idlePID && !known:
+			why = fmt.Sprintf("there is no P%d in the workload", g)
+		case g != idlePID && p.ArrivalTime > int64(t):
+			why = fmt.Sprintf("P%d has not arrived yet; it arrives at %d", g, p.ArrivalTime)
+		case g != idlePID && ran[g] >= p.BurstDuration:
+			why = fmt.Sprintf("P%d already ran its whole burst of %d", g, p.BurstDuration)
+		case t >= len(want):
+			why = fmt.Sprintf("every process has finished by %d", len(want))
+		case g == idlePID:
+			why = fmt.Sprintf("P%d is ready, and %s does not leave the CPU idle here", w, name)
+		case w == idlePID:
+			why = fmt.Sprintf("%s leaves the CPU idle here", name)
+		default:
+			why = fmt.Sprintf("%s picks P%d", name, w)
+			if rule, ok := dispatchRules[name]; ok {
+				why += " by " + rule
+			}
+		}
+		return int64(t), head + ": " + why
+	}
+
+	return -1, ""
+}
+
+// describeTick says what a gantt chart does in a tick running pid.
+func describeTick(pid int64) string {
+	if pid == idlePID {
+		return "idles"
+	}
+
+	return fmt.Sprintf("runs P%d", pid)
+}
+
+// runCheck compares a hand-computed gantt chart with the simulator's schedule of a workload.
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	name := fs.String("algo", "fcfs", "`algorithm` the answer was worked out for")
+	quantum := fs.Int64("quantum", defaultQuantum, "round-robin time slice in `ticks`")
+	rounding := fs.String("rr-rounding", "exact", "how a process finishing mid-quantum is `charged`: exact or full")
+	rrTie := fs.String("rr-tie", "arrival", "who queues first when a process arrives as another is preempted: `arrival` or preempted")
+	preset := fs.String("convention", "", "textbook `preset` for the flags above: silberschatz, stallings or tanenbaum")
+	delim := fs.String("delimiter", ",", "field `separator` of the workload file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: usage: check [-algo name] workload.csv answer.txt", ErrInvalidArgs)
+	}
+	if *preset != "" {
+		if err := applyConvention(fs, *preset); err != nil {
+			return err
+		}
+	}
+	a := findAlgorithm(*name)
+	if a == nil {
+		return fmt.Errorf("%w: unknown -algo %q", ErrInvalidArgs, *name)
+	}
+	if _, ok := sliceRoundings[*rounding]; !ok {
+		return fmt.Errorf("%w: unknown -rr-rounding %q, want exact or full", ErrInvalidArgs, *rounding)
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		return err
+	}
+	processes, err := loadWorkloadFile(fs.Arg(0), delimiter)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("%w: opening answer", err)
+	}
+	defer func() { _ = f.Close() }()
+	answer, err := parseHandGantt(f)
+	if err != nil {
+		return err
+	}
+
+	opts := Options{Quantum: *quantum, Rounding: *rounding, PreemptedFirst: *rrTie == "preempted"}
+	result, err := runAlgorithmChecked(*a, io.Discard, processes, opts)
+	if err != nil {
+		return err
+	}
+	t, why := diffGantt(a.name, processes, result.Gantt, answer)
+	if t < 0 {
+		_, _ = fmt.Printf("the answer matches %s\n", a.name)
+		return nil
+	}
+	_, _ = fmt.Println(why)
+	_, _ = fmt.Print("expected:\n")
+	outputTextGantt(os.Stdout, NewGanttModel(result.Gantt), ganttLayout)
+
+	return errAnswerDiffers
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseHandGantt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		text    string
+		want    []TimeSlice
+		wantErr error
+	}{
+		{
+			name: "mixed separators",
+			text: "P1:0-3, 2:3-5;\np3:8-9\n",
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 8, Stop: 9}},
+		},
+		{name: "empty", text: "", want: []TimeSlice{}},
+		{name: "no span", text: "P1:3", wantErr: ErrInvalidArgs},
+		{name: "not a number", text: "P1:a-3", wantErr: ErrInvalidArgs},
+		{name: "backwards", text: "P1:3-3", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseHandGantt(strings.NewReader(tt.text))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseHandGantt() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHandGantt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_diffGantt(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 8, BurstDuration: 1},
+	}
+	simulated := []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 8, Stop: 9}}
+	tests := []struct {
+		name   string
+		answer []TimeSlice
+		wantT  int64
+		want   string
+	}{
+		{name: "match, split slice", answer: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 8, Stop: 9}}, wantT: -1},
+		{name: "before arrival", answer: []TimeSlice{{PID: 2, Start: 0, Stop: 2}}, wantT: 0, want: "at t=0 the answer runs P2 but fcfs runs P1: P2 has not arrived yet; it arrives at 1"},
+		{name: "wrong pick", answer: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}}, wantT: 1, want: "at t=1 the answer runs P2 but fcfs runs P1: fcfs picks P1 by earliest arrival"},
+		{name: "overrun", answer: []TimeSlice{{PID: 1, Start: 0, Stop: 4}}, wantT: 3, want: "at t=3 the answer runs P1 but fcfs runs P2: P1 already ran its whole burst of 3"},
+		{name: "idle while ready", answer: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 4, Stop: 6}}, wantT: 3, want: "at t=3 the answer idles but fcfs runs P2: P2 is ready, and fcfs does not leave the CPU idle here"},
+		{name: "too long", answer: append(append([]TimeSlice{}, simulated...), TimeSlice{PID: 3, Start: 9, Stop: 1 << 40}), wantT: 9, want: "at t=9 the answer runs P3 but fcfs idles: P3 already ran its whole burst of 1"},
+		{name: "overlap", answer: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 2, Stop: 4}}, wantT: 0, want: "two slices in the answer overlap; a single CPU runs one process at a time"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gotT, got := diffGantt("fcfs", processes, simulated, tt.answer)
+			if gotT != tt.wantT || got != tt.want {
+				t.Errorf("diffGantt() = %d, %q, want %d, %q", gotT, got, tt.wantT, tt.want)
+			}
+		})
+	}
+}
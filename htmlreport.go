@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// htmlWorkload is one workload's results for writeHTMLReports; a workload that could not be
+// loaded keeps its error and has no results.
+type htmlWorkload struct {
+	Name    string
+	Results []Result
+	Error   string
+}
+
+// htmlIndexRow is a line of the index page's summary table: one algorithm on one workload, or
+// a workload that failed.
+type htmlIndexRow struct {
+	Workload string
+	Link     string
+	Result   Result
+	Error    string
+}
+
+// htmlSortScript sorts a table by the column whose header is clicked, numerically when both
+// cells are numbers, toggling the direction on every click. A failed workload's row spans the
+// columns after the first, so its missing cells sort as empty.
+const htmlSortScript = `document.querySelectorAll("th").forEach((th, col) => th.addEventListener("click", () => {
+  const body = th.closest("table").tBodies[0];
+  const asc = th.dataset.order !== "asc";
+  th.dataset.order = asc ? "asc" : "desc";
+  const text = row => row.cells[col] ? row.cells[col].textContent : "";
+  const rows = [...body.rows].sort((a, b) => {
+    const x = text(a), y = text(b);
+    const nx = parseFloat(x), ny = parseFloat(y);
+    const c = isNaN(nx) || isNaN(ny) ? x.localeCompare(y) : nx - ny;
+    return asc ? c : -c;
+  });
+  body.append(...rows);
+}));`
+
+const htmlStyle = `body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: right; }
+th { cursor: pointer; background: #eee; }
+td:first-child, td:nth-child(2) { text-align: left; }
+.error { color: #b00; }`
+
+var htmlIndexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Scheduling results</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<h1>Scheduling results</h1>
+<p>{{len .Workloads}} workloads, {{.Failed}} failed. Click a column header to sort by it.</p>
+<table>
+<thead><tr><th>Workload</th><th>Algorithm</th><th>Avg wait</th><th>Avg turnaround</th><th>Avg response</th><th>Switches</th><th>Utilization</th><th>Fairness</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+{{- if .Error}}
+<tr><td>{{.Workload}}</td><td class="error" colspan="7">{{.Error}}</td></tr>
+{{- else}}
+<tr><td>{{.Workload}}</td><td><a href="{{.Link}}">{{.Result.Name}}</a></td><td>{{printf "%.2f" .Result.AveWait}}</td><td>{{printf "%.2f" .Result.AveTurnaround}}</td><td>{{printf "%.2f" .Result.AveResponse}}</td><td>{{.Result.ContextSwitches}}</td><td>{{printf "%.1f%%" .Result.Utilization}}</td><td>{{printf "%.3f" .Result.Fairness}}</td></tr>
+{{- end}}
+{{- end}}
+</tbody>
+</table>
+<script>{{.Script}}</script>
+</body>
+</html>
+`))
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Result.Title}}: {{.Workload}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<p><a href="../index.html">All results</a></p>
+<h1>{{.Result.Title}}</h1>
+<p>Workload {{.Workload}}</p>
+{{.Chart}}
+<table>
+<thead><tr><th>Avg wait</th><th>Avg turnaround</th><th>Avg response</th><th>Throughput</th><th>Switches</th><th>Idle</th><th>Utilization</th><th>Fairness</th></tr></thead>
+<tbody><tr><td>{{printf "%.2f" .Result.AveWait}}</td><td>{{printf "%.2f" .Result.AveTurnaround}}</td><td>{{printf "%.2f" .Result.AveResponse}}</td><td>{{printf "%.2f/t" .Result.AveThroughput}}</td><td>{{.Result.ContextSwitches}}</td><td>{{.Result.IdleTime}}</td><td>{{printf "%.1f%%" .Result.Utilization}}</td><td>{{printf "%.3f" .Result.Fairness}}</td></tr></tbody>
+</table>
+<h2>Schedule</h2>
+<table>
+<thead><tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr></thead>
+<tbody>
+{{- range .Result.Schedule}}
+<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{- end}}
+</tbody>
+</table>
+<script>{{.Script}}</script>
+</body>
+</html>
+`))
+
+// htmlSlug names the directory of the i-th workload's reports after its file, numbered so that
+// files of the same name in different directories do not collide.
+func htmlSlug(i int, name string) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	base = strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			return r
+		}
+		return '-'
+	}, base)
+
+	return fmt.Sprintf("%03d-%s", i+1, base)
+}
+
+// writeHTMLReports writes a page per workload and algorithm, with its Gantt chart, metrics and
+// schedule, into dir/<workload>/<algorithm>.html, and dir/index.html linking them all from a
+// sortable summary table. dir is created as needed.
+func writeHTMLReports(dir string, workloads []htmlWorkload) error {
+	var rows []htmlIndexRow
+	var failed int
+	for i, wl := range workloads {
+		if wl.Error != "" {
+			failed++
+			rows = append(rows, htmlIndexRow{Workload: wl.Name, Error: wl.Error})
+			continue
+		}
+		slug := htmlSlug(i, wl.Name)
+		for _, r := range wl.Results {
+			var chart strings.Builder
+			outputGanttSVG(&chart, r.Title, r.Gantt)
+			err := writeHTMLFile(filepath.Join(dir, slug, r.Name+".html"), htmlReportTemplate, map[string]any{
+				"Workload": wl.Name,
+				"Result":   r,
+				"Header":   scheduleHeader,
+				// outputGanttSVG escapes the only text it is given, the title
+				"Chart":  template.HTML(chart.String()),
+				"Style":  template.CSS(htmlStyle),
+				"Script": template.JS(htmlSortScript),
+			})
+			if err != nil {
+				return err
+			}
+			rows = append(rows, htmlIndexRow{Workload: wl.Name, Link: slug + "/" + r.Name + ".html", Result: r})
+		}
+	}
+
+	return writeHTMLFile(filepath.Join(dir, "index.html"), htmlIndexTemplate, map[string]any{
+		"Workloads": workloads,
+		"Failed":    failed,
+		"Rows":      rows,
+		"Style":     template.CSS(htmlStyle),
+		"Script":    template.JS(htmlSortScript),
+	})
+}
+
+func writeHTMLFile(path string, tmpl *template.Template, data any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("%w: creating HTML report directory", err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating HTML report", err)
+	}
+	if err := tmpl.Execute(f, data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing HTML report", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing HTML report", err)
+	}
+
+	return nil
+}
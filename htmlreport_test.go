@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_htmlSlug(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		i    int
+		name string
+		want string
+	}{
+		{i: 0, name: "workloads/heavy.csv", want: "001-heavy"},
+		{i: 11, name: "a b&c.csv.gz", want: "012-a-b-c-csv"},
+		{i: 2, name: "run 3", want: "003-run-3"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := htmlSlug(tt.i, tt.name); got != tt.want {
+				t.Errorf("htmlSlug(%d, %q) = %q, want %q", tt.i, tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeHTMLReports(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}}
+	var results []Result
+	for _, a := range algorithms[:2] {
+		results = append(results, runAlgorithm(a, io.Discard, processes, Options{}))
+	}
+	dir := t.TempDir()
+	workloads := []htmlWorkload{
+		{Name: "small.csv", Results: results},
+		{Name: "broken.csv", Error: "line 2: <bad> burst"},
+	}
+	if err := writeHTMLReports(dir, workloads); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<a href="001-small/fcfs.html">fcfs</a>`,
+		`<a href="001-small/sjf.html">sjf</a>`,
+		"line 2: &lt;bad&gt; burst",
+		"2 workloads, 1 failed",
+		"<script>",
+	} {
+		if !strings.Contains(string(index), want) {
+			t.Errorf("index.html lacks %q:\n%s", want, index)
+		}
+	}
+	report, err := os.ReadFile(filepath.Join(dir, "001-small", "fcfs.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"<svg", `href="../index.html"`, "<th>Turnaround</th>"} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("fcfs.html lacks %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const icsTimeFormat = "20060102T150405Z"
+
+// icsEscaper escapes TEXT values per RFC 5545 §3.3.11.
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// writeICS writes the time slices of every result as calendar events to path.
+func writeICS(path string, epoch time.Time, unit time.Duration, results []Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating calendar file", err)
+	}
+	outputICS(f, epoch, unit, results)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing calendar file", err)
+	}
+
+	return nil
+}
+
+// outputICS renders results as an iCalendar, mapping tick t to epoch + t*unit.
+// Each algorithm becomes a category so calendar apps can toggle them independently.
+func outputICS(w io.Writer, epoch time.Time, unit time.Duration, results []Result) {
+	at := func(t int64) string { return epoch.Add(time.Duration(t) * unit).UTC().Format(icsTimeFormat) }
+	line := func(format string, a ...any) { _, _ = fmt.Fprintf(w, format+"\r\n", a...) }
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Project1//Process Scheduler//EN")
+	for _, r := range results {
+		title := icsEscaper.Replace(r.Title)
+		for i, slice := range r.Gantt {
+			line("BEGIN:VEVENT")
+			line("UID:%s-%d@project1", r.Name, i)
+			line("DTSTAMP:%s", at(0))
+			line("DTSTART:%s", at(slice.Start))
+			line("DTEND:%s", at(slice.Stop))
+			line("SUMMARY:P%d (%s)", slice.PID, title)
+			line("CATEGORIES:%s", title)
+			line("END:VEVENT")
+		}
+	}
+	line("END:VCALENDAR")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_outputICS(t *testing.T) {
+	t.Parallel()
+	results := []Result{
+		{
+			Name:  "fcfs",
+			Title: "First-come, first-serve",
+			Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}},
+		},
+	}
+	epoch := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var w bytes.Buffer
+	outputICS(&w, epoch, time.Hour, results)
+	got := w.String()
+
+	want := strings.Join([]string{
+		"BEGIN:VEVENT",
+		"UID:fcfs-1@project1",
+		"DTSTAMP:20000101T000000Z",
+		"DTSTART:20000101T050000Z",
+		"DTEND:20000101T140000Z",
+		`SUMMARY:P2 (First-come\, first-serve)`,
+		`CATEGORIES:First-come\, first-serve`,
+		"END:VEVENT",
+	}, "\r\n")
+	if !strings.Contains(got, want) {
+		t.Errorf("outputICS() = %v, want to contain %v", got, want)
+	}
+	if !strings.HasPrefix(got, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(got, "END:VCALENDAR\r\n") {
+		t.Errorf("outputICS() = %v, want a VCALENDAR wrapper", got)
+	}
+}
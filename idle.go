@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// idleForShorterJob decides whether a non-preemptive scheduler should leave the CPU idle rather
+// than dispatch next at time now. With n processes ready, running next (burst b) first delays the
+// others by b and a job arriving after d ticks by b-d; idling for that job (burst bj) delays all n
+// ready processes by d+bj. Idling wins when n(d+bj) < nb-d. future holds the processes yet to
+// arrive, in arrival order; only those arriving within lookahead ticks are considered (all of them
+// when lookahead is negative). It returns the arrival time to idle until for the job that saves
+// the most waiting time.
+func idleForShorterJob(ready int64, future []Process, next Process, now, lookahead int64) (int64, bool) {
+	var (
+		best    int64
+		saving  int64
+		idleFor bool
+	)
+	for _, p := range future {
+		d := p.ArrivalTime - now
+		if lookahead >= 0 && d > lookahead {
+			break
+		}
+		if p.BurstDuration >= next.BurstDuration {
+			continue
+		}
+		if s := ready*next.BurstDuration - d - ready*(d+p.BurstDuration); s > saving {
+			best, saving, idleFor = p.ArrivalTime, s, true
+		}
+	}
+
+	return best, idleFor
+}
+
+// outputIdlingEffect compares a non-work-conserving run against the work-conserving one.
+func outputIdlingEffect(w io.Writer, conserving, idling Result) {
+	verdict := "no change"
+	switch diff := conserving.AveWait - idling.AveWait; {
+	case diff > 0:
+		verdict = fmt.Sprintf("improved by %.2f", diff)
+	case diff < 0:
+		verdict = fmt.Sprintf("worse by %.2f", -diff)
+	}
+	_, _ = fmt.Fprintf(w, "non-work-conserving %s: average wait %.2f vs %.2f work-conserving (%s)\n",
+		idling.Name, idling.AveWait, conserving.AveWait, verdict)
+}
+
+// outputLookaheadSweep reruns each algorithm non-work-conserving once per lookahead window, showing
+// how much knowing future arrivals is worth to it. It stops at the first run that fails.
+func outputLookaheadSweep(w io.Writer, processes []Process, selected []algorithm, windows []int64) error {
+	_, _ = fmt.Fprintln(w, "Lookahead sweep")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Lookahead", "Avg wait", "Avg turnaround", "Avg response"})
+	for _, a := range selected {
+		for _, l := range windows {
+			r, err := runAlgorithmChecked(a, io.Discard, processes, Options{NonWorkConserving: true, Lookahead: l})
+			if err != nil {
+				return err
+			}
+			table.Append([]string{
+				a.name,
+				fmt.Sprint(l),
+				fmt.Sprintf("%.2f", r.AveWait),
+				fmt.Sprintf("%.2f", r.AveTurnaround),
+				fmt.Sprintf("%.2f", r.AveResponse),
+			})
+		}
+	}
+	table.Render()
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_idleForShorterJob(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		ready     int64
+		future    []Process
+		next      Process
+		now       int64
+		lookahead int64
+		want      int64
+		wantIdle  bool
+	}{
+		{
+			name:      "imminent short job",
+			ready:     1,
+			future:    []Process{{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1}},
+			next:      Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+			lookahead: -1,
+			want:      1,
+			wantIdle:  true,
+		},
+		{
+			name:      "short job beyond lookahead",
+			ready:     1,
+			future:    []Process{{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1}},
+			next:      Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+			lookahead: 1,
+		},
+		{
+			name:      "short job too far away",
+			ready:     1,
+			future:    []Process{{ProcessID: 2, ArrivalTime: 3, BurstDuration: 1}},
+			next:      Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+			lookahead: -1,
+		},
+		{
+			name:      "no shorter job",
+			ready:     1,
+			future:    []Process{{ProcessID: 2, ArrivalTime: 1, BurstDuration: 5}},
+			next:      Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+			lookahead: -1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, idle := idleForShorterJob(tt.ready, tt.future, tt.next, tt.now, tt.lookahead)
+			if got != tt.want || idle != tt.wantIdle {
+				t.Errorf("idleForShorterJob() = %v, %v, want %v, %v", got, idle, tt.want, tt.wantIdle)
+			}
+		})
+	}
+}
+
+func Test_sjfSchedule_nonWorkConserving(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}
+	conserving := sjfSchedule(io.Discard, "", processes, Options{})
+	idling := sjfSchedule(io.Discard, "", processes, Options{NonWorkConserving: true, Lookahead: -1})
+
+	want := []TimeSlice{{PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 12}}
+	if !reflect.DeepEqual(idling.Gantt, want) {
+		t.Errorf("gantt = %v, want %v", idling.Gantt, want)
+	}
+	if conserving.AveWait != 4.5 || idling.AveWait != 1 {
+		t.Errorf("average wait = %v work-conserving, %v idling, want 4.5 and 1", conserving.AveWait, idling.AveWait)
+	}
+
+	var w bytes.Buffer
+	conserving.Name, idling.Name = "sjf", "sjf"
+	outputIdlingEffect(&w, conserving, idling)
+	if got, want := w.String(), "non-work-conserving sjf: average wait 1.00 vs 4.50 work-conserving (improved by 3.50)\n"; got != want {
+		t.Errorf("outputIdlingEffect() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputLookaheadSweep(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}
+	var w bytes.Buffer
+	if err := outputLookaheadSweep(&w, processes, []algorithm{*findAlgorithm("sjf")}, []int64{0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"| sjf       |         0 |     4.50 |",
+		"| sjf       |         1 |     1.00 |",
+	} {
+		if !bytes.Contains(w.Bytes(), []byte(want)) {
+			t.Errorf("outputLookaheadSweep() = %v, want to contain %v", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// ErrResourceLimit is wrapped by the error a resourceGuard returns when a run exceeds its limits.
+var ErrResourceLimit = errors.New("resource limit exceeded")
+
+const (
+	// memPollInterval is how often a guarded run samples heap usage.
+	memPollInterval = 10 * time.Millisecond
+	// cancelGrace is how long a guarded run that hit a limit waits for the cancelled scheduler to
+	// return its partial result before abandoning it.
+	cancelGrace = time.Second
+)
+
+// Context returns the context that cancels a run with opts, never nil.
+func (opts Options) Context() context.Context {
+	if opts.ctx == nil {
+		return context.Background()
+	}
+
+	return opts.ctx
+}
+
+// WithContext returns a copy of opts whose runs stop, with the partial schedule so far, once ctx
+// is done.
+func (opts Options) WithContext(ctx context.Context) Options {
+	opts.ctx = ctx
+	return opts
+}
+
+// resourceGuard caps the wall-clock time and heap size of a whole simulation, shared across every
+// algorithm it runs. A zero deadline or memory limit disables that check.
+type resourceGuard struct {
+	deadline time.Time
+	memLimit uint64
+}
+
+// newResourceGuard starts the clock for a simulation allowed timeout and memLimit bytes of heap.
+func newResourceGuard(timeout time.Duration, memLimit uint64) *resourceGuard {
+	g := &resourceGuard{memLimit: memLimit}
+	if timeout > 0 {
+		g.deadline = time.Now().Add(timeout)
+	}
+
+	return g
+}
+
+// run calls f, cancelling its context with an ErrResourceLimit error as soon as the deadline
+// passes or the heap grows past the limit. It then returns the partial result f stops with, or a
+// zero Result if f ignores the cancellation, in which case its goroutine is left to the caller's
+// exit.
+func (g *resourceGuard) run(f func(ctx context.Context) Result) (Result, error) {
+	if g.deadline.IsZero() && g.memLimit == 0 {
+		return f(context.Background()), nil
+	}
+	if err := g.check(); err != nil {
+		return Result{}, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan Result, 1)
+	go func() { done <- f(ctx) }()
+	stop := func(err error) (Result, error) {
+		cancel()
+		select {
+		case r := <-done:
+			return r, err
+		case <-time.After(cancelGrace):
+			return Result{}, err
+		}
+	}
+
+	var timeout <-chan time.Time
+	if !g.deadline.IsZero() {
+		timer := time.NewTimer(time.Until(g.deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	poll := time.NewTicker(memPollInterval)
+	defer poll.Stop()
+	for {
+		select {
+		case r := <-done:
+			return r, nil
+		case <-timeout:
+			return stop(g.check())
+		case <-poll.C:
+			if err := g.check(); err != nil {
+				return stop(err)
+			}
+		}
+	}
+}
+
+// check reports whether the simulation is already over its limits.
+func (g *resourceGuard) check() error {
+	if !g.deadline.IsZero() && !time.Now().Before(g.deadline) {
+		return fmt.Errorf("%w: simulation ran past its -timeout", ErrResourceLimit)
+	}
+	if g.memLimit > 0 {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		if m.HeapAlloc > g.memLimit {
+			return fmt.Errorf("%w: heap reached %d bytes, over the -mem-limit of %d", ErrResourceLimit, m.HeapAlloc, g.memLimit)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_resourceGuard_run(t *testing.T) {
+	t.Parallel()
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		memMax  uint64
+		f       func(context.Context) Result
+		want    string
+		wantErr error
+	}{
+		{
+			name: "unlimited",
+			f:    func(context.Context) Result { return Result{Name: "fcfs"} },
+			want: "fcfs",
+		},
+		{
+			name:    "within limits",
+			timeout: time.Minute,
+			memMax:  1 << 40,
+			f:       func(context.Context) Result { return Result{Name: "sjf"} },
+			want:    "sjf",
+		},
+		{
+			name:    "timeout",
+			timeout: time.Millisecond,
+			f:       func(context.Context) Result { <-block; return Result{} },
+			wantErr: ErrResourceLimit,
+		},
+		{
+			name:    "timeout with a partial result",
+			timeout: 50 * time.Millisecond,
+			f: func(ctx context.Context) Result {
+				<-ctx.Done()
+				return Result{Name: "rr"}
+			},
+			want:    "rr",
+			wantErr: ErrResourceLimit,
+		},
+		{
+			name:    "memory",
+			memMax:  1,
+			f:       func(context.Context) Result { <-block; return Result{} },
+			wantErr: ErrResourceLimit,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// the deadline starts now, as parallel subtests only start once the serial tests are done
+			got, err := newResourceGuard(tt.timeout, tt.memMax).run(tt.f)
+			if got.Name != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("run() = %v, %v, want %v, %v", got.Name, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
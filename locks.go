@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// lockSpan is a stretch of a process's burst during which it holds a shared resource: it
+// acquires Resource after running Acquire ticks and releases it after running Release ticks.
+type lockSpan struct {
+	PID      int64
+	Resource string
+	Acquire  int64
+	Release  int64
+}
+
+// loadLocks reads lock annotations as pid,resource,acquire,release rows, with an optional header
+// and # comments, and checks them against processes. A process may hold one resource at a time,
+// which also rules out deadlock.
+func loadLocks(r io.Reader, processes []Process) ([]lockSpan, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = 4
+	cr.TrimLeadingSpace = true
+	var spans []lockSpan
+	for first := true; ; first = false {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading locks", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if _, err := strconv.ParseInt(row[0], 10, 64); first && err != nil {
+			continue // header
+		}
+		var values [3]int64
+		for k, pos := range []int{0, 2, 3} {
+			if values[k], err = strconv.ParseInt(strings.TrimSpace(row[pos]), 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: locks line %d, column %d: %w", ErrInvalidArgs, line, pos+1, err)
+			}
+		}
+		s := lockSpan{PID: values[0], Resource: strings.TrimSpace(row[1]), Acquire: values[1], Release: values[2]}
+		switch {
+		case s.PID < 1 || s.PID > int64(len(processes)):
+			return nil, fmt.Errorf("%w: locks line %d: unknown PID %d", ErrInvalidArgs, line, s.PID)
+		case s.Resource == "":
+			return nil, fmt.Errorf("%w: locks line %d: empty resource name", ErrInvalidArgs, line)
+		case s.Acquire < 0 || s.Release <= s.Acquire:
+			return nil, fmt.Errorf("%w: locks line %d: PID %d must release %s after acquiring it", ErrInvalidArgs, line, s.PID, s.Resource)
+		}
+		spans = append(spans, s)
+	}
+
+	burst := make([]int64, len(processes))
+	for _, p := range processes {
+		burst[p.ProcessID-1] = p.BurstDuration
+	}
+	sort.SliceStable(spans, func(a, b int) bool {
+		if spans[a].PID != spans[b].PID {
+			return spans[a].PID < spans[b].PID
+		}
+		return spans[a].Acquire < spans[b].Acquire
+	})
+	for k, s := range spans {
+		if s.Release > burst[s.PID-1] {
+			return nil, fmt.Errorf("%w: PID %d releases %s at %d, after its burst of %d", ErrInvalidArgs, s.PID, s.Resource, s.Release, burst[s.PID-1])
+		}
+		if k > 0 && spans[k-1].PID == s.PID && s.Acquire < spans[k-1].Release {
+			return nil, fmt.Errorf("%w: PID %d acquires %s at %d while still holding %s", ErrInvalidArgs, s.PID, s.Resource, s.Acquire, spans[k-1].Resource)
+		}
+	}
+
+	return spans, nil
+}
+
+// loadLocksFile is loadLocks for the file at path.
+func loadLocksFile(path string, processes []Process) ([]lockSpan, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening locks", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return loadLocks(f, processes)
+}
+
+// lockReady is the ready set of preemptive priority scheduling with shared resources. It also
+// tracks each process's progress through its lock spans, through the engine's ran and checkpoint
+// hooks. A process blocked on a held resource stays in the set but is never dispatched; when the
+// resource is released it is handed to the highest-priority waiter. With inherit, a holder runs
+// at the priority of the highest-priority process it blocks.
+type lockReady struct {
+	processes []Process
+	inherit   bool
+	items     []int
+	// spans holds each process's lock spans in order; nextSpan indexes the next one to acquire
+	// and held the one being held, or -1
+	spans    [][]lockSpan
+	resource map[string]int
+	nextSpan []int
+	held     []int
+	progress []int64
+	// holder is the process holding each resource, or -1; waiting is the resource each process
+	// is blocked on, or -1
+	holder  []int
+	waiting []int
+	// priority is each process's effective priority
+	priority []int64
+	// clock counts ticks of CPU time; a process is only blocked while its resource's holder can
+	// run, so the CPU is never idle in between and the clock measures blocking exactly
+	clock        int64
+	blockedSince []int64
+	blocked      []int64
+}
+
+func newLockReady(processes []Process, spans []lockSpan, inherit bool) *lockReady {
+	n := len(processes)
+	q := &lockReady{
+		processes:    processes,
+		inherit:      inherit,
+		spans:        make([][]lockSpan, n),
+		resource:     make(map[string]int),
+		nextSpan:     make([]int, n),
+		held:         make([]int, n),
+		progress:     make([]int64, n),
+		waiting:      make([]int, n),
+		priority:     make([]int64, n),
+		blockedSince: make([]int64, n),
+		blocked:      make([]int64, n),
+	}
+	index := make([]int, n) // workload index by PID-1
+	for i, p := range processes {
+		index[p.ProcessID-1] = i
+		q.held[i], q.waiting[i], q.priority[i] = -1, -1, p.Priority
+	}
+	for _, s := range spans {
+		if _, ok := q.resource[s.Resource]; !ok {
+			q.resource[s.Resource] = len(q.holder)
+			q.holder = append(q.holder, -1)
+		}
+		i := index[s.PID-1]
+		q.spans[i] = append(q.spans[i], s)
+	}
+
+	return q
+}
+
+func (q *lockReady) len() int { return len(q.items) }
+
+// push adds i to the set; a process arriving with a lock at offset 0 tries to take it now.
+func (q *lockReady) push(i int) {
+	q.items = append(q.items, i)
+	q.reach(i)
+}
+
+// best returns the position in items of the highest-priority process that is not blocked, ties in
+// workload order. One always exists, since whoever holds a blocked process's resource is ready or
+// running.
+func (q *lockReady) best() int {
+	best := -1
+	for pos, i := range q.items {
+		if q.waiting[i] >= 0 {
+			continue
+		}
+		if b := q.items[max(best, 0)]; best < 0 || q.priority[i] < q.priority[b] || q.priority[i] == q.priority[b] && i < b {
+			best = pos
+		}
+	}
+
+	return best
+}
+
+func (q *lockReady) peek() int { return q.items[q.best()] }
+
+func (q *lockReady) pop() int {
+	pos := q.best()
+	i := q.items[pos]
+	q.items = append(q.items[:pos], q.items[pos+1:]...)
+
+	return i
+}
+
+// before reports whether a should take the CPU from b: b is blocked, or a has a strictly higher
+// effective priority.
+func (q *lockReady) before(a, b int) bool {
+	return q.waiting[b] >= 0 || q.priority[a] < q.priority[b]
+}
+
+// checkpoint returns the ticks until i next acquires or releases a resource.
+func (q *lockReady) checkpoint(i int) int64 {
+	switch {
+	case q.held[i] >= 0:
+		return q.spans[i][q.held[i]].Release - q.progress[i]
+	case q.nextSpan[i] < len(q.spans[i]):
+		return q.spans[i][q.nextSpan[i]].Acquire - q.progress[i]
+	}
+
+	return 0
+}
+
+func (q *lockReady) ran(i int, run int64) {
+	q.clock += run
+	q.progress[i] += run
+	q.reach(i)
+}
+
+// reach releases and acquires whatever i's progress has reached, blocking i on a held resource.
+func (q *lockReady) reach(i int) {
+	if k := q.held[i]; k >= 0 && q.progress[i] == q.spans[i][k].Release {
+		q.release(i)
+	}
+	k := q.nextSpan[i]
+	if q.held[i] >= 0 || q.waiting[i] >= 0 || k >= len(q.spans[i]) || q.progress[i] != q.spans[i][k].Acquire {
+		return
+	}
+	r := q.resource[q.spans[i][k].Resource]
+	if h := q.holder[r]; h >= 0 {
+		q.waiting[i], q.blockedSince[i] = r, q.clock
+		if q.inherit {
+			q.priority[h] = min(q.priority[h], q.priority[i])
+		}
+		return
+	}
+	q.acquire(i, r)
+}
+
+func (q *lockReady) acquire(i, r int) {
+	q.holder[r], q.held[i] = i, q.nextSpan[i]
+	q.nextSpan[i]++
+}
+
+// release frees i's resource and hands it to the highest-priority process waiting for it, which
+// then inherits from the rest of the waiters.
+func (q *lockReady) release(i int) {
+	r := q.resource[q.spans[i][q.held[i]].Resource]
+	q.holder[r], q.held[i] = -1, -1
+	q.priority[i] = q.processes[i].Priority
+	next := -1
+	for j, w := range q.waiting {
+		if w == r && (next < 0 || q.processes[j].Priority < q.processes[next].Priority) {
+			next = j
+		}
+	}
+	if next < 0 {
+		return
+	}
+	q.waiting[next] = -1
+	q.blocked[next] += q.clock - q.blockedSince[next]
+	q.acquire(next, r)
+	if !q.inherit {
+		return
+	}
+	for j, w := range q.waiting {
+		if w == r {
+			q.priority[next] = min(q.priority[next], q.priority[j])
+		}
+	}
+}
+
+// lockPolicy is preemptive priority scheduling over processes that share resources.
+type lockPolicy struct {
+	spans   []lockSpan
+	inherit bool
+}
+
+// algorithm registers the policy so it runs and compares like the built-in ones.
+func (l lockPolicy) algorithm() algorithm {
+	title := "Priority with locks"
+	if l.inherit {
+		title += " (priority inheritance)"
+	}
+
+	return algorithm{name: "locks", title: title, schedule: l.schedule, preemptive: true, priorities: true}
+}
+
+// simulate returns the schedule and, indexed like processes, how long each one was blocked on a
+// resource.
+func (l lockPolicy) simulate(ctx context.Context, processes []Process) ([]TimeSlice, []int64) {
+	ready := newLockReady(processes, l.spans, l.inherit)
+	gantt := simulateEvents(ctx, processes, simPolicy{
+		ready:      func([]int64) readySet { return ready },
+		checkpoint: ready.checkpoint,
+		ran:        ready.ran,
+		preemptive: true,
+	})
+
+	return gantt, ready.blocked
+}
+
+func (l lockPolicy) schedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	gantt, _ := l.simulate(opts.Context(), processes)
+	return resultFromGantt(w, title, processes, gantt)
+}
+
+// inversionRow is one process's blocking and turnaround without and with priority inheritance.
+type inversionRow struct {
+	process               Process
+	blocked, turnaround   float64
+	inherited, inheritedT float64
+}
+
+func inversionRows(processes []Process, spans []lockSpan) []inversionRow {
+	plain, blocked := lockPolicy{spans: spans}.simulate(context.Background(), processes)
+	inheriting, inheritedBlocked := lockPolicy{spans: spans, inherit: true}.simulate(context.Background(), processes)
+	times, inheritedTimes := processTimesFromGantt(processes, plain), processTimesFromGantt(processes, inheriting)
+	rows := make([]inversionRow, len(processes))
+	for i, p := range processes {
+		rows[i] = inversionRow{
+			process:    p,
+			blocked:    float64(blocked[i]),
+			turnaround: times.turnaround[i],
+			inherited:  float64(inheritedBlocked[i]),
+			inheritedT: inheritedTimes.turnaround[i],
+		}
+	}
+
+	return rows
+}
+
+// outputInversion compares how long each process is blocked on resources, and its turnaround,
+// without and with priority inheritance.
+func outputInversion(w io.Writer, processes []Process, spans []lockSpan) {
+	_, _ = fmt.Fprintln(w, "Priority inversion")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Priority", "Blocked", "Blocked (inheritance)", "Turnaround", "Turnaround (inheritance)"})
+	for _, r := range inversionRows(processes, spans) {
+		table.Append([]string{
+			fmt.Sprint(r.process.ProcessID),
+			fmt.Sprint(r.process.Priority),
+			fmt.Sprint(r.blocked),
+			fmt.Sprint(r.inherited),
+			fmt.Sprint(r.turnaround),
+			fmt.Sprint(r.inheritedT),
+		})
+	}
+	table.Render()
+}
+
+// outputPlainInversion is the -plain form of outputInversion.
+func outputPlainInversion(w io.Writer, processes []Process, spans []lockSpan) {
+	for _, r := range inversionRows(processes, spans) {
+		_, _ = fmt.Fprintf(w, "inversion: pid %d, priority %d, blocked %v, blocked with inheritance %v, turnaround %v, turnaround with inheritance %v\n",
+			r.process.ProcessID, r.process.Priority, r.blocked, r.inherited, r.turnaround, r.inheritedT)
+	}
+}
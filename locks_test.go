@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_lockPolicy_simulate(t *testing.T) {
+	t.Parallel()
+	// the classic inversion: low-priority 1 holds R when high-priority 3 needs it, and medium 2 runs
+	// in between unless 1 inherits 3's priority
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 4, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+	}
+	spans := []lockSpan{{PID: 1, Resource: "R", Acquire: 1, Release: 3}, {PID: 3, Resource: "R", Acquire: 1, Release: 2}}
+	tests := []struct {
+		name        string
+		inherit     bool
+		wantGantt   []TimeSlice
+		wantBlocked []int64
+	}{
+		{
+			name: "inversion",
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 7},
+				{PID: 1, Start: 7, Stop: 8}, {PID: 3, Start: 8, Stop: 10}, {PID: 1, Start: 10, Stop: 11},
+			},
+			wantBlocked: []int64{0, 0, 5},
+		},
+		{
+			name:    "inheritance",
+			inherit: true,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 10}, {PID: 1, Start: 10, Stop: 11},
+			},
+			wantBlocked: []int64{0, 0, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gantt, blocked := lockPolicy{spans: spans, inherit: tt.inherit}.simulate(context.Background(), processes)
+			if !reflect.DeepEqual(gantt, tt.wantGantt) {
+				t.Errorf("simulate() gantt = %v, want %v", gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(blocked, tt.wantBlocked) {
+				t.Errorf("simulate() blocked = %v, want %v", blocked, tt.wantBlocked)
+			}
+		})
+	}
+}
+
+func Test_loadLocks(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, BurstDuration: 3}}
+	tests := []struct {
+		name    string
+		in      string
+		want    []lockSpan
+		wantErr error
+	}{
+		{
+			name: "header and comments",
+			in:   "pid,resource,acquire,release\n# disk first\n2, disk, 0, 2\n1,disk,3,5\n1,net,0,2\n",
+			want: []lockSpan{{1, "net", 0, 2}, {1, "disk", 3, 5}, {2, "disk", 0, 2}},
+		},
+		{name: "past the burst", in: "2,disk,1,4\n", wantErr: ErrInvalidArgs},
+		{name: "nested", in: "1,disk,0,3\n1,net,2,4\n", wantErr: ErrInvalidArgs},
+		{name: "unknown pid", in: "3,disk,0,1\n", wantErr: ErrInvalidArgs},
+		{name: "release before acquire", in: "1,disk,2,2\n", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadLocks(strings.NewReader(tt.in), processes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("loadLocks() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadLocks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
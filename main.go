@@ -1,57 +1,725 @@
 package main
 
 import (
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
-	"sort"
 	"os"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 )
 
-
 var ErrInvalidArgs error = errors.New("invalid arguments")
 
+// algorithms lists the schedulers in the order they are run and reported.
+var algorithms = []algorithm{
+	{name: "fcfs", title: "First-come, first-serve", schedule: fcfsSchedule},
+	{name: "sjf", title: "Shortest-job-first (SJF)", schedule: sjfSchedule, nonWorkConserving: true},
+	{name: "sjf-predicted", title: "SJF with predicted bursts (exponential averaging)", schedule: sjfPredictedSchedule},
+	{name: "sjf-priority", title: "SJF with Priority scheduling", schedule: sjfPrioritySchedule, preemptive: true, priorities: true},
+	{name: "rr", title: "Round-robin scheduling", schedule: rrSchedule, quantum: true, preemptive: true},
+	{name: "mlq", title: "Multilevel queue (foreground RR, background FCFS)", schedule: mlqSchedule, quantum: true, preemptive: true, priorities: true},
+	{name: "wrr", title: "Weighted round-robin", schedule: wrrSchedule, quantum: true, preemptive: true, weights: true},
+	{name: "drr", title: "Deficit round-robin", schedule: drrSchedule, quantum: true, weights: true},
+}
+
+// subcommands maps the first CLI argument to an alternative entry point; anything else is
+// treated as a scheduling file.
+var subcommands = map[string]func(args []string) error{
+	"serve":       runServe,
+	"generate":    runGenerate,
+	"convert":     runConvert,
+	"verify":      runVerify,
+	"check":       runCheck,
+	"experiment":  runExperiment,
+	"pipeline":    runPipeline,
+	"exec":        runExec,
+	"observe":     runObserve,
+	"anonymize":   runAnonymize,
+	"autotune":    runAutotune,
+	"env":         runEnv,
+	"replay":      runReplay,
+	"golden":      runGolden,
+	"conformance": runConformance,
+}
+
+// runAlgorithm schedules processes with a, writing its report to w, and fills in the metrics
+// that are derived from the gantt chart rather than tracked by each scheduler.
+func runAlgorithm(a algorithm, w io.Writer, processes []Process, opts Options) Result {
+	result := a.schedule(w, a.title, processes, opts)
+	result.Name = a.name
+	result.Ignored = ignoredFeatures(a, processes)
+	deriveMetrics(&result, processes)
+
+	return result
+}
+
+// runAlgorithmChecked is runAlgorithm for callers that can report an error, which it returns
+// when a's schedule is invalid.
+func runAlgorithmChecked(a algorithm, w io.Writer, processes []Process, opts Options) (Result, error) {
+	if a.scheduleChecked == nil {
+		return runAlgorithm(a, w, processes, opts), nil
+	}
+	result, err := a.scheduleChecked(w, a.title, processes, opts)
+	if err != nil {
+		return Result{}, err
+	}
+	result.Name = a.name
+	result.Ignored = ignoredFeatures(a, processes)
+	deriveMetrics(&result, processes)
+
+	return result, nil
+}
+
+// deriveMetrics fills in the metrics of result that come from its gantt chart.
+func deriveMetrics(result *Result, processes []Process) {
+	times := processTimesFromGantt(processes, result.Gantt)
+	shares := cpuShares(processes, times.turnaround)
+	if result.Unfinished > 0 {
+		finished := finishedProcesses(processes, result.Gantt)
+		times = processTimes{wait: onlyFinished(times.wait, finished), turnaround: onlyFinished(times.turnaround, finished), response: onlyFinished(times.response, finished)}
+		shares = onlyFinished(shares, finished)
+	}
+	result.AveResponse = mean(times.response)
+	result.ContextSwitches = contextSwitches(result.Gantt)
+	for i, reason := range sliceEndReasons(processes, result.Gantt) {
+		result.Gantt[i].Reason = reason
+	}
+	result.IdleTime, result.Utilization = cpuUsage(result.Gantt)
+	result.Fairness = jainIndex(shares)
+	result.WaitStats = describe(times.wait)
+	result.TurnaroundStats = describe(times.turnaround)
+	result.ResponseStats = describe(times.response)
+}
+
+// selectAlgorithms looks up algorithms by name, keeping the given order; no names selects all.
+func selectAlgorithms(names []string) ([]algorithm, error) {
+	if len(names) == 0 {
+		return algorithms, nil
+	}
+	selected := make([]algorithm, 0, len(names))
+	for _, name := range names {
+		a := findAlgorithm(strings.TrimSpace(name))
+		if a == nil {
+			return nil, fmt.Errorf("%w: unknown algorithm %q (see -list-algos)", ErrInvalidArgs, name)
+		}
+		selected = append(selected, *a)
+	}
+
+	return selected, nil
+}
+
+// findAlgorithm returns the algorithm registered under name, or nil.
+func findAlgorithm(name string) *algorithm {
+	for i := range algorithms {
+		if algorithms[i].name == name {
+			return &algorithms[i]
+		}
+	}
+
+	return nil
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	var (
+		ganttSVG = flag.String("gantt-svg", "", "write an SVG Gantt chart per algorithm into `dir`")
+		htmlDir  = flag.String("html", "", "write an HTML report per workload and algorithm into `dir`, with an index.html summarizing them in a sortable table")
+		icsPath  = flag.String("ics", "", "write every algorithm's time slices as calendar events to `file`.ics")
+		icsEpoch = flag.String("ics-epoch", "2000-01-01T00:00:00Z", "RFC 3339 `time` that tick 0 maps to in the calendar")
+		icsUnit  = flag.Duration("ics-unit", time.Minute, "calendar `duration` of a single tick")
+		trace    = flag.String("trace", "", "write every arrival, dispatch, preemption and completion as CSV to `file`, gzipped if it ends in .gz")
+		xlsxPath = flag.String("xlsx", "", "write an Excel workbook to `file`: a comparison sheet with charts, then a sheet per algorithm")
+		dbPath   = flag.String("db", "", "append the run's configuration, per-process metrics and averages to the SQLite database `file`, keyed by a new run ID")
+		record   = flag.String("record", "", "save the workload and every result to `file` for the replay subcommand to render later, gzipped if it ends in .gz")
+		chrome   = flag.String("chrome-trace", "", "write every algorithm's timeline as Chrome trace event JSON to `file`, for chrome://tracing or Perfetto, gzipped if it ends in .gz")
+		compress = flag.Bool("compress", false, "gzip the -trace file, adding .gz to its name")
+		plots    = flag.String("plots", "", "write PNG bar charts comparing the algorithms' metrics into `dir`")
+		plain    = flag.Bool("plain", false, "print labeled key: value lines instead of charts and tables")
+		tui      = flag.Bool("tui", false, "step through the schedules interactively instead of printing them")
+		replay   = flag.Duration("replay", 0, "play each schedule back in real time at `duration` per tick, printing its state every tick, instead of reporting it")
+		burn     = flag.Bool("replay-burn", false, "with -replay, run each process as a goroutine that busy-loops while it is scheduled and report the CPU it burned")
+		step     = flag.Bool("step", false, "walk through each schedule, printing the running and ready processes and advancing on every Enter, instead of reporting it")
+		stepBy   = flag.String("step-by", "tick", "what -step advances by: `tick`, or event to jump to the next arrival, dispatch, preemption or completion")
+		format   = flag.String("format", "text", "output `format`: text, json (which also reports errors as JSON on stderr), proto, a ResultSet of result.proto, or mermaid, a Markdown gantt block per algorithm")
+		idle     = flag.Bool("non-work-conserving", false, "let SJF idle for an imminent shorter job and report the effect on average wait")
+		window   = flag.Int64("lookahead", -1, "ticks of future arrivals non-work-conserving decisions may see (-1 unlimited)")
+		sweep    = flag.String("lookahead-sweep", "", "comma-separated lookahead `windows` to compare (implies -non-work-conserving)")
+		cohorts  = flag.String("cohorts", "", "comma-separated ascending arrival-time `boundaries` to report metrics per arrival cohort")
+		timeout  = flag.Duration("timeout", 0, "abort the simulation with partial results once it runs longer than `duration` (0 disables)")
+		memLimit = flag.Uint64("mem-limit", 0, "abort the simulation with partial results once its heap exceeds `MiB` (0 disables)")
+		delim    = flag.String("delimiter", ",", "field `separator` of the workload file, e.g. ; or tab")
+		groups   = flag.Bool("aggregate", false, "also report averages per group of bulk-arrival copies")
+		algo     = flag.String("algo", "", "comma-separated `names` of the algorithms to run, in order (default all)")
+		locks    = flag.String("locks", "", "also run preemptive priority over processes sharing resources, locked as pid,resource,acquire,release rows of `file`")
+		inherit  = flag.Bool("priority-inheritance", false, "with -locks, let a process holding a resource run at the priority of the highest-priority process it blocks")
+		chain    = flag.String("chain", "", "also run a policy composed of tie-breakers, e.g. `priority,then=sjf,then=fifo`")
+		list     = flag.Bool("list-algos", false, "list the available algorithms and exit; with -verbose, tabulate the features each supports")
+		plugins  = flag.String("plugin", "", "comma-separated Go plugin `files` to load schedulers from (see loadPlugin)")
+		config   = flag.String("config", "", "read flags and the workload file from a TOML `file` of flag = value lines")
+		quantum  = flag.Int64("quantum", defaultQuantum, "round-robin time slice in `ticks`")
+		rounding = flag.String("rr-rounding", "exact", "how a process finishing mid-quantum is `charged`: exact, or full to hold the CPU for the whole quantum")
+		rrTie    = flag.String("rr-tie", "arrival", "who queues first when a process arrives as another is preempted: `arrival` or preempted")
+		mlqFore  = flag.Int64("mlq-foreground", defaultForeground, "lowest `priority` (highest number) in the multilevel queue's foreground queue")
+		mlqInter = flag.String("mlq-policy", "fixed", "how the multilevel queue shares the CPU: `fixed` priority for the foreground, or sliced turns")
+		mlqTurns = flag.String("mlq-slices", "8,2", "foreground and background `turns` in ticks under -mlq-policy sliced")
+		alpha    = flag.Float64("sjf-alpha", defaultAlpha, "`weight` sjf-predicted gives the latest burst when predicting the next (0 to 1)")
+		guess    = flag.Int64("sjf-initial", defaultInitialGuess, "`ticks` sjf-predicted predicts for a job with no burst history")
+		preset   = flag.String("convention", "", "set tie-breaking and accounting flags to match a textbook's worked examples: silberschatz, stallings or tanenbaum (explicit flags win)")
+		qSweep   = flag.String("quantum-sweep", "", "comma-separated `quanta` to compare for each quantum-based algorithm, with a recommendation")
+		outDir   = flag.String("o", "", "write each algorithm's report to `dir`/<name>.txt instead of stdout")
+		toStdout = flag.Bool("stdout", false, "with -o, also print the reports on stdout as before")
+		verbose  = flag.Bool("verbose", false, "log every scheduling decision, and why it was made, to stderr")
+		quiet    = flag.Bool("quiet", false, "print only the comparison of averages, leaving out each algorithm's gantt chart and tables (-o still writes them)")
+		manifest = flag.Bool("manifest", false, "with -o, write a SHA-256 manifest of the report directory to SHA256SUMS")
+		signKey  = flag.String("sign-key", "", "with -o, also sign the manifest with HMAC-SHA256 using the key in `file` (implies -manifest)")
+		stable   = flag.Int("stability", 0, "rerun each algorithm on `n` perturbed copies of the workload and report how far its dispatch order moves")
+		jitter   = flag.Int64("stability-jitter", 1, "largest shift in `ticks` applied to each burst and arrival by -stability")
+		gScale   = flag.Float64("gantt-scale", ganttLayout.scale, "`characters` per tick in text gantt charts")
+		gWidth   = flag.Int("gantt-width", ganttLayout.width, "wrap text gantt charts at `columns`")
+		jobs     = flag.Int("jobs", runtime.GOMAXPROCS(0), "run up to `n` algorithms at once, reporting them in order all the same (1 runs them one after another)")
+		noProg   = flag.Bool("no-progress", false, "never draw a progress bar on stderr for long runs (bars are drawn only on a terminal)")
+		noColor  = flag.Bool("no-color", false, "never color gantt charts and schedule rows by PID (colors are used only on a terminal)")
+		console  = flag.String("console", "auto", "terminal `kind`: modern, legacy for the classic Windows console (plain ASCII, no colors or images), or auto to detect it")
+		crlf     = flag.Bool("crlf", false, "end the lines of -o reports and -trace CSV with \\r\\n for Windows tools")
+		strict   = flag.Bool("strict-features", false, "fail instead of warning when the workload uses a field, such as deadline or weight, that a selected algorithm ignores")
+		baseline = flag.String("baseline", "", "compare the results with those an earlier version saved to `file` with -format json (.json) or proto, optionally gzipped")
+		baseVer  = flag.String("baseline-version", "", "compare the results with those release `version` stored for this workload in -baseline-store")
+		baseDir  = flag.String("baseline-store", defaultBaselineStore, "`directory` or http(s) URL holding each release's baselines as <version>/<workload>.json")
+		saveBase = flag.String("save-baseline", "", "store the results in -baseline-store as release `version`'s baseline for this workload")
+		goal     = flag.String("objective", "", "rank the algorithms by a weighted sum of metrics, e.g. `0.5*avgWait + 0.3*p95Turnaround + 0.2*switches` (lower is better)")
+		qGoal    = flag.String("quantum-objective", "response", "what -quantum-sweep recommends for: a metric to minimize (wait, turnaround, response or switches) then optional constraints, e.g. `response,switches<20`")
+	)
+	flag.Parse()
+	started := time.Now()
+	fatal := func(err error) { exitWithError(os.Stderr, *format, err) }
+	var input string
+	if *config != "" {
+		var err error
+		if input, err = loadConfig(flag.CommandLine, *config); err != nil {
+			fatal(err)
+		}
+	}
+	if *preset != "" {
+		if err := applyConvention(flag.CommandLine, *preset); err != nil {
+			fatal(err)
+		}
+	}
+	if *format != "text" && *format != "json" && *format != "proto" && *format != "mermaid" {
+		fatal(fmt.Errorf("%w: unknown -format %q", ErrInvalidArgs, *format))
+	}
+	if *outDir != "" && *format != "text" {
+		fatal(fmt.Errorf("%w: -o writes text reports and cannot be combined with -format %s", ErrInvalidArgs, *format))
+	}
+	if *quiet && *toStdout {
+		fatal(fmt.Errorf("%w: -quiet leaves the reports off stdout, which -stdout asks for", ErrInvalidArgs))
+	}
+	var key []byte
+	if *signKey != "" {
+		var err error
+		if key, err = readSigningKey(*signKey); err != nil {
+			fatal(err)
+		}
+		*manifest = true
+	}
+	if *burn && *replay <= 0 {
+		fatal(fmt.Errorf("%w: -replay-burn needs -replay to set the tick length", ErrInvalidArgs))
+	}
+	if *stepBy != "tick" && *stepBy != "event" {
+		fatal(fmt.Errorf("%w: unknown -step-by %q, want tick or event", ErrInvalidArgs, *stepBy))
+	}
+	if *manifest && *outDir == "" {
+		fatal(fmt.Errorf("%w: -manifest and -sign-key need -o to name the report directory", ErrInvalidArgs))
+	}
+	if *plugins != "" {
+		if err := registerPlugins(*plugins); err != nil {
+			fatal(err)
+		}
+	}
+	if *list {
+		switch {
+		case *verbose && *plain:
+			outputPlainCapabilities(os.Stdout, algorithms)
+		case *verbose:
+			outputCapabilities(os.Stdout, algorithms)
+		default:
+			for _, a := range algorithms {
+				fmt.Printf("%-14s%s\n", a.name, a.title)
+			}
+		}
+		return
+	}
+	var names []string
+	if *algo != "" {
+		names = strings.Split(*algo, ",")
+	}
+	selected, err := selectAlgorithms(names)
+	if err != nil {
+		fatal(err)
+	}
+	if *chain != "" {
+		policy, err := parseChain(*chain)
+		if err != nil {
+			fatal(err)
+		}
+		selected = append(selected, policy.algorithm())
+	}
+
+	if *gScale <= 0 || *gWidth < 3 {
+		fatal(fmt.Errorf("%w: -gantt-scale must be positive and -gantt-width at least 3", ErrInvalidArgs))
+	}
+	legacy, err := parseConsole(*console)
+	if err != nil {
+		fatal(err)
+	}
+	legacyConsole, crlfFiles = legacy, *crlf
+	if progressEnabled(os.Stderr, *noProg) {
+		progressOut = os.Stderr
+	}
+	// colors would end up as escape codes in report files and machine-readable output
+	colorPIDs = *format == "text" && !*plain && *outDir == "" && !legacyConsole && colorEnabled(os.Stdout, *noColor)
+	ganttLayout = ganttStyle{scale: *gScale, width: *gWidth, color: colorPIDs}
+	if *stable < 0 || *jitter < 0 {
+		fatal(fmt.Errorf("%w: -stability and -stability-jitter must not be negative", ErrInvalidArgs))
+	}
+	if *quantum < 1 {
+		fatal(fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs))
+	}
+	if _, ok := sliceRoundings[*rounding]; !ok {
+		fatal(fmt.Errorf("%w: unknown -rr-rounding %q, want exact or full", ErrInvalidArgs, *rounding))
+	}
+	if *rrTie != "arrival" && *rrTie != "preempted" {
+		fatal(fmt.Errorf("%w: unknown -rr-tie %q, want arrival or preempted", ErrInvalidArgs, *rrTie))
+	}
+	if err := checkInterQueue(*mlqInter); err != nil {
+		fatal(err)
+	}
+	if err := checkPrediction(*alpha, *guess); err != nil {
+		fatal(err)
+	}
+	turns, err := parseInt64List(*mlqTurns)
+	if err != nil || len(turns) != 2 || turns[0] < 1 || turns[1] < 1 {
+		fatal(fmt.Errorf("%w: -mlq-slices %q must be two positive tick counts, e.g. 8,2", ErrInvalidArgs, *mlqTurns))
+	}
+	opts := Options{
+		NonWorkConserving: *idle,
+		Lookahead:         *window,
+		Quantum:           *quantum,
+		Rounding:          *rounding,
+		PreemptedFirst:    *rrTie == "preempted",
+		Foreground:        *mlqFore,
+		InterQueue:        *mlqInter,
+		ForegroundSlice:   turns[0],
+		BackgroundSlice:   turns[1],
+		Alpha:             *alpha,
+		InitialGuess:      *guess,
+	}
+	var windows []int64
+	if *sweep != "" {
+		if windows, err = parseInt64List(*sweep); err != nil {
+			fatal(fmt.Errorf("%w: parsing -lookahead-sweep", err))
+		}
+	}
+	var quanta []int64
+	var objective quantumObjective
+	if *qSweep != "" {
+		if quanta, err = parseInt64List(*qSweep); err != nil {
+			fatal(fmt.Errorf("%w: parsing -quantum-sweep", err))
+		}
+		for _, q := range quanta {
+			if q < 1 {
+				fatal(fmt.Errorf("%w: -quantum-sweep quanta must be at least 1", ErrInvalidArgs))
+			}
+		}
+		if objective, err = parseQuantumObjective(*qGoal); err != nil {
+			fatal(err)
+		}
+	}
+	var boundaries []int64
+	if *cohorts != "" {
+		if boundaries, err = parseInt64List(*cohorts); err != nil {
+			fatal(fmt.Errorf("%w: parsing -cohorts", err))
+		}
+		if !sort.SliceIsSorted(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] }) {
+			fatal(fmt.Errorf("%w: -cohorts boundaries must be ascending", ErrInvalidArgs))
+		}
+	}
+	var ranking weightedObjective
+	if *goal != "" {
+		if ranking, err = parseObjective(*goal); err != nil {
+			fatal(err)
+		}
+	}
+	var old []Result
+	baselineFrom := *baseline
+	if *baseline != "" {
+		if *baseVer != "" {
+			fatal(fmt.Errorf("%w: -baseline and -baseline-version are mutually exclusive", ErrInvalidArgs))
+		}
+		if old, err = loadResults(*baseline); err != nil {
+			fatal(err)
+		}
+	}
+
 	// CLI args
-	f, closeFile, err := openProcessingFile(os.Args...)
+	args := flag.Args()
+	if len(args) == 0 && input != "" {
+		args = []string{input}
+	}
+	if len(args) == 0 && isPiped(os.Stdin) {
+		args = []string{stdinName}
+	}
+	if args, err = expandWorkloadArgs(args); err != nil {
+		fatal(err)
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		fatal(err)
+	}
+	if len(args) > 1 {
+		// several workloads: one report each plus a summary, without the single-workload extras
+		var extras []string
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "tui", "gantt-svg", "ics", "trace", "chrome-trace", "plots", "lookahead-sweep", "quantum-sweep", "cohorts", "aggregate", "o", "manifest", "sign-key", "stability", "verbose", "locks", "priority-inheritance", "replay", "replay-burn", "step", "step-by", "baseline", "objective", "record", "db", "xlsx", "baseline-version", "save-baseline":
+				extras = append(extras, "-"+f.Name)
+			}
+		})
+		if *format == "proto" || *format == "mermaid" {
+			extras = append(extras, "-format "+*format)
+		}
+		if len(extras) > 0 {
+			fatal(fmt.Errorf("%w: %s only work with a single workload file", ErrInvalidArgs, strings.Join(extras, ", ")))
+		}
+		guard := newResourceGuard(*timeout, *memLimit<<20)
+		if err := runBatch(os.Stdout, args, delimiter, selected, opts, guard, *jobs, *format, *plain, *quiet, *strict, *htmlDir); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	if *baseVer != "" {
+		if old, baselineFrom, err = loadBaselineVersion(*baseDir, *baseVer, args[0]); err != nil {
+			fatal(err)
+		}
+		baselineFrom = fmt.Sprintf("%s (%s)", *baseVer, baselineFrom)
+	}
+	f, closeFile, err := openProcessingFile(append([]string{os.Args[0]}, args...)...)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	defer closeFile()
+	in, err := decompressInput(f, args[0])
+	if err != nil {
+		fatal(err)
+	}
 
 	// Load and parse processes
-	processes, err := loadProcesses(f)
+	processes, err := loadProcessesDelimited(in, delimiter)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
+	}
+	var spans []lockSpan
+	if *locks != "" {
+		if spans, err = loadLocksFile(*locks, processes); err != nil {
+			fatal(err)
+		}
+		selected = append(selected, lockPolicy{spans: spans, inherit: *inherit}.algorithm())
+	}
+	if *strict {
+		if err := checkFeatures(selected, processes); err != nil {
+			fatal(err)
+		}
 	}
 
-	// First-come, first-serve scheduling
-	FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
+	if *tui {
+		if err := runTUI(processes, selected, opts); err != nil {
+			fatal(err)
+		}
+		return
+	}
+	if *replay > 0 {
+		r := newReplayer(os.Stdout, *replay, *burn)
+		for _, s := range selected {
+			result, err := runAlgorithmChecked(s, io.Discard, processes, opts)
+			if err != nil {
+				fatal(err)
+			}
+			rows := r.replay(processes, result)
+			switch {
+			case rows == nil:
+			case *plain:
+				outputPlainBurn(os.Stdout, s.name, rows)
+			default:
+				outputBurn(os.Stdout, s.name, rows)
+			}
+		}
+		return
+	}
 
-	// Shortest Job First (SJF) scheduling
-	SJFSchedule(os.Stdout, "Shortest-job-first (SJF)", processes)
+	if *step {
+		if args[0] == stdinName {
+			fatal(fmt.Errorf("%w: -step reads keypresses from stdin, so the workload must come from a file", ErrInvalidArgs))
+		}
+		s := newStepper(os.Stdin, os.Stdout, *stepBy == "event")
+		for _, a := range selected {
+			result, err := runAlgorithmChecked(a, io.Discard, processes, opts)
+			if err != nil {
+				fatal(err)
+			}
+			if !s.step(processes, result) {
+				break
+			}
+		}
+		return
+	}
 
-	// SJF with Priority scheduling
-	SJFPrioritySchedule(os.Stdout, "SJF with Priority scheduling", processes)
+	guard := newResourceGuard(*timeout, *memLimit<<20)
+	var term *os.File
+	if *outDir == "" {
+		term = os.Stdout
+	}
+	runs := runConcurrently(selected, processes, opts, guard, *jobs, *format != "text" || *plain || (*quiet && *outDir == ""), term)
+	results := make([]Result, 0, len(selected))
+	var limitErr error
+	for i, s := range selected {
+		result := runs[i].result
+		if err := runs[i].err; err != nil {
+			if errors.Is(err, ErrInvalidSchedule) {
+				fatal(err)
+			}
+			// report what finished and the partial schedule of the algorithm cut short, then fail
+			limitErr = fmt.Errorf("%w: after %d of %d algorithms", err, len(results), len(selected))
+			if result.Name == "" {
+				break
+			}
+		}
+		report, closeReport, err := reportWriter(*outDir, s.name, *toStdout)
+		if err != nil {
+			fatal(err)
+		}
+		if runs[i].report != nil {
+			if _, err := runs[i].report.WriteTo(report); err != nil {
+				fatal(fmt.Errorf("%w: writing report", err))
+			}
+		}
+		if *plain && (!*quiet || *outDir != "") {
+			outputPlain(report, result)
+		}
+		if *verbose {
+			outputDecisions(os.Stderr, s.name, processes, result.Gantt)
+		}
+		if err := closeReport(); err != nil {
+			fatal(err)
+		}
+		if *ganttSVG != "" {
+			if err := writeGanttSVG(*ganttSVG, s.name, s.title, result.Gantt); err != nil {
+				fatal(err)
+			}
+		}
+		results = append(results, result)
+		if limitErr != nil {
+			break
+		}
+	}
+	if *format != "json" {
+		outputFeatureWarnings(os.Stderr, results)
+	}
+	if *manifest {
+		if err := writeManifest(*outDir, key); err != nil {
+			fatal(err)
+		}
+	}
+	if *htmlDir != "" {
+		if err := writeHTMLReports(*htmlDir, []htmlWorkload{{Name: args[0], Results: results}}); err != nil {
+			fatal(err)
+		}
+	}
+	switch {
+	case *format == "json":
+		if err := json.NewEncoder(os.Stdout).Encode(simulateResponse{Results: results}); err != nil {
+			fatal(fmt.Errorf("%w: writing JSON results", err))
+		}
+	case *format == "mermaid":
+		if err := writeMermaid(os.Stdout, results); err != nil {
+			fatal(err)
+		}
+	case *format == "proto":
+		if _, err := os.Stdout.Write(marshalResults(results)); err != nil {
+			fatal(fmt.Errorf("%w: writing protobuf results", err))
+		}
+	case *plain:
+		outputPlainComparison(os.Stdout, results)
+		if len(ranking) > 0 {
+			outputPlainObjectiveRanking(os.Stdout, ranking, results)
+		}
+		if !*quiet {
+			outputPlainDistributions(os.Stdout, results)
+		}
+		if len(boundaries) > 0 {
+			outputPlainCohorts(os.Stdout, processes, results, boundaries)
+		}
+		if *groups {
+			outputPlainGroups(os.Stdout, processes, results)
+		}
+		if hasDeadlines(processes) {
+			outputPlainTardiness(os.Stdout, processes, results)
+		}
+		if hasDependencies(processes) {
+			outputPlainCriticalPath(os.Stdout, processes, results)
+		}
+		if len(spans) > 0 {
+			outputPlainInversion(os.Stdout, processes, spans)
+		}
+		if *stable > 0 {
+			if err := outputPlainStability(os.Stdout, processes, selected[:len(results)], opts, *stable, *jitter); err != nil {
+				fatal(err)
+			}
+		}
+		if len(old) > 0 {
+			outputPlainBaselineDiff(os.Stdout, diffBaseline(old, results))
+		}
+	default:
+		outputComparison(os.Stdout, results)
+		if len(ranking) > 0 {
+			outputObjectiveRanking(os.Stdout, ranking, results)
+		}
+		if !*quiet {
+			outputDistributions(os.Stdout, results)
+		}
+		if len(boundaries) > 0 {
+			outputCohorts(os.Stdout, processes, results, boundaries)
+		}
+		if *groups {
+			outputGroups(os.Stdout, processes, results)
+		}
+		if hasDeadlines(processes) {
+			outputTardiness(os.Stdout, processes, results)
+		}
+		if hasDependencies(processes) {
+			outputCriticalPath(os.Stdout, processes, results)
+		}
+		if len(spans) > 0 {
+			outputInversion(os.Stdout, processes, spans)
+		}
+		if *stable > 0 {
+			if err := outputStability(os.Stdout, processes, selected[:len(results)], opts, *stable, *jitter); err != nil {
+				fatal(err)
+			}
+		}
+		if len(old) > 0 {
+			outputBaselineDiff(os.Stdout, baselineFrom, diffBaseline(old, results))
+		}
+	}
+	if limitErr != nil {
+		fatal(limitErr)
+	}
+	if len(windows) > 0 && *format == "text" {
+		if err := outputLookaheadSweep(os.Stdout, processes, selected, windows); err != nil {
+			fatal(err)
+		}
+	}
+	if len(quanta) > 0 && *format == "text" {
+		if err := outputQuantumSweep(os.Stdout, processes, selected, opts, quanta, objective); err != nil {
+			fatal(err)
+		}
+	}
+	if opts.NonWorkConserving && *format == "text" {
+		for i, s := range selected {
+			if s.nonWorkConserving {
+				conserving, err := runAlgorithmChecked(s, io.Discard, processes, Options{})
+				if err != nil {
+					fatal(err)
+				}
+				outputIdlingEffect(os.Stdout, conserving, results[i])
+			}
+		}
+	}
 
-	// Round-robin scheduling
-	RRSchedule(os.Stdout, "Round-robin scheduling", processes)
+	if *icsPath != "" {
+		epoch, err := time.Parse(time.RFC3339, *icsEpoch)
+		if err != nil {
+			fatal(fmt.Errorf("%w: parsing -ics-epoch", err))
+		}
+		if err := writeICS(*icsPath, epoch, *icsUnit, results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *trace != "" {
+		if err := writeTrace(*trace, *compress, processes, results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *chrome != "" {
+		if err := writeChromeTrace(*chrome, processes, results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *record != "" {
+		if err := writeRecording(*record, processes, results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *saveBase != "" {
+		if _, err := saveBaseline(*baseDir, *saveBase, args[0], results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *xlsxPath != "" {
+		if err := writeXLSX(*xlsxPath, results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *dbPath != "" {
+		if _, err := appendRunDB(*dbPath, dbRun{started: started, command: os.Args, workload: args[0]}, processes, opts, results); err != nil {
+			fatal(err)
+		}
+	}
+
+	if *plots != "" {
+		if err := writeMetricPlots(*plots, results); err != nil {
+			fatal(err)
+		}
+	}
 }
 
+// stdinName is the scheduling file name that reads the workload from standard input.
+const stdinName = "-"
+
 func openProcessingFile(args ...string) (*os.File, func(), error) {
 	if len(args) != 2 {
 		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
 	}
+	if args[1] == stdinName {
+		return os.Stdin, func() {}, nil
+	}
 	// Read in CSV process CSV file
 	f, err := os.Open(args[1])
 	if err != nil {
-		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+		return nil, nil, fmt.Errorf("%w: error opening scheduling file", err)
 	}
 	closeFn := func() {
 		if err := f.Close(); err != nil {
@@ -62,17 +730,113 @@ func openProcessingFile(args ...string) (*os.File, func(), error) {
 	return f, closeFn, nil
 }
 
+// isPiped reports whether f is a pipe or file rather than a terminal, so it can be read without
+// waiting on someone to type.
+func isPiped(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice == 0
+}
+
 type (
 	Process struct {
-		ProcessID     int64
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
+		ProcessID     int64 `json:"pid"`
+		ArrivalTime   int64 `json:"arrival"`
+		BurstDuration int64 `json:"burst"`
+		Priority      int64 `json:"priority"`
+		// Group is the source row's PID for processes expanded from a bulk (count) row, else 0.
+		Group int64 `json:"group,omitempty"`
+		// Deadline is the time the process should complete by, or 0 when it has none.
+		Deadline int64 `json:"deadline,omitempty"`
+		// Memory is the space the process needs resident to run, or 0 when it is not modeled.
+		Memory int64 `json:"memory,omitempty"`
+		// Weight is the process's share of the CPU under wrr and drr, or 0 for the default share of 1.
+		Weight int64 `json:"weight,omitempty"`
+		// Depends lists the PIDs that must complete before the process becomes ready.
+		Depends []int64 `json:"depends,omitempty"`
 	}
 	TimeSlice struct {
-		PID   int64
-		Start int64
-		Stop  int64
+		PID   int64 `json:"pid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+		// Reason says why the slice ended; runAlgorithm fills it in with one of the endReason values.
+		Reason string `json:"reason,omitempty"`
+	}
+	// Options tune how the schedulers that support them behave.
+	Options struct {
+		// NonWorkConserving lets a scheduler idle while processes are ready when waiting for an
+		// imminent shorter job lowers total waiting time.
+		NonWorkConserving bool `json:"nonWorkConserving"`
+		// Lookahead is how many ticks ahead arrivals are visible to such decisions; negative
+		// means the whole workload is known in advance.
+		Lookahead int64 `json:"lookahead"`
+		// Quantum is the time slice of quantum-based schedulers; zero means defaultQuantum.
+		Quantum int64 `json:"quantum"`
+		// Rounding names the sliceRoundings entry that charges a slice ending before its quantum;
+		// empty means exact.
+		Rounding string `json:"rounding,omitempty"`
+		// PreemptedFirst queues a preempted process ahead of one arriving at the same instant,
+		// rather than behind it.
+		PreemptedFirst bool `json:"preemptedFirst,omitempty"`
+		// Foreground is the lowest priority (highest number) the multilevel queue treats as
+		// foreground; zero means defaultForeground.
+		Foreground int64 `json:"foreground,omitempty"`
+		// InterQueue is how the multilevel queue shares the CPU between its queues: fixed, the
+		// default, or sliced.
+		InterQueue string `json:"interQueue,omitempty"`
+		// ForegroundSlice and BackgroundSlice are the turns of the sliced inter-queue policy;
+		// zero means the 80/20 defaults.
+		ForegroundSlice int64 `json:"foregroundSlice,omitempty"`
+		BackgroundSlice int64 `json:"backgroundSlice,omitempty"`
+		// Alpha is the weight sjf-predicted gives the latest burst in its exponential average, and
+		// InitialGuess its prediction for a job with no history; zero means defaultAlpha and
+		// defaultInitialGuess.
+		Alpha        float64 `json:"alpha,omitempty"`
+		InitialGuess int64   `json:"initialGuess,omitempty"`
+		// ctx cuts the run short when it is done; see Context and WithContext.
+		ctx context.Context
+	}
+	algorithm struct {
+		name     string
+		title    string
+		schedule func(io.Writer, string, []Process, Options) Result
+		// scheduleChecked, if set, is schedule returning an error for an invalid schedule.
+		scheduleChecked func(io.Writer, string, []Process, Options) (Result, error)
+		// nonWorkConserving reports whether schedule honors Options.NonWorkConserving and
+		// Options.Lookahead.
+		nonWorkConserving bool
+		// quantum reports whether schedule honors Options.Quantum.
+		quantum bool
+		// preemptive reports whether schedule can take the CPU from a process before it completes.
+		preemptive bool
+		// priorities and weights report whether schedule uses Process.Priority and Process.Weight.
+		priorities, weights bool
+	}
+	// Result is the outcome of running one scheduling algorithm over a workload.
+	Result struct {
+		Name          string      `json:"name"`
+		Title         string      `json:"title"`
+		Gantt         []TimeSlice `json:"gantt"`
+		Schedule      [][]string  `json:"schedule"`
+		AveWait       float64     `json:"aveWait"`
+		AveTurnaround float64     `json:"aveTurnaround"`
+		AveThroughput float64     `json:"aveThroughput"`
+		// AveResponse, ContextSwitches, the CPU accounting and the distributions are derived from
+		// Gantt by runAlgorithm.
+		AveResponse     float64      `json:"aveResponse"`
+		ContextSwitches int          `json:"contextSwitches"`
+		IdleTime        int64        `json:"idleTime"`
+		Utilization     float64      `json:"utilization"`
+		Fairness        float64      `json:"fairness"`
+		WaitStats       Distribution `json:"waitStats"`
+		TurnaroundStats Distribution `json:"turnaroundStats"`
+		ResponseStats   Distribution `json:"responseStats"`
+		// Predictions are the bursts an algorithm that does not know them predicted, if any.
+		Predictions []Prediction `json:"predictions,omitempty"`
+		// Ignored names the workload fields the algorithm does not schedule by (see ignoredFeatures).
+		Ignored []string `json:"ignored,omitempty"`
+		// Unfinished counts the processes a run cut short by cancellation did not complete. Their
+		// schedule rows show - and the averages leave them out.
+		Unfinished int `json:"unfinished,omitempty"`
 	}
 )
 
@@ -82,392 +846,381 @@ type (
 // • an output writer
 // • a title for the chart
 // • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
+func FCFSSchedule(w io.Writer, title string, processes []Process) Result {
+	return fcfsSchedule(w, title, processes, Options{})
+}
 
-		start := waitingTime + processes[i].ArrivalTime
+func fcfsSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		// processes run to completion in workload order
+		ready:         func([]int64) readySet { return &ringQueue{} },
+		workloadOrder: true,
+	}))
+}
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+// SJFPrioritySchedule outputs a preemptive priority schedule of processes, breaking ties on the
+// shortest remaining burst, in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process) Result {
+	return sjfPrioritySchedule(w, title, processes, Options{})
+}
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
+func sjfPrioritySchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		// highest priority first, then shortest remaining burst, then workload order
+		ready: func(remaining []int64) readySet {
+			return newPriorityQueue(func(a, b int) bool {
+				switch {
+				case processes[a].Priority != processes[b].Priority:
+					return processes[a].Priority < processes[b].Priority
+				case remaining[a] != remaining[b]:
+					return remaining[a] < remaining[b]
+				}
+				return a < b
+			})
+		},
+		preemptive: true,
+	}))
+}
 
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
+// SJFSchedule outputs a shortest-job-first schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func SJFSchedule(w io.Writer, title string, processes []Process) Result {
+	return sjfSchedule(w, title, processes, Options{})
+}
 
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+func sjfSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	policy := simPolicy{
+		// shortest burst first and equal bursts in workload order
+		ready: func([]int64) readySet {
+			return newPriorityQueue(func(a, b int) bool {
+				if processes[a].BurstDuration != processes[b].BurstDuration {
+					return processes[a].BurstDuration < processes[b].BurstDuration
+				}
+				return a < b
+			})
+		},
+	}
+	if opts.NonWorkConserving {
+		policy.idle = func(ready int, future []Process, next Process, now int64) (int64, bool) {
+			return idleForShorterJob(int64(ready), future, next, now, opts.Lookahead)
+		}
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, policy))
 }
 
-//func SJFPrioritySchedule(w io.Writer, title string, processes []Process) { }
-//
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		remaining       = make([]Process, len(processes))
-	)
-
-	copy(remaining, processes)
-	sort.Slice(remaining, func(i, j int) bool {
-		if remaining[i].BurstDuration == remaining[j].BurstDuration {
-			return remaining[i].Priority < remaining[j].Priority
-		}
-		return remaining[i].BurstDuration < remaining[j].BurstDuration
-	})
+// defaultQuantum is the round-robin time slice when Options.Quantum is not set.
+const defaultQuantum = 2
 
-	var running Process
-	for len(remaining) > 0 || running.ProcessID != 0 {
-		// add any arriving processes to the queue
-		for len(remaining) > 0 && remaining[0].ArrivalTime <= serviceTime {
-			if remaining[0].Priority < running.Priority {
-				// preempt the running process if a higher-priority process arrives
-				if running.ProcessID != 0 {
-					queue := append([]Process{running}, remaining[0])
-					remaining = append(remaining[1:], queue...)
-				} else {
-					running = remaining[0]
-				}
-			} else {
-				remaining = append(remaining[1:], remaining[0])
-			}
-		}
-
-		if running.ProcessID == 0 {
-			// wait for the next process to arrive
-			running = remaining[0]
-			remaining = remaining[1:]
-			waitingTime = serviceTime - running.ArrivalTime
-			totalWait += float64(waitingTime)
-		}
-
-		start := serviceTime
-
-		// execute the process for a fixed time slice
-		if running.BurstDuration > 0 {
-			running.BurstDuration--
-			serviceTime++
-			if running.BurstDuration == 0 {
-				turnaround := waitingTime + running.BurstDuration + 1
-				totalTurnaround += float64(turnaround)
-				completion := serviceTime
-				lastCompletion = float64(completion)
-				schedule[running.ProcessID-1] = []string{
-					fmt.Sprint(running.ProcessID),
-					fmt.Sprint(running.Priority),
-					fmt.Sprint(running.BurstDuration),
-					fmt.Sprint(running.ArrivalTime),
-					fmt.Sprint(waitingTime),
-					fmt.Sprint(turnaround),
-					fmt.Sprint(completion),
-				}
-				running = Process{}
-			}
-		} else {
-			// process has finished executing
-			turnaround := waitingTime + running.BurstDuration + 1
-			totalTurnaround += float64(turnaround)
-			completion := serviceTime
-			lastCompletion = float64(completion)
-			schedule[running.ProcessID-1] = []string{
-				fmt.Sprint(running.ProcessID),
-				fmt.Sprint(running.Priority),
-				fmt.Sprint(running.BurstDuration),
-				fmt.Sprint(running.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-			running = Process{}
-		}
+// sliceRoundings are the conventions for how long the CPU is charged for a slice that used run
+// ticks of its quantum, which only differ for a process finishing before its quantum is up.
+var sliceRoundings = map[string]func(run, quantum int64) int64{
+	// exact hands the CPU on as soon as the process finishes
+	"exact": func(run, _ int64) int64 { return run },
+	// full charges the whole quantum, leaving the CPU idle for the rest of it
+	"full": func(_, quantum int64) int64 { return quantum },
+}
 
-		gantt = append(gantt, TimeSlice{
-			PID:   running.ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+// sliceRounding returns the rounding named by opts.Rounding, exact when it is empty or unknown.
+func sliceRounding(opts Options) func(run, quantum int64) int64 {
+	if round, ok := sliceRoundings[opts.Rounding]; ok {
+		return round
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return sliceRoundings["exact"]
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// RRSchedule outputs a round-robin schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func RRSchedule(w io.Writer, title string, processes []Process) Result {
+	return rrSchedule(w, title, processes, Options{})
 }
 
-//func SJFSchedule(w io.Writer, title string, processes []Process) { }
-//
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		remaining       = make([]Process, len(processes))
-	)
+// rrSchedule is RRSchedule with a quantum of opts.Quantum ticks, charging slices as opts.Rounding
+// says. A process arriving during a slice queues ahead of the process that slice preempts, as does
+// one arriving as the slice ends unless opts.PreemptedFirst is set.
+func rrSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	quantum := opts.Quantum
+	if quantum <= 0 {
+		quantum = defaultQuantum
+	}
 
-	copy(remaining, processes)
-	sort.Slice(remaining, func(i, j int) bool {
-		return remaining[i].BurstDuration < remaining[j].BurstDuration
-	})
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		ready:          func([]int64) readySet { return &ringQueue{} },
+		quantum:        quantum,
+		charge:         sliceRounding(opts),
+		preemptedFirst: opts.PreemptedFirst,
+	}))
+}
 
-	for len(remaining) > 0 {
-		var next Process
-		if len(remaining) == 1 || remaining[0].ArrivalTime <= serviceTime {
-			next = remaining[0]
-			remaining = remaining[1:]
-		} else {
-			// find the process with the shortest burst time that has arrived
-			for i, p := range remaining {
-				if p.ArrivalTime > serviceTime {
-					break
-				}
-				if p.BurstDuration < next.BurstDuration || next.ProcessID == 0 {
-					next = remaining[i]
-				}
-			}
-			remaining = removeProcess(remaining, next)
-		}
+//endregion
 
-		if next.ProcessID != 0 {
-			if next.ArrivalTime > 0 {
-				waitingTime = serviceTime - next.ArrivalTime
-			}
-			totalWait += float64(waitingTime)
+//region Output helpers
 
-			start := waitingTime + next.ArrivalTime
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
 
-			turnaround := next.BurstDuration + waitingTime
-			totalTurnaround += float64(turnaround)
+func outputGantt(w io.Writer, gantt []TimeSlice) {
+	if w == io.Discard {
+		// laying out a million slices only to drop them costs more than scheduling them
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	if f := terminalOf(w); f != nil && len(gantt) > 0 {
+		if protocol := detectGraphicsProtocol(f); protocol != graphicsNone {
+			outputGanttImage(w, protocol, gantt)
+			return
+		}
+	}
+	ganttRenderer.RenderGantt(w, NewGanttModel(gantt))
+}
 
-			completion := next.BurstDuration + next.ArrivalTime + waitingTime
-			lastCompletion = float64(completion)
+// scheduleHeader names the columns of each schedule row.
+var scheduleHeader = []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Response", "Turnaround", "Exit"}
 
-			schedule[next.ProcessID-1] = []string{
-				fmt.Sprint(next.ProcessID),
-				fmt.Sprint(next.Priority),
-				fmt.Sprint(next.BurstDuration),
-				fmt.Sprint(next.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
-			}
-			serviceTime += next.BurstDuration
+func outputSchedule(w io.Writer, rows [][]string, wait, response, turnaround, throughput float64) {
+	if w == io.Discard {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(scheduleHeader)
+	for _, row := range rows {
+		pid, err := strconv.ParseInt(row[0], 10, 64)
+		if colorPIDs && err == nil && pid > 0 {
+			table.Rich(row, rowColors(pid, len(row)))
+			continue
+		}
+		table.Append(row)
+	}
+	table.SetFooter([]string{"", "", "", "",
+		fmt.Sprintf("Average\n%.2f", wait),
+		fmt.Sprintf("Average\n%.2f", response),
+		fmt.Sprintf("Average\n%.2f", turnaround),
+		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
+	table.Render()
+}
 
-			gantt = append(gantt, TimeSlice{
-				PID:   next.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
-			})
+// parseInt64List parses a comma-separated list of integers.
+func parseInt64List(s string) ([]int64, error) {
+	fields := strings.Split(s, ",")
+	values := make([]int64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseInt(strings.TrimSpace(f), 10, 64)
+		if err != nil {
+			return nil, err
 		}
+		values[i] = v
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return values, nil
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// loadProcesses reads a comma-separated workload; see loadProcessesDelimited.
+func loadProcesses(r io.Reader) ([]Process, error) {
+	return loadProcessesDelimited(r, ',')
 }
 
-//func RRSchedule(w io.Writer, title string, processes []Process) { }
+// loadProcessesDelimited reads a workload whose fields are separated by delimiter; see
+// scanProcesses for the format.
+func loadProcessesDelimited(r io.Reader, delimiter rune) ([]Process, error) {
+	var processes []Process
+	err := scanProcesses(r, delimiter, func(p Process) error {
+		processes = append(processes, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-func RRSchedule(w io.Writer, title string, processes []Process) {
-	const quantum = 2 // fixed time slice
+	return processes, nil
+}
+
+// scanProcesses parses and validates a workload one row at a time, handing each process to yield
+// as soon as it is read, so no more than the current row is held. Columns are pid, burst, arrival
+// and optional priority and count, in that order, unless the first row is a header naming them; a
+// header may order the columns freely and carry extra ones, which are ignored. Blank lines and
+// lines starting with # are skipped, and errors name the line in the file. A row with a count
+// stands for that many copies, and bulk workloads are numbered 1..n in file order.
+func scanProcesses(r io.Reader, delimiter rune, yield func(Process) error) error {
+	cr := csv.NewReader(r)
+	cr.Comma = delimiter
+	cr.Comment = '#'
+	cr.ReuseRecord = true
 
 	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-		remaining       = make([]Process, len(processes))
-		queue           = make([]Process, 0)
+		v         workloadValidator
+		positions = []int{0, 1, 2, 3, 4, -1, -1, -1, -1}
+		bulk      bool
+		rows      int64
+		n         int64
 	)
-
-	copy(remaining, processes)
-
-	for len(remaining) > 0 || len(queue) > 0 {
-		// add any arriving processes to the queue
-		for len(remaining) > 0 && remaining[0].ArrivalTime <= serviceTime {
-			queue = append(queue, remaining[0])
-			remaining = remaining[1:]
+	for first := true; ; first = false {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: reading CSV", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if first && isHeader(row) {
+			if positions, err = headerPositions(row, line); err != nil {
+				return err
+			}
+			continue
+		}
+		if rows++; rows == 1 {
+			// the CSV reader keeps the field count fixed, so the first row settles it
+			bulk = positions[4] >= 0 && positions[4] < len(row)
 		}
 
-		if len(queue) == 0 {
-			// wait for the next process to arrive
-			serviceTime = remaining[0].ArrivalTime
-		} else {
-			p := queue[0]
-			queue = queue[1:]
-
-			if p.ArrivalTime > 0 {
-				waitingTime = serviceTime - p.ArrivalTime
+		values := [8]int64{4: 1} // indexed like csvColumns; count defaults to one copy
+		for c, pos := range positions {
+			if c == dependsColumn {
+				continue // a list, parsed below
 			}
-			totalWait += float64(waitingTime)
-
-			start := waitingTime + p.ArrivalTime
-
-			var completion int64
-			if p.BurstDuration > quantum {
-				p.BurstDuration -= quantum
-				completion = serviceTime + quantum
-				queue = append(queue, p)
-			} else {
-				completion = serviceTime + p.BurstDuration
-				p.BurstDuration = 0
-				lastCompletion = float64(completion)
+			if pos < 0 || (pos >= len(row) && c >= 3) {
+				continue // priority, count, deadline, memory and weight are optional
 			}
-
-			turnaround := p.BurstDuration + waitingTime
-			totalTurnaround += float64(turnaround)
-
-			schedule[p.ProcessID-1] = []string{
-				fmt.Sprint(p.ProcessID),
-				fmt.Sprint(p.Priority),
-				fmt.Sprint(p.BurstDuration),
-				fmt.Sprint(p.ArrivalTime),
-				fmt.Sprint(waitingTime),
-				fmt.Sprint(turnaround),
-				fmt.Sprint(completion),
+			if c >= 5 && strings.TrimSpace(row[pos]) == "" {
+				continue // a blank deadline, memory or weight means the process has none
+			}
+			if pos >= len(row) {
+				return &fieldError{Line: line, Column: pos + 1, Name: csvColumns[c], Err: errMissingField}
+			}
+			v, err := strconv.ParseInt(strings.TrimSpace(row[pos]), 10, 64)
+			if err != nil {
+				return &fieldError{Line: line, Column: pos + 1, Name: csvColumns[c], Value: row[pos], Err: err}
+			}
+			values[c] = v
+		}
+		p := Process{ProcessID: values[0], BurstDuration: values[1], ArrivalTime: values[2], Priority: values[3], Deadline: values[5], Memory: values[6], Weight: values[7]}
+		if pos := positions[dependsColumn]; pos >= 0 && pos < len(row) {
+			if p.Depends, err = parseDepends(row[pos]); err != nil {
+				return &fieldError{Line: line, Column: pos + 1, Name: "depends", Value: row[pos], Err: err}
+			}
+		}
+		count := values[4]
+		if bulk {
+			if count < 1 {
+				return &fieldError{Line: line, Column: positions[4] + 1, Name: "count", Value: row[positions[4]], Err: errBadCount}
+			}
+			// copies of a bulk row share its PID (or row number without a pid column) as their group
+			p.Group = p.ProcessID
+			if positions[0] < 0 {
+				p.Group = rows
+			}
+		}
+		for k := int64(0); k < count; k++ {
+			if n++; bulk {
+				p.ProcessID = n
+			}
+			if err := v.add(p, line); err != nil {
+				return err
+			}
+			if err := yield(p); err != nil {
+				return err
 			}
-			serviceTime += quantum
-
-			gantt = append(gantt, TimeSlice{
-				PID:   p.ProcessID,
-				Start: start,
-				Stop:  serviceTime,
-			})
 		}
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return v.finish()
+}
+
+// parseDelimiter turns a -delimiter value into the single field separator it names; "tab" and
+// "\t" both mean a tab.
+func parseDelimiter(s string) (rune, error) {
+	if s == "tab" || s == `\t` {
+		return '\t', nil
+	}
+	if r := []rune(s); len(r) == 1 && r[0] != '#' && r[0] != '"' && r[0] != '\n' && r[0] != '\r' {
+		return r[0], nil
+	}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return 0, fmt.Errorf("%w: -delimiter %q must be a single character other than #, \" or a newline", ErrInvalidArgs, s)
 }
 
-//endregion
+// csvColumns names the workload columns in file order. Priority and count are optional, and pid
+// may be left out of a header when count is present since bulk workloads are renumbered. The
+// optional deadline, memory, weight and depends are only read from named header columns.
+var csvColumns = []string{"pid", "burst", "arrival", "priority", "count", "deadline", "memory", "weight", "depends"}
 
-//region Output helpers
+// dependsColumn is the index of depends in csvColumns, the one column that holds a list of PIDs
+// rather than a number.
+const dependsColumn = 8
 
-func outputTitle(w io.Writer, title string) {
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
-	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
-	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+// isHeader reports whether row names columns rather than holding a process: its first field is
+// not a number and at least one field is a known column name.
+func isHeader(row []string) bool {
+	if _, err := strconv.ParseInt(strings.TrimSpace(row[0]), 10, 64); err == nil {
+		return false
+	}
+	for _, name := range row {
+		for _, c := range csvColumns {
+			if strings.EqualFold(strings.TrimSpace(name), c) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
-func outputGantt(w io.Writer, gantt []TimeSlice) {
-	_, _ = fmt.Fprintln(w, "Gantt schedule")
-	_, _ = fmt.Fprint(w, "|")
-	for i := range gantt {
-		pid := fmt.Sprint(gantt[i].PID)
-		padding := strings.Repeat(" ", (8-len(pid))/2)
-		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+// headerPositions maps each of csvColumns to its index in header, or -1 for an absent priority.
+func headerPositions(header []string, line int) ([]int, error) {
+	positions := []int{-1, -1, -1, -1, -1, -1, -1, -1, -1}
+	for i, name := range header {
+		for c, column := range csvColumns {
+			if strings.EqualFold(strings.TrimSpace(name), column) {
+				positions[c] = i
+			}
+		}
 	}
-	_, _ = fmt.Fprintln(w)
-	for i := range gantt {
-		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
-		if len(gantt)-1 == i {
-			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+	for c, pos := range positions[:3] {
+		if pos < 0 && (c != 0 || positions[4] < 0) {
+			return nil, &fieldError{Line: line, Column: len(header) + 1, Name: csvColumns[c], Err: errMissingColumn}
 		}
 	}
-	_, _ = fmt.Fprintf(w, "\n\n")
-}
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
-	_, _ = fmt.Fprintln(w, "Schedule table")
-	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
-	table.AppendBulk(rows)
-	table.SetFooter([]string{"", "", "", "",
-		fmt.Sprintf("Average\n%.2f", wait),
-		fmt.Sprintf("Average\n%.2f", turnaround),
-		fmt.Sprintf("Throughput\n%.2f/t", throughput)})
-	table.Render()
+	return positions, nil
 }
 
-var ErrInvalidArgs = errors.New("invalid args")
+// errMissingColumn is wrapped by a fieldError for a header that lacks a required column.
+var errMissingColumn = errors.New("missing column")
 
-func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
-	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
-	}
+// errBadCount is wrapped by a fieldError for a bulk row with fewer than one copy.
+var errBadCount = errors.New("count must be at least 1")
 
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = mustStrToInt(rows[i][0])
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
-		}
-	}
+// errMissingField is wrapped by a fieldError for a row that stops before a required column.
+var errMissingField = errors.New("missing value")
 
-	return processes, nil
+// fieldError reports a workload value that could not be parsed, pinned to its 1-based line and
+// column.
+type fieldError struct {
+	Line   int
+	Column int
+	Name   string
+	Value  string
+	Err    error
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		_, _ = fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+func (e *fieldError) Error() string {
+	if errors.Is(e.Err, errMissingField) || errors.Is(e.Err, errMissingColumn) {
+		return fmt.Sprintf("line %d, column %d (%s): %v", e.Line, e.Column, e.Name, e.Err)
 	}
 
-	return i
+	return fmt.Sprintf("line %d, column %d (%s): invalid value %q: %v", e.Line, e.Column, e.Name, e.Value, e.Err)
 }
+
+func (e *fieldError) Unwrap() error { return e.Err }
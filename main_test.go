@@ -3,10 +3,13 @@ package main
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"math/rand"
 	"os"
 	"path"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -64,6 +67,44 @@ func TestFCFSSchedule(t *testing.T) {
 	}
 }
 
+func TestFCFSSchedule_gantt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+		wantWait  float64
+	}{
+		{
+			name: "simultaneous arrivals",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3},
+				{ProcessID: 2, BurstDuration: 3},
+			},
+			want:     []TimeSlice{{PID: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 6}},
+			wantWait: 1.5,
+		},
+		{
+			name: "idle gap",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+			},
+			want: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 5, Stop: 6}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := FCFSSchedule(io.Discard, "", tt.processes)
+			if !reflect.DeepEqual(got.Gantt, tt.want) || got.AveWait != tt.wantWait {
+				t.Errorf("FCFSSchedule() = %v (wait %v), want %v (wait %v)", got.Gantt, got.AveWait, tt.want, tt.wantWait)
+			}
+		})
+	}
+}
+
 func Test_loadProcesses(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -82,6 +123,88 @@ func Test_loadProcesses(t *testing.T) {
 			},
 			wantErr: io.ErrUnexpectedEOF,
 		},
+		{
+			name: "header with reordered and extra columns",
+			args: args{
+				r: strings.NewReader("arrival,owner,pid,burst\n0,10,1,5\n3,20,2,9\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "header with deadlines",
+			args: args{
+				r: strings.NewReader("pid,burst,arrival,deadline\n1,5,0,10\n2,9,3,\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Deadline: 10},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "header with memory",
+			args: args{
+				r: strings.NewReader("pid,memory,burst,arrival\n1,40,5,0\n2,,9,3\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Memory: 40},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "header with weight",
+			args: args{
+				r: strings.NewReader("pid,burst,arrival,weight\n1,5,0,3\n2,9,3,\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Weight: 3},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "header with depends",
+			args: args{
+				r: strings.NewReader("pid,burst,arrival,depends\n1,5,0,\n2,9,3,1\n3,1,0,1;2\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Depends: []int64{1}},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1, Depends: []int64{1, 2}},
+			},
+		},
+		{
+			name: "header missing a column",
+			args: args{
+				r: strings.NewReader("pid,burst\n1,5\n"),
+			},
+			wantErr: errMissingColumn,
+		},
+		{
+			name: "comments and blank lines",
+			args: args{
+				r: strings.NewReader("# demo workload\n\n1,5,0\n# second\n2,9,3\n\n"),
+			},
+			want: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "bad number",
+			args: args{
+				r: strings.NewReader("1,5,0\n2,x,1\n"),
+			},
+			wantErr: strconv.ErrSyntax,
+		},
+		{
+			name: "missing column",
+			args: args{
+				r: strings.NewReader("1,5\n2,9\n"),
+			},
+			wantErr: errMissingField,
+		},
 		{
 			name: "success",
 			args: args{
@@ -171,6 +294,13 @@ func Test_openProcessingFile1(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "stdin",
+			args: args{
+				args: []string{"binary_name", "-"},
+			},
+			want: os.Stdin,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -204,4 +334,154 @@ func Test_openProcessingFile1(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func Test_isPiped(t *testing.T) {
+	t.Parallel()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = r.Close(); _ = w.Close() })
+	if !isPiped(r) {
+		t.Error("isPiped(pipe) = false, want true")
+	}
+	null, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = null.Close() })
+	if isPiped(null) {
+		t.Errorf("isPiped(%s) = true, want false for a character device", os.DevNull)
+	}
+}
+
+func Test_fieldError(t *testing.T) {
+	t.Parallel()
+	_, err := loadProcesses(strings.NewReader("1,5,0\n2,9,soon\n"))
+	want := `line 2, column 3 (arrival): invalid value "soon": strconv.ParseInt: parsing "soon": invalid syntax`
+	if err == nil || err.Error() != want {
+		t.Errorf("loadProcesses() error = %v, want %v", err, want)
+	}
+}
+
+func Test_loadProcesses_headerLines(t *testing.T) {
+	t.Parallel()
+	_, err := loadProcesses(strings.NewReader("PID,Burst,Arrival\n1,5,0\n1,9,3\n"))
+	var workloadErr *workloadError
+	if !errors.As(err, &workloadErr) || workloadErr.Row != 3 {
+		t.Errorf("loadProcesses() error = %v, want duplicate reported on line 3", err)
+	}
+}
+
+func Test_loadProcessesDelimited(t *testing.T) {
+	t.Parallel()
+	got, err := loadProcessesDelimited(strings.NewReader("pid;burst;arrival\n1;5;0\n"), ';')
+	if want := []Process{{ProcessID: 1, BurstDuration: 5}}; err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("loadProcessesDelimited() = %v, %v, want %v", got, err, want)
+	}
+
+	_, err = loadProcessesDelimited(strings.NewReader("# header comment\n1\t5\t0\n\n1\t9\t3\n"), '\t')
+	want := "invalid workload: row 4: duplicate process ID 1 (first on row 2)"
+	if err == nil || err.Error() != want {
+		t.Errorf("loadProcessesDelimited() error = %v, want %v", err, want)
+	}
+}
+
+func Test_parseDelimiter(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    rune
+		wantErr error
+	}{
+		{in: ",", want: ','},
+		{in: ";", want: ';'},
+		{in: "tab", want: '\t'},
+		{in: `\t`, want: '\t'},
+		{in: "#", wantErr: ErrInvalidArgs},
+		{in: ";;", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDelimiter(tt.in)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseDelimiter() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Benchmark_loadProcesses(b *testing.B) {
+	var csv strings.Builder
+	for i := 1; i <= 1_000_000; i++ {
+		fmt.Fprintf(&csv, "%d,%d,%d,%d\n", i, i%20+1, i/2, i%5)
+	}
+	data := csv.String()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := loadProcesses(strings.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_schedulers(b *testing.B) {
+	cfg := defaultGeneratorConfig
+	cfg.Count = 1_000_000
+	processes := generateWorkload(rand.New(rand.NewSource(1)), cfg)
+	for _, a := range algorithms {
+		a := a
+		b.Run(a.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a.schedule(io.Discard, a.title, processes, Options{})
+			}
+		})
+	}
+}
+
+func Test_selectAlgorithms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "default all",
+			want: []string{"fcfs", "sjf", "sjf-predicted", "sjf-priority", "rr", "mlq", "wrr", "drr"},
+		},
+		{
+			name:  "given order",
+			names: []string{"rr", " fcfs"},
+			want:  []string{"rr", "fcfs"},
+		},
+		{
+			name:    "unknown",
+			names:   []string{"fcfs", "lottery"},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := selectAlgorithms(tt.names)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, a := range got {
+				names = append(names, a.name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("selectAlgorithms() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// manifestName is the SHA-256 manifest of a report directory, in the format sha256sum -c reads.
+	manifestName = "SHA256SUMS"
+	// signatureName holds the hex HMAC-SHA256 of the manifest under the signing key.
+	signatureName = manifestName + ".hmac"
+)
+
+// errTampered reports a bundle that no longer matches its manifest or signature.
+var errTampered = errors.New("bundle does not match its manifest")
+
+// readSigningKey reads the HMAC key from path, ignoring surrounding whitespace such as a trailing
+// newline.
+func readSigningKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading signing key", err)
+	}
+	if key = bytes.TrimSpace(key); len(key) == 0 {
+		return nil, fmt.Errorf("%w: signing key %s is empty", ErrInvalidArgs, path)
+	}
+
+	return key, nil
+}
+
+// bundleFiles lists the files under dir, slash-separated and relative to it, leaving out the
+// manifest and its signature.
+func bundleFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if rel = filepath.ToSlash(rel); rel != manifestName && rel != signatureName {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing %s", err, dir)
+	}
+
+	return files, nil
+}
+
+// hashFile returns the hex SHA-256 of the file at path.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// signManifest returns the hex HMAC-SHA256 of manifest under key.
+func signManifest(manifest, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(manifest)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// writeManifest writes the SHA-256 of every file in dir to dir/SHA256SUMS and, given a key, its
+// HMAC to dir/SHA256SUMS.hmac, so the bundle can later be checked with verifyManifest.
+func writeManifest(dir string, key []byte) error {
+	files, err := bundleFiles(dir)
+	if err != nil {
+		return err
+	}
+	var manifest bytes.Buffer
+	for _, name := range files {
+		sum, err := hashFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return fmt.Errorf("%w: hashing %s", err, name)
+		}
+		_, _ = fmt.Fprintf(&manifest, "%s  %s\n", sum, name)
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestName), manifest.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("%w: writing manifest", err)
+	}
+	if key == nil {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(dir, signatureName), []byte(signManifest(manifest.Bytes(), key)+"\n"), 0o644); err != nil {
+		return fmt.Errorf("%w: writing manifest signature", err)
+	}
+
+	return nil
+}
+
+// verifyManifest checks dir against its manifest: the signature first when a key is given, then
+// that every listed file is present and unchanged and no unlisted file was added.
+func verifyManifest(dir string, key []byte) error {
+	manifest, err := os.ReadFile(filepath.Join(dir, manifestName))
+	if err != nil {
+		return fmt.Errorf("%w: reading manifest", err)
+	}
+	if key != nil {
+		sig, err := os.ReadFile(filepath.Join(dir, signatureName))
+		if err != nil {
+			return fmt.Errorf("%w: reading manifest signature", err)
+		}
+		if !hmac.Equal(bytes.TrimSpace(sig), []byte(signManifest(manifest, key))) {
+			return fmt.Errorf("%w: the signature does not match the manifest", errTampered)
+		}
+	}
+
+	listed := make(map[string]bool)
+	sc := bufio.NewScanner(bytes.NewReader(manifest))
+	for line := 1; sc.Scan(); line++ {
+		sum, name, ok := strings.Cut(sc.Text(), "  ")
+		if !ok {
+			return fmt.Errorf("%w: manifest line %d is not `sum  file`", errTampered, line)
+		}
+		listed[name] = true
+		got, err := hashFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return fmt.Errorf("%w: %s: %w", errTampered, name, err)
+		}
+		if got != sum {
+			return fmt.Errorf("%w: %s has changed", errTampered, name)
+		}
+	}
+	files, err := bundleFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		if !listed[name] {
+			return fmt.Errorf("%w: %s is not in the manifest", errTampered, name)
+		}
+	}
+
+	return nil
+}
+
+// runVerify checks a report directory written with -manifest, and its signature given
+// -sign-key.
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	keyPath := fs.String("sign-key", "", "check the manifest's HMAC-SHA256 signature with the key in `file`")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: verify [-sign-key file] dir", ErrInvalidArgs)
+	}
+	var key []byte
+	if *keyPath != "" {
+		var err error
+		if key, err = readSigningKey(*keyPath); err != nil {
+			return err
+		}
+	}
+	if err := verifyManifest(fs.Arg(0), key); err != nil {
+		return err
+	}
+	_, _ = fmt.Printf("%s: OK\n", fs.Arg(0))
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_verifyManifest(t *testing.T) {
+	t.Parallel()
+	key := []byte("secret")
+	tests := []struct {
+		name   string
+		tamper func(dir string) error
+		key    []byte
+		want   error
+	}{
+		{name: "untouched", key: key},
+		{name: "untouched, signature unchecked", tamper: func(string) error { return nil }},
+		{
+			name:   "edited report",
+			tamper: func(dir string) error { return os.WriteFile(filepath.Join(dir, "fcfs.txt"), []byte("wait 0"), 0o600) },
+			want:   errTampered,
+		},
+		{
+			name:   "removed report",
+			tamper: func(dir string) error { return os.Remove(filepath.Join(dir, "sjf.txt")) },
+			want:   errTampered,
+		},
+		{
+			name:   "added report",
+			tamper: func(dir string) error { return os.WriteFile(filepath.Join(dir, "rr.txt"), []byte("rr"), 0o600) },
+			want:   errTampered,
+		},
+		{
+			name: "edited report and manifest",
+			tamper: func(dir string) error {
+				if err := os.WriteFile(filepath.Join(dir, "fcfs.txt"), []byte("wait 0"), 0o600); err != nil {
+					return err
+				}
+				return writeManifest(dir, nil)
+			},
+			key:  key,
+			want: errTampered,
+		},
+		{name: "wrong key", key: []byte("guess"), want: errTampered},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for name, report := range map[string]string{"fcfs.txt": "wait 3", "sjf.txt": "wait 2"} {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(report), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writeManifest(dir, key); err != nil {
+				t.Fatal(err)
+			}
+			if tt.tamper != nil {
+				if err := tt.tamper(dir); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := verifyManifest(dir, tt.key); !errors.Is(err, tt.want) {
+				t.Errorf("verifyManifest() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
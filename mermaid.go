@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// writeMermaid writes each result's schedule as a fenced Mermaid gantt block that Markdown
+// renderers such as GitHub's draw as a chart: one section per process in PID order, one task per
+// slice, with ticks as the time axis.
+func writeMermaid(w io.Writer, results []Result) error {
+	var b strings.Builder
+	for i, r := range results {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("```mermaid\ngantt\n")
+		// ; and # start statements and entities in Mermaid, so keep them out of the title
+		fmt.Fprintf(&b, "    title %s\n", strings.NewReplacer(";", ",", "#", "").Replace(r.Title))
+		b.WriteString("    dateFormat X\n    axisFormat %s\n")
+		var pids []int64
+		slices := make(map[int64][]TimeSlice)
+		for _, s := range r.Gantt {
+			if _, ok := slices[s.PID]; !ok {
+				pids = append(pids, s.PID)
+			}
+			slices[s.PID] = append(slices[s.PID], s)
+		}
+		sort.Slice(pids, func(a, b int) bool { return pids[a] < pids[b] })
+		for _, pid := range pids {
+			fmt.Fprintf(&b, "    section P%d\n", pid)
+			for _, s := range slices[pid] {
+				fmt.Fprintf(&b, "    P%d : %d, %d\n", pid, s.Start, s.Stop)
+			}
+		}
+		b.WriteString("```\n")
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("%w: writing Mermaid charts", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_writeMermaid(t *testing.T) {
+	t.Parallel()
+	results := []Result{
+		{Title: "Round-robin; q#2", Gantt: []TimeSlice{{PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 5}, {PID: 2, Start: 5, Stop: 6}}},
+		{Title: "FCFS", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}}},
+	}
+	var w bytes.Buffer
+	if err := writeMermaid(&w, results); err != nil {
+		t.Fatal(err)
+	}
+	want := "```mermaid\ngantt\n    title Round-robin, q2\n    dateFormat X\n    axisFormat %s\n" +
+		"    section P1\n    P1 : 3, 5\n    section P2\n    P2 : 1, 3\n    P2 : 5, 6\n```\n" +
+		"\n```mermaid\ngantt\n    title FCFS\n    dateFormat X\n    axisFormat %s\n" +
+		"    section P1\n    P1 : 0, 2\n```\n"
+	if w.String() != want {
+		t.Errorf("writeMermaid() =\n%s\nwant\n%s", w.String(), want)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var (
+	// workloadBuckets are the upper bounds, in processes, of the workload size histogram.
+	workloadBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 5000}
+	// tickBuckets are the upper bounds, in ticks, of the per-algorithm average histograms.
+	tickBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000}
+)
+
+// histogram counts observations into buckets by upper bound, the way a Prometheus histogram
+// does; counts are per bucket and made cumulative when written.
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(v float64) {
+	if i := sort.SearchFloat64s(h.bounds, v); i < len(h.bounds) {
+		h.counts[i]++
+	}
+	h.sum += v
+	h.count++
+}
+
+// write writes h in the Prometheus text format as name with the given labels, which are
+// rendered ahead of le and must already be formatted as key="value" pairs.
+func (h *histogram) write(w io.Writer, name, labels string) {
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.counts[i]
+		_, _ = fmt.Fprintf(w, "%s_bucket{%s%sle=\"%s\"} %d\n", name, labels, sep, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+	}
+	_, _ = fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, h.count)
+	braces := ""
+	if labels != "" {
+		braces = "{" + labels + "}"
+	}
+	_, _ = fmt.Fprintf(w, "%s_sum%s %s\n", name, braces, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	_, _ = fmt.Fprintf(w, "%s_count%s %d\n", name, braces, h.count)
+}
+
+// serverMetrics is what serve exposes at /metrics: the simulation requests it served and
+// rejected, the size of their workloads, and per algorithm how many runs it made and the
+// distribution of their average wait, turnaround and response.
+type serverMetrics struct {
+	mu           sync.Mutex
+	served       uint64
+	rejected     uint64
+	workloadSize *histogram
+	simulations  map[string]uint64
+	averages     map[string][3]*histogram // wait, turnaround, response
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		workloadSize: newHistogram(workloadBuckets),
+		simulations:  make(map[string]uint64),
+		averages:     make(map[string][3]*histogram),
+	}
+}
+
+// metrics are the server's own; tests make their own serverMetrics so they do not share counts.
+var metrics = newServerMetrics()
+
+// observe records a request that simulated processes with the algorithms of results.
+func (m *serverMetrics) observe(processes []Process, results []Result) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.served++
+	m.workloadSize.observe(float64(len(processes)))
+	for _, r := range results {
+		m.simulations[r.Name]++
+		h, ok := m.averages[r.Name]
+		if !ok {
+			h = [3]*histogram{newHistogram(tickBuckets), newHistogram(tickBuckets), newHistogram(tickBuckets)}
+			m.averages[r.Name] = h
+		}
+		for i, v := range []float64{r.AveWait, r.AveTurnaround, r.AveResponse} {
+			if !math.IsNaN(v) {
+				h[i].observe(v)
+			}
+		}
+	}
+}
+
+// reject records a simulation request that failed validation.
+func (m *serverMetrics) reject() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rejected++
+}
+
+// write writes every metric in the Prometheus text exposition format, algorithms in name order.
+func (m *serverMetrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, _ = fmt.Fprintln(w, "# HELP scheduler_requests_total Simulation requests, by whether they were served or rejected.")
+	_, _ = fmt.Fprintln(w, "# TYPE scheduler_requests_total counter")
+	_, _ = fmt.Fprintf(w, "scheduler_requests_total{outcome=\"served\"} %d\n", m.served)
+	_, _ = fmt.Fprintf(w, "scheduler_requests_total{outcome=\"rejected\"} %d\n", m.rejected)
+	_, _ = fmt.Fprintln(w, "# HELP scheduler_workload_processes Processes in each served workload.")
+	_, _ = fmt.Fprintln(w, "# TYPE scheduler_workload_processes histogram")
+	m.workloadSize.write(w, "scheduler_workload_processes", "")
+
+	names := make([]string, 0, len(m.simulations))
+	for name := range m.simulations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	_, _ = fmt.Fprintln(w, "# HELP scheduler_simulations_total Schedules computed, by algorithm.")
+	_, _ = fmt.Fprintln(w, "# TYPE scheduler_simulations_total counter")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "scheduler_simulations_total{algorithm=%q} %d\n", name, m.simulations[name])
+	}
+	for i, metric := range []string{"wait", "turnaround", "response"} {
+		full := "scheduler_average_" + metric + "_ticks"
+		_, _ = fmt.Fprintf(w, "# HELP %s Average %s of each schedule, by algorithm.\n", full, metric)
+		_, _ = fmt.Fprintf(w, "# TYPE %s histogram\n", full)
+		for _, name := range names {
+			m.averages[name][i].write(w, full, fmt.Sprintf("algorithm=%q", name))
+		}
+	}
+}
+
+func handleMetrics(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.write(w)
+}
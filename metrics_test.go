@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_serverMetrics(t *testing.T) {
+	t.Parallel()
+	m := newServerMetrics()
+	processes := []Process{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 1}}
+	m.observe(processes, []Result{{Name: "rr", AveWait: 3, AveTurnaround: 5.5, AveResponse: 1}, {Name: "fcfs", AveWait: 4}})
+	m.observe(processes[:1], []Result{{Name: "rr", AveTurnaround: 4}})
+	m.reject()
+	var w bytes.Buffer
+	m.write(&w)
+	for _, want := range []string{
+		`scheduler_requests_total{outcome="served"} 2`,
+		`scheduler_requests_total{outcome="rejected"} 1`,
+		`scheduler_workload_processes_bucket{le="1"} 1`,
+		`scheduler_workload_processes_bucket{le="2"} 1`,
+		`scheduler_workload_processes_bucket{le="5"} 2`,
+		`scheduler_workload_processes_bucket{le="+Inf"} 2`,
+		`scheduler_workload_processes_sum 4`,
+		`scheduler_simulations_total{algorithm="fcfs"} 1`,
+		`scheduler_simulations_total{algorithm="rr"} 2`,
+		`scheduler_average_wait_ticks_bucket{algorithm="rr",le="2"} 1`,
+		`scheduler_average_wait_ticks_bucket{algorithm="rr",le="5"} 2`,
+		`scheduler_average_turnaround_ticks_sum{algorithm="rr"} 9.5`,
+		`scheduler_average_response_ticks_count{algorithm="fcfs"} 1`,
+	} {
+		if !strings.Contains(w.String(), want+"\n") {
+			t.Errorf("metrics lack %q:\n%s", want, w.String())
+		}
+	}
+	// fcfs sorts ahead of rr
+	if strings.Index(w.String(), `algorithm="fcfs"`) > strings.Index(w.String(), `algorithm="rr"`) {
+		t.Errorf("algorithms are not in name order:\n%s", w.String())
+	}
+}
+
+func Test_handleMetrics(t *testing.T) {
+	t.Parallel()
+	rec := httptest.NewRecorder()
+	newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatalf("GET /metrics = %d %q, want 200 and the Prometheus text format", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "# TYPE scheduler_requests_total counter\n") {
+		t.Errorf("GET /metrics body lacks the request counter:\n%s", rec.Body.String())
+	}
+}
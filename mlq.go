@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	// defaultForeground is the lowest priority (highest number) in the foreground queue when
+	// Options.Foreground is not set.
+	defaultForeground = 2
+	// defaultForegroundSlice and defaultBackgroundSlice are the turns of the sliced inter-queue
+	// policy when Options does not set them: the textbook 80/20 split.
+	defaultForegroundSlice = 8
+	defaultBackgroundSlice = 2
+)
+
+// mlqReady is the ready set of the multilevel queue: a round-robin foreground queue and an FCFS
+// background queue. The background queue is kept in arrival order, so a process the foreground
+// preempts resumes at its head.
+type mlqReady struct {
+	foreground   ringQueue
+	background   *priorityQueue
+	isForeground func(i int) bool
+	quantum      int64
+	// sliced turns on time slicing between the queues; active is the queue whose turn it is (0
+	// for the foreground) and turnLeft the ticks left in that turn
+	sliced   bool
+	slices   [2]int64
+	active   int
+	turnLeft int64
+}
+
+func (q *mlqReady) len() int { return q.foreground.len() + q.background.len() }
+
+func (q *mlqReady) push(i int) {
+	if q.isForeground(i) {
+		q.foreground.push(i)
+		return
+	}
+	q.background.push(i)
+}
+
+// queue returns the queue to dispatch from, handing the turn to the other queue when the one
+// whose turn it is has nothing ready.
+func (q *mlqReady) queue() readySet {
+	if !q.sliced {
+		if q.foreground.len() > 0 {
+			return &q.foreground
+		}
+		return q.background
+	}
+	queues := [2]readySet{&q.foreground, q.background}
+	if queues[q.active].len() == 0 {
+		q.active = 1 - q.active
+		q.turnLeft = q.slices[q.active]
+	}
+
+	return queues[q.active]
+}
+
+func (q *mlqReady) pop() int  { return q.queue().pop() }
+func (q *mlqReady) peek() int { return q.queue().peek() }
+
+// before lets a foreground arrival preempt a background process under fixed priority; taking
+// turns never preempts.
+func (q *mlqReady) before(a, b int) bool {
+	return !q.sliced && q.isForeground(a) && !q.isForeground(b)
+}
+
+// limit caps a foreground dispatch at the quantum and, when sliced, any dispatch at the rest of
+// its queue's turn.
+func (q *mlqReady) limit(i int) int64 {
+	var limit int64
+	if q.isForeground(i) {
+		limit = q.quantum
+	}
+	if q.sliced && (limit == 0 || q.turnLeft < limit) {
+		limit = q.turnLeft
+	}
+
+	return limit
+}
+
+// ran charges run ticks to the queue whose turn it is, passing the turn on when it is used up.
+func (q *mlqReady) ran(_ int, run int64) {
+	if !q.sliced {
+		return
+	}
+	if q.turnLeft -= run; q.turnLeft <= 0 {
+		q.active = 1 - q.active
+		q.turnLeft = q.slices[q.active]
+	}
+}
+
+// mlqSchedule outputs a multilevel queue schedule of processes. Processes with a priority up to
+// opts.Foreground go to a foreground queue scheduled round-robin with opts.Quantum; the rest go
+// to a background queue scheduled FCFS. opts.InterQueue chooses how the queues share the CPU.
+func mlqSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	foreground := opts.Foreground
+	if foreground == 0 {
+		foreground = defaultForeground
+	}
+	ready := &mlqReady{
+		isForeground: func(i int) bool { return processes[i].Priority <= foreground },
+		background: newPriorityQueue(func(a, b int) bool {
+			if processes[a].ArrivalTime != processes[b].ArrivalTime {
+				return processes[a].ArrivalTime < processes[b].ArrivalTime
+			}
+			return a < b
+		}),
+		quantum: opts.Quantum,
+		sliced:  opts.InterQueue == "sliced",
+		slices:  [2]int64{opts.ForegroundSlice, opts.BackgroundSlice},
+	}
+	if ready.quantum <= 0 {
+		ready.quantum = defaultQuantum
+	}
+	if ready.slices[0] <= 0 {
+		ready.slices[0] = defaultForegroundSlice
+	}
+	if ready.slices[1] <= 0 {
+		ready.slices[1] = defaultBackgroundSlice
+	}
+	ready.turnLeft = ready.slices[0]
+
+	return resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		ready:      func([]int64) readySet { return ready },
+		limit:      ready.limit,
+		ran:        ready.ran,
+		preemptive: !ready.sliced,
+	}))
+}
+
+// checkInterQueue rejects an unknown Options.InterQueue. Under fixed, the default, the background
+// queue runs only while the foreground queue is empty; under sliced the queues take turns.
+func checkInterQueue(name string) error {
+	if name != "" && name != "fixed" && name != "sliced" {
+		return fmt.Errorf("%w: unknown inter-queue policy %q, want fixed or sliced", ErrInvalidArgs, name)
+	}
+
+	return nil
+}
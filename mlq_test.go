@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_mlqSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 2},
+	}
+	tests := []struct {
+		name string
+		opts Options
+		want []TimeSlice
+	}{
+		{
+			name: "fixed priority preempts the background",
+			opts: Options{Quantum: 2},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 3, Start: 3, Stop: 5},
+				{PID: 2, Start: 5, Stop: 6}, {PID: 1, Start: 6, Stop: 10},
+			},
+		},
+		{
+			name: "sliced turns",
+			opts: Options{Quantum: 2, InterQueue: "sliced", ForegroundSlice: 2, BackgroundSlice: 1},
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 6}, {PID: 1, Start: 6, Stop: 7}, {PID: 2, Start: 7, Stop: 8},
+				{PID: 1, Start: 8, Stop: 9}, {PID: 1, Start: 9, Stop: 10},
+			},
+		},
+		{
+			name: "all foreground is round-robin",
+			opts: Options{Quantum: 2, Foreground: 5},
+			want: RRSchedule(io.Discard, "", processes).Gantt,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := mlqSchedule(io.Discard, "", processes, tt.opts).Gantt
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mlqSchedule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := checkInterQueue("lottery"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("checkInterQueue() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
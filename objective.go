@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// scoreMetrics are the Result figures a weighted objective can combine: the averages, context
+// switches, idle time and utilization, and every statistic of the wait, turnaround and response
+// distributions, e.g. p95Turnaround or maxWait.
+var scoreMetrics = func() map[string]func(Result) float64 {
+	metrics := map[string]func(Result) float64{
+		"avgWait":       func(r Result) float64 { return r.AveWait },
+		"avgTurnaround": func(r Result) float64 { return r.AveTurnaround },
+		"avgResponse":   func(r Result) float64 { return r.AveResponse },
+		"switches":      func(r Result) float64 { return float64(r.ContextSwitches) },
+		"idle":          func(r Result) float64 { return float64(r.IdleTime) },
+		"utilization":   func(r Result) float64 { return r.Utilization },
+	}
+	for name, d := range map[string]func(Result) Distribution{
+		"Wait":       func(r Result) Distribution { return r.WaitStats },
+		"Turnaround": func(r Result) Distribution { return r.TurnaroundStats },
+		"Response":   func(r Result) Distribution { return r.ResponseStats },
+	} {
+		metrics["min"+name] = func(r Result) float64 { return d(r).Min }
+		metrics["max"+name] = func(r Result) float64 { return d(r).Max }
+		metrics["median"+name] = func(r Result) float64 { return d(r).Median }
+		metrics["p95"+name] = func(r Result) float64 { return d(r).P95 }
+		metrics["stddev"+name] = func(r Result) float64 { return d(r).StdDev }
+	}
+
+	return metrics
+}()
+
+// objectiveTerm is one weighted metric of a weightedObjective.
+type objectiveTerm struct {
+	weight float64
+	metric string
+}
+
+// weightedObjective scores a result as the weighted sum of its metrics; lower is better.
+type weightedObjective []objectiveTerm
+
+// parseObjective reads terms of the form weight*metric, or a bare metric for weight 1, joined by
+// +, e.g. "0.5*avgWait + 0.3*p95Turnaround + 0.2*switches". A negative weight rewards a metric.
+func parseObjective(spec string) (weightedObjective, error) {
+	var objective weightedObjective
+	for _, term := range strings.Split(spec, "+") {
+		term = strings.TrimSpace(term)
+		t := objectiveTerm{weight: 1, metric: term}
+		if w, metric, ok := strings.Cut(term, "*"); ok {
+			weight, err := strconv.ParseFloat(strings.TrimSpace(w), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w: -objective weight in %q", ErrInvalidArgs, err, term)
+			}
+			t = objectiveTerm{weight: weight, metric: strings.TrimSpace(metric)}
+		}
+		if _, ok := scoreMetrics[t.metric]; !ok {
+			return nil, fmt.Errorf("%w: -objective metric %q is unknown (want e.g. avgWait, p95Turnaround, switches or utilization)", ErrInvalidArgs, t.metric)
+		}
+		objective = append(objective, t)
+	}
+
+	return objective, nil
+}
+
+func (o weightedObjective) score(r Result) float64 {
+	var score float64
+	for _, t := range o {
+		score += t.weight * scoreMetrics[t.metric](r)
+	}
+
+	return score
+}
+
+func (o weightedObjective) String() string {
+	terms := make([]string, len(o))
+	for i, t := range o {
+		terms[i] = fmt.Sprintf("%g*%s", t.weight, t.metric)
+	}
+
+	return strings.Join(terms, " + ")
+}
+
+// rank returns the indexes of results from the best score to the worst, ties in result order.
+func (o weightedObjective) rank(results []Result) []int {
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return o.score(results[order[a]]) < o.score(results[order[b]]) })
+
+	return order
+}
+
+// outputObjectiveRanking ranks results by objective, showing each term's metric beside the score.
+func outputObjectiveRanking(w io.Writer, objective weightedObjective, results []Result) {
+	_, _ = fmt.Fprintf(w, "Ranking by %s\n", objective)
+	table := tablewriter.NewWriter(w)
+	header := []string{"Rank", "Algorithm", "Score"}
+	for _, t := range objective {
+		header = append(header, t.metric)
+	}
+	table.SetHeader(header)
+	for rank, i := range objective.rank(results) {
+		row := []string{fmt.Sprint(rank + 1), results[i].Name, fmt.Sprintf("%.2f", objective.score(results[i]))}
+		for _, t := range objective {
+			row = append(row, fmt.Sprintf("%.2f", scoreMetrics[t.metric](results[i])))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func outputPlainObjectiveRanking(w io.Writer, objective weightedObjective, results []Result) {
+	for rank, i := range objective.rank(results) {
+		_, _ = fmt.Fprintf(w, "objective: rank %d, algorithm %s, score %.2f\n", rank+1, results[i].Name, objective.score(results[i]))
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_parseObjective(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    weightedObjective
+		wantErr error
+	}{
+		{
+			name: "weighted terms",
+			spec: "0.5*avgWait + 0.3*p95Turnaround + 0.2*switches",
+			want: weightedObjective{{0.5, "avgWait"}, {0.3, "p95Turnaround"}, {0.2, "switches"}},
+		},
+		{name: "bare metric", spec: "maxResponse", want: weightedObjective{{1, "maxResponse"}}},
+		{name: "negative weight", spec: "avgWait+-0.1*utilization", want: weightedObjective{{1, "avgWait"}, {-0.1, "utilization"}}},
+		{name: "unknown metric", spec: "0.5*latency", wantErr: ErrInvalidArgs},
+		{name: "bad weight", spec: "half*avgWait", wantErr: ErrInvalidArgs},
+		{name: "empty term", spec: "avgWait +", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseObjective(tt.spec)
+			if !errors.Is(err, tt.wantErr) || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseObjective() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_weightedObjective_rank(t *testing.T) {
+	t.Parallel()
+	results := []Result{
+		{Name: "fcfs", AveWait: 6, ContextSwitches: 2, TurnaroundStats: Distribution{P95: 20}},
+		{Name: "rr", AveWait: 4, ContextSwitches: 12, TurnaroundStats: Distribution{P95: 18}},
+		{Name: "sjf", AveWait: 3, ContextSwitches: 2, TurnaroundStats: Distribution{P95: 22}},
+	}
+	objective, err := parseObjective("0.5*avgWait + 0.3*p95Turnaround + 0.2*switches")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fcfs 3+6+0.4 = 9.4, rr 2+5.4+2.4 = 9.8, sjf 1.5+6.6+0.4 = 8.5
+	if got, want := objective.rank(results), []int{2, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rank() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// procSample is what one read of /proc/<pid>/stat says about a host process.
+	procSample struct {
+		alive bool
+		comm  string
+		// cpu is the user plus system time the process has used, in clock ticks
+		cpu  int64
+		nice int64
+	}
+	// observedProcess is a host process as the scheduling model sees it.
+	observedProcess struct {
+		Process
+		HostPID int
+		Comm    string
+	}
+	// observation is a host schedule in the TimeSlice model, one tick per sampling interval.
+	observation struct {
+		Processes []observedProcess
+		Gantt     []TimeSlice
+		// Shared counts the ticks in which more than one process ran, time-sliced within the
+		// interval or in parallel on several cores.
+		Shared int64
+		// Idle lists the host PIDs that never ran while they were observed, and were left out.
+		Idle []int
+	}
+)
+
+// readProcStat reads /proc/<pid>/stat from fsys, rooted at /proc. A process that has exited
+// reads as not alive.
+func readProcStat(fsys fs.FS, pid int) (procSample, error) {
+	data, err := fs.ReadFile(fsys, strconv.Itoa(pid)+"/stat")
+	if errors.Is(err, fs.ErrNotExist) {
+		return procSample{}, nil
+	}
+	if err != nil {
+		return procSample{}, fmt.Errorf("%w: reading stat of PID %d", err, pid)
+	}
+	// the command name is in parentheses and may itself contain spaces and parentheses
+	s := string(data)
+	open, end := strings.IndexByte(s, '('), strings.LastIndexByte(s, ')')
+	if open < 0 || end < open {
+		return procSample{}, fmt.Errorf("%w: malformed stat of PID %d", ErrInvalidArgs, pid)
+	}
+	// fields from the state on: state is field 3, utime 14, stime 15 and nice 19
+	fields := strings.Fields(s[end+1:])
+	if len(fields) < 17 {
+		return procSample{}, fmt.Errorf("%w: short stat of PID %d", ErrInvalidArgs, pid)
+	}
+	if fields[0] == "Z" || fields[0] == "X" {
+		return procSample{}, nil // exited, not yet reaped
+	}
+	var values [3]int64
+	for k, pos := range []int{11, 12, 16} {
+		if values[k], err = strconv.ParseInt(fields[pos], 10, 64); err != nil {
+			return procSample{}, fmt.Errorf("%w: stat of PID %d: %w", ErrInvalidArgs, pid, err)
+		}
+	}
+
+	return procSample{alive: true, comm: s[open+1 : end], cpu: values[0] + values[1], nice: values[2]}, nil
+}
+
+// sampleProcs reads the stat of every PID in pids once per interval until duration is up or
+// they have all exited. samples[k][j] is what the k-th read said of pids[j].
+func sampleProcs(fsys fs.FS, pids []int, interval, duration time.Duration) ([][]procSample, error) {
+	var samples [][]procSample
+	for start := time.Now(); ; time.Sleep(interval) {
+		row := make([]procSample, len(pids))
+		alive := false
+		for j, pid := range pids {
+			sample, err := readProcStat(fsys, pid)
+			if err != nil {
+				return nil, err
+			}
+			row[j] = sample
+			alive = alive || sample.alive
+		}
+		samples = append(samples, row)
+		if !alive || time.Since(start) >= duration {
+			return samples, nil
+		}
+	}
+}
+
+// buildObservation turns samples of pids into a one-CPU schedule. Tick k is the interval between
+// reads k and k+1. It goes to the process that ran in it with the most CPU time not yet credited
+// as ticks, so processes sharing intervals get them in proportion; ties go to the PID listed
+// first. A process that exits in an interval counts as having run in it. A process arrives at the
+// first read that sees it, and its burst is the ticks it got. Priorities are nice values shifted
+// to 0..39.
+func buildObservation(pids []int, samples [][]procSample) observation {
+	var (
+		obs     observation
+		ticks   = make([][]int64, len(pids)) // ticks[j] lists the ticks pids[j] got
+		arrival = make([]int64, len(pids))
+		seen    = make([]bool, len(pids))
+		owed    = make([]int64, len(pids)) // CPU time used but not yet credited
+		last    = make([]procSample, len(pids))
+	)
+	for k := 0; k+1 < len(samples); k++ {
+		winner, ran, total := -1, 0, int64(0)
+		for j := range pids {
+			before, after := samples[k][j], samples[k+1][j]
+			if !before.alive {
+				continue
+			}
+			if !seen[j] {
+				seen[j], arrival[j], last[j] = true, int64(k), before
+			}
+			used := after.cpu - before.cpu
+			if !after.alive {
+				used = 1 // it ran to exit, but its final CPU time is gone with it
+			}
+			if used <= 0 {
+				continue
+			}
+			ran++
+			total += used
+			owed[j] += used
+			if winner < 0 || owed[j] > owed[winner] {
+				winner = j
+			}
+		}
+		if winner >= 0 {
+			ticks[winner] = append(ticks[winner], int64(k))
+			owed[winner] -= total
+		}
+		if ran > 1 {
+			obs.Shared++
+		}
+	}
+
+	// number the processes that ran 1..n in the order they were listed
+	pidOf := make([]int64, len(pids))
+	for j, pid := range pids {
+		if len(ticks[j]) == 0 {
+			obs.Idle = append(obs.Idle, pid)
+			continue
+		}
+		pidOf[j] = int64(len(obs.Processes) + 1)
+		obs.Processes = append(obs.Processes, observedProcess{
+			Process: Process{
+				ProcessID:     pidOf[j],
+				ArrivalTime:   arrival[j],
+				BurstDuration: int64(len(ticks[j])),
+				Priority:      last[j].nice + 20,
+			},
+			HostPID: pid,
+			Comm:    last[j].comm,
+		})
+	}
+	for k := 0; k+1 < len(samples); k++ {
+		for j := range pids {
+			if len(ticks[j]) == 0 || ticks[j][0] != int64(k) {
+				continue
+			}
+			ticks[j] = ticks[j][1:]
+			if n := len(obs.Gantt); n > 0 && obs.Gantt[n-1].PID == pidOf[j] && obs.Gantt[n-1].Stop == int64(k) {
+				obs.Gantt[n-1].Stop++
+			} else {
+				obs.Gantt = append(obs.Gantt, TimeSlice{PID: pidOf[j], Start: int64(k), Stop: int64(k + 1)})
+			}
+		}
+	}
+
+	return obs
+}
+
+// processes returns the observed processes in the scheduling model.
+func (o observation) processes() []Process {
+	processes := make([]Process, len(o.Processes))
+	for i, p := range o.Processes {
+		processes[i] = p.Process
+	}
+
+	return processes
+}
+
+func outputObservation(w io.Writer, interval time.Duration, o observation) {
+	_, _ = fmt.Fprintf(w, "Observed processes (1 tick = %s)\n", interval)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Host PID", "Command", "Arrival", "Burst", "Priority"})
+	for _, p := range o.Processes {
+		table.Append([]string{fmt.Sprint(p.ProcessID), fmt.Sprint(p.HostPID), p.Comm, fmt.Sprint(p.ArrivalTime), fmt.Sprint(p.BurstDuration), fmt.Sprint(p.Priority)})
+	}
+	table.Render()
+	outputObservationNotes(w, o)
+}
+
+func outputPlainObservation(w io.Writer, interval time.Duration, o observation) {
+	_, _ = fmt.Fprintf(w, "tick: %s\n", interval)
+	for _, p := range o.Processes {
+		_, _ = fmt.Fprintf(w, "observed: pid %d, host pid %d, command %s, arrival %d, burst %d, priority %d\n",
+			p.ProcessID, p.HostPID, p.Comm, p.ArrivalTime, p.BurstDuration, p.Priority)
+	}
+	outputObservationNotes(w, o)
+}
+
+func outputObservationNotes(w io.Writer, o observation) {
+	if o.Shared > 0 {
+		_, _ = fmt.Fprintf(w, "note: %d ticks were shared by several processes and credited to one; a shorter -interval, with the workload pinned to one core (taskset -c 0), gives a closer one-CPU view\n", o.Shared)
+	}
+	for _, pid := range o.Idle {
+		_, _ = fmt.Fprintf(w, "note: PID %d never ran while observed and is left out\n", pid)
+	}
+}
+
+// outputObservedVsSimulated lists each observed process's turnaround on the host next to its
+// turnaround under each simulated policy.
+func outputObservedVsSimulated(w io.Writer, o observation, observed Result, simulated []Result) {
+	_, _ = fmt.Fprintln(w, "Turnaround, observed vs simulated")
+	table := tablewriter.NewWriter(w)
+	header := []string{"ID", "Host PID", "Command", "Observed"}
+	turnarounds := make([][]float64, 0, len(simulated)+1)
+	for _, r := range append([]Result{observed}, simulated...) {
+		turnarounds = append(turnarounds, processTimesFromGantt(o.processes(), r.Gantt).turnaround)
+	}
+	for _, r := range simulated {
+		header = append(header, r.Name)
+	}
+	table.SetHeader(header)
+	for i, p := range o.Processes {
+		row := []string{fmt.Sprint(p.ProcessID), fmt.Sprint(p.HostPID), p.Comm}
+		for _, t := range turnarounds {
+			row = append(row, fmt.Sprint(t[i]))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func outputPlainObservedVsSimulated(w io.Writer, o observation, observed Result, simulated []Result) {
+	host := processTimesFromGantt(o.processes(), observed.Gantt).turnaround
+	for _, r := range simulated {
+		sim := processTimesFromGantt(o.processes(), r.Gantt).turnaround
+		for i, p := range o.Processes {
+			_, _ = fmt.Fprintf(w, "versus: algorithm %s, pid %d, host pid %d, observed turnaround %.0f, simulated turnaround %.0f\n",
+				r.Name, p.ProcessID, p.HostPID, host[i], sim[i])
+		}
+	}
+}
+
+// runObserve is the observe subcommand: it samples /proc for a set of PIDs and reports their
+// host schedule like a simulated one, and with -algo how those policies would have scheduled the
+// same processes.
+func runObserve(args []string) error {
+	fs := flag.NewFlagSet("observe", flag.ExitOnError)
+	pidList := fs.String("pids", "", "comma-separated host `PIDs` to observe")
+	interval := fs.Duration("interval", 10*time.Millisecond, "sampling `interval`, which becomes one tick")
+	duration := fs.Duration("duration", 5*time.Second, "longest `time` to observe for; it ends early once every process has exited")
+	plain := fs.Bool("plain", false, "print labeled key: value lines instead of charts and tables")
+	out := fs.String("o", "", "also write the observed processes to `file` as a workload")
+	algo := fs.String("algo", "", "comma-separated `names` of algorithms to simulate on the observed processes and compare with the host")
+	quantum := fs.Int64("quantum", defaultQuantum, "round-robin time slice in `ticks` for -algo")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("%w: observe reads /proc, which needs Linux", ErrInvalidArgs)
+	}
+	if *pidList == "" || fs.NArg() != 0 {
+		return fmt.Errorf("%w: usage: observe -pids 1234,5678 [-interval 10ms] [-duration 5s]", ErrInvalidArgs)
+	}
+	if *interval <= 0 || *duration <= 0 {
+		return fmt.Errorf("%w: -interval and -duration must be positive", ErrInvalidArgs)
+	}
+	if *quantum < 1 {
+		return fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs)
+	}
+	var selected []algorithm
+	if *algo != "" {
+		var err error
+		if selected, err = selectAlgorithms(strings.Split(*algo, ",")); err != nil {
+			return err
+		}
+	}
+	values, err := parseInt64List(*pidList)
+	if err != nil {
+		return fmt.Errorf("%w: -pids %q: %w", ErrInvalidArgs, *pidList, err)
+	}
+	pids := make([]int, len(values))
+	for i, v := range values {
+		pids[i] = int(v)
+	}
+
+	samples, err := sampleProcs(os.DirFS("/proc"), pids, *interval, *duration)
+	if err != nil {
+		return err
+	}
+	obs := buildObservation(pids, samples)
+	if len(obs.Processes) == 0 {
+		return fmt.Errorf("%w: none of the PIDs ran while observed", ErrInvalidArgs)
+	}
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("%w: creating workload file", err)
+		}
+		if err := writeWorkloadCSV(f, obs.processes()); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("%w: closing workload file", err)
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if *plain {
+		w = io.Discard
+		outputPlainObservation(os.Stdout, *interval, obs)
+	} else {
+		outputObservation(os.Stdout, *interval, obs)
+	}
+	result := resultFromGantt(w, "Observed host schedule", obs.processes(), obs.Gantt)
+	result.Name = "observed"
+	deriveMetrics(&result, obs.processes())
+	if *plain {
+		outputPlain(os.Stdout, result)
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	// what the chosen policies would have done with the same arrivals and bursts
+	simulated := make([]Result, len(selected))
+	for i, a := range selected {
+		if simulated[i], err = runAlgorithmChecked(a, io.Discard, obs.processes(), Options{Quantum: *quantum}); err != nil {
+			return err
+		}
+	}
+	results := append([]Result{result}, simulated...)
+	if *plain {
+		outputPlainComparison(os.Stdout, results)
+		outputPlainObservedVsSimulated(os.Stdout, obs, result, simulated)
+	} else {
+		outputComparison(os.Stdout, results)
+		outputObservedVsSimulated(os.Stdout, obs, result, simulated)
+	}
+
+	return nil
+}
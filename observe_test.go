@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func Test_readProcStat(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"41/stat": {Data: []byte("41 (my (odd) cmd) R 1 41 41 0 -1 4194304 90 0 0 0 7 3 0 0 20 5 1 0 100 0 0\n")},
+		"42/stat": {Data: []byte("42 (gone) Z 1 42 42 0 -1 4194304 90 0 0 0 7 3 0 0 20 0 1 0 100 0 0\n")},
+	}
+	tests := []struct {
+		name string
+		pid  int
+		want procSample
+	}{
+		{name: "running", pid: 41, want: procSample{alive: true, comm: "my (odd) cmd", cpu: 10, nice: 5}},
+		{name: "zombie", pid: 42},
+		{name: "exited", pid: 43},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := readProcStat(fsys, tt.pid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("readProcStat() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildObservation(t *testing.T) {
+	t.Parallel()
+	run := func(cpu int64) procSample { return procSample{alive: true, comm: "job", cpu: cpu} }
+	gone := procSample{}
+	// 10 runs alone, then 20 starts and gets a tick, the two share ticks 2 and 3 as 20 exits, and
+	// 30 never runs
+	samples := [][]procSample{
+		{run(0), gone, run(5)},
+		{run(2), run(0), run(5)},
+		{run(2), run(3), run(5)},
+		{run(4), run(4), run(5)},
+		{run(6), gone, run(5)},
+	}
+	got := buildObservation([]int{10, 20, 30}, samples)
+	want := observation{
+		Processes: []observedProcess{
+			{Process: Process{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 20}, HostPID: 10, Comm: "job"},
+			{Process: Process{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Priority: 20}, HostPID: 20, Comm: "job"},
+		},
+		Gantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 4}},
+		Shared: 2,
+		Idle:   []int{30},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildObservation() = %+v, want %+v", got, want)
+	}
+	if err := checkGantt(got.processes(), got.Gantt); err != nil {
+		t.Errorf("observed gantt is not a one-CPU schedule: %v", err)
+	}
+}
+
+func Test_outputPlainObservedVsSimulated(t *testing.T) {
+	t.Parallel()
+	// the host interleaved a long and a short job; fcfs would have run them back to back
+	o := observation{
+		Processes: []observedProcess{
+			{Process: Process{ProcessID: 1, BurstDuration: 4}, HostPID: 100, Comm: "long"},
+			{Process: Process{ProcessID: 2, BurstDuration: 2}, HostPID: 200, Comm: "short"},
+		},
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 4}, {PID: 1, Start: 4, Stop: 6}},
+	}
+	observed := Result{Name: "observed", Gantt: o.Gantt}
+	simulated := []Result{runAlgorithm(*findAlgorithm("fcfs"), io.Discard, o.processes(), Options{})}
+
+	var b bytes.Buffer
+	outputPlainObservedVsSimulated(&b, o, observed, simulated)
+	want := `versus: algorithm fcfs, pid 1, host pid 100, observed turnaround 6, simulated turnaround 4
+versus: algorithm fcfs, pid 2, host pid 200, observed turnaround 4, simulated turnaround 6
+`
+	if b.String() != want {
+		t.Errorf("outputPlainObservedVsSimulated() =\n%s\nwant\n%s", b.String(), want)
+	}
+}
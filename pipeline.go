@@ -0,0 +1,375 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// pipelineKey orders the processes waiting at one level of the pipeline: the lowest key goes
+// first and ties keep queue order. remaining is indexed like processes.
+type pipelineKey func(p Process, remaining int64) int64
+
+var (
+	// admitPolicies choose which job in the job queue the long-term scheduler admits next.
+	admitPolicies = map[string]pipelineKey{
+		"fcfs": func(Process, int64) int64 { return 0 },
+		"sjf":  func(p Process, _ int64) int64 { return p.BurstDuration },
+	}
+	// swapPolicies choose which swapped-out process the medium-term scheduler loads next.
+	swapPolicies = map[string]pipelineKey{
+		"fcfs":     func(Process, int64) int64 { return 0 },
+		"smallest": func(p Process, _ int64) int64 { return p.Memory },
+	}
+	// cpuPolicies choose which resident process the short-term scheduler dispatches next. Only
+	// rr preempts, after a quantum.
+	cpuPolicies = map[string]pipelineKey{
+		"fcfs":     func(Process, int64) int64 { return 0 },
+		"sjf":      func(_ Process, remaining int64) int64 { return remaining },
+		"priority": func(p Process, _ int64) int64 { return p.Priority },
+		"rr":       func(Process, int64) int64 { return 0 },
+	}
+)
+
+type (
+	// pipelineConfig picks a policy for each level of the pipeline. Zero jobs or memory means
+	// that level never holds anything back.
+	pipelineConfig struct {
+		admit   string
+		jobs    int
+		swap    string
+		memory  int64
+		cpu     string
+		quantum int64
+		plain   bool
+	}
+	// pipelineStats is where one process spent its time before completing: waiting in the job
+	// queue for admission, admitted but swapped out, and resident but not on the CPU.
+	pipelineStats struct {
+		Process
+		Admitted   int64
+		Completion int64
+		JobWait    int64
+		SwapWait   int64
+		ReadyWait  int64
+		SwapOuts   int
+	}
+	pipelineResult struct {
+		Gantt []TimeSlice
+		// Stats is indexed like the workload.
+		Stats []pipelineStats
+		// PeakResident is the most processes that were in memory at once.
+		PeakResident int
+		SwapOuts     int
+	}
+)
+
+// validate rejects unknown policies and workloads that could never be loaded.
+func (cfg pipelineConfig) validate(processes []Process) error {
+	for _, level := range []struct {
+		flag, name string
+		policies   map[string]pipelineKey
+	}{
+		{"admit", cfg.admit, admitPolicies},
+		{"swap", cfg.swap, swapPolicies},
+		{"cpu", cfg.cpu, cpuPolicies},
+	} {
+		if _, ok := level.policies[level.name]; !ok {
+			names := make([]string, 0, len(level.policies))
+			for name := range level.policies {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("%w: unknown -%s %q, want one of %s", ErrInvalidArgs, level.flag, level.name, strings.Join(names, ", "))
+		}
+	}
+	switch {
+	case cfg.jobs < 0:
+		return fmt.Errorf("%w: -jobs must not be negative", ErrInvalidArgs)
+	case cfg.memory < 0:
+		return fmt.Errorf("%w: -memory must not be negative", ErrInvalidArgs)
+	case hasDependencies(processes):
+		return fmt.Errorf("%w: pipeline does not model dependencies between processes", ErrInvalidArgs)
+	case cfg.cpu == "rr" && cfg.quantum < 1:
+		return fmt.Errorf("%w: -quantum must be at least 1", ErrInvalidArgs)
+	}
+	if cfg.memory > 0 {
+		for _, p := range processes {
+			if p.Memory > cfg.memory {
+				return fmt.Errorf("%w: process %d needs memory %d, more than -memory %d", ErrInvalidArgs, p.ProcessID, p.Memory, cfg.memory)
+			}
+		}
+	}
+
+	return nil
+}
+
+// pickFirst returns the position in queue of the process with the lowest key, the earliest on a
+// tie.
+func pickFirst(queue []int, processes []Process, remaining []int64, key pipelineKey) int {
+	best := 0
+	for pos := 1; pos < len(queue); pos++ {
+		i, b := queue[pos], queue[best]
+		if key(processes[i], remaining[i]) < key(processes[b], remaining[b]) {
+			best = pos
+		}
+	}
+
+	return best
+}
+
+// removeAt deletes queue[pos], keeping the rest in order.
+func removeAt(queue []int, pos int) []int {
+	return append(queue[:pos], queue[pos+1:]...)
+}
+
+// pipeline runs processes through the three scheduling levels. Arrivals enter the job queue; the
+// long-term scheduler admits them while fewer than cfg.jobs are in the system; the medium-term
+// scheduler loads admitted processes while they fit in cfg.memory, and under rr swaps a
+// preempted process out when another is waiting for memory; the short-term scheduler dispatches
+// resident processes to the CPU. Memory is released and a job slot freed when a process completes.
+func pipeline(processes []Process, cfg pipelineConfig) pipelineResult {
+	n := len(processes)
+	res := pipelineResult{Stats: make([]pipelineStats, n)}
+	remaining := make([]int64, n)
+	for i, p := range processes {
+		remaining[i] = p.BurstDuration
+		res.Stats[i].Process = p
+	}
+	order := arrivalOrder(processes)
+	admit, swap, cpu := admitPolicies[cfg.admit], swapPolicies[cfg.swap], cpuPolicies[cfg.cpu]
+
+	var (
+		jobQueue, swapped, ready []int
+		since                    = make([]int64, n) // when each process entered its current queue
+		next, admitted, resident int
+		done                     int
+		used, now                int64
+	)
+	// step moves everything that can advance at now: arrivals into the job queue, admissions
+	// into the swapped-out set, and loads into memory.
+	step := func() {
+		for ; next < n && processes[order[next]].ArrivalTime <= now; next++ {
+			i := order[next]
+			jobQueue = append(jobQueue, i)
+			since[i] = processes[i].ArrivalTime
+		}
+		for len(jobQueue) > 0 && (cfg.jobs == 0 || admitted < cfg.jobs) {
+			pos := pickFirst(jobQueue, processes, remaining, admit)
+			i := jobQueue[pos]
+			jobQueue = removeAt(jobQueue, pos)
+			admitted++
+			res.Stats[i].Admitted, res.Stats[i].JobWait = now, now-since[i]
+			swapped = append(swapped, i)
+			since[i] = now
+		}
+		for len(swapped) > 0 {
+			pos := pickFirst(swapped, processes, remaining, swap)
+			i := swapped[pos]
+			if cfg.memory > 0 && used+processes[i].Memory > cfg.memory {
+				break
+			}
+			swapped = removeAt(swapped, pos)
+			used += processes[i].Memory
+			resident++
+			res.Stats[i].SwapWait += now - since[i]
+			ready = append(ready, i)
+			since[i] = now
+		}
+		res.PeakResident = max(res.PeakResident, resident)
+	}
+
+	for done < n {
+		step()
+		if len(ready) == 0 {
+			if next == n {
+				break // unreachable once cfg is validated, since an empty memory fits anyone
+			}
+			now = max(now, processes[order[next]].ArrivalTime)
+			continue
+		}
+		pos := pickFirst(ready, processes, remaining, cpu)
+		i := ready[pos]
+		ready = removeAt(ready, pos)
+		res.Stats[i].ReadyWait += now - since[i]
+
+		run := remaining[i]
+		if cfg.cpu == "rr" {
+			run = min(run, cfg.quantum)
+		}
+		start := now
+		res.Gantt = append(res.Gantt, TimeSlice{PID: processes[i].ProcessID, Start: start, Stop: start + run})
+		// the upper levels keep admitting and loading arrivals while the CPU is busy
+		for next < n && processes[order[next]].ArrivalTime < start+run {
+			now = processes[order[next]].ArrivalTime
+			step()
+		}
+		now = start + run
+		remaining[i] -= run
+		if remaining[i] == 0 {
+			done++
+			admitted--
+			resident--
+			used -= processes[i].Memory
+			res.Stats[i].Completion = now
+			continue
+		}
+
+		// arrivals during the slice queue ahead of the preempted process
+		step()
+		since[i] = now
+		if len(swapped) > 0 {
+			used -= processes[i].Memory
+			resident--
+			swapped = append(swapped, i)
+			res.Stats[i].SwapOuts++
+			res.SwapOuts++
+			continue
+		}
+		ready = append(ready, i)
+	}
+
+	return res
+}
+
+// pipelineTitle describes the policy chosen at each level.
+func pipelineTitle(cfg pipelineConfig) string {
+	jobs, memory, cpu := "unlimited jobs", "unlimited memory", cfg.cpu
+	if cfg.jobs > 0 {
+		jobs = fmt.Sprintf("at most %d jobs", cfg.jobs)
+	}
+	if cfg.memory > 0 {
+		memory = fmt.Sprintf("memory %d", cfg.memory)
+	}
+	if cfg.cpu == "rr" {
+		cpu += fmt.Sprintf(", quantum %d", cfg.quantum)
+	}
+
+	return fmt.Sprintf("admit %s, %s | swap %s, %s | cpu %s", cfg.admit, jobs, cfg.swap, memory, cpu)
+}
+
+// averageWaits returns the mean job-queue, swapped-out and ready wait over res.
+func averageWaits(res pipelineResult) (job, swap, ready float64) {
+	if len(res.Stats) == 0 {
+		return 0, 0, 0
+	}
+	for _, s := range res.Stats {
+		job += float64(s.JobWait)
+		swap += float64(s.SwapWait)
+		ready += float64(s.ReadyWait)
+	}
+	n := float64(len(res.Stats))
+
+	return job / n, swap / n, ready / n
+}
+
+// pipelineHeader names the columns of the pipeline table.
+var pipelineHeader = []string{"ID", "Arrival", "Burst", "Memory", "Admitted", "Job wait", "Swap wait", "Ready wait", "Swap-outs", "Completion"}
+
+// pipelineRow formats s in pipelineHeader order.
+func pipelineRow(s pipelineStats) []string {
+	return []string{
+		fmt.Sprint(s.ProcessID),
+		fmt.Sprint(s.ArrivalTime),
+		fmt.Sprint(s.BurstDuration),
+		fmt.Sprint(s.Memory),
+		fmt.Sprint(s.Admitted),
+		fmt.Sprint(s.JobWait),
+		fmt.Sprint(s.SwapWait),
+		fmt.Sprint(s.ReadyWait),
+		fmt.Sprint(s.SwapOuts),
+		fmt.Sprint(s.Completion),
+	}
+}
+
+// outputPipeline prints the CPU gantt chart and where each process waited at every level.
+func outputPipeline(w io.Writer, cfg pipelineConfig, res pipelineResult) {
+	_, _ = fmt.Fprintf(w, "Pipeline: %s\n", pipelineTitle(cfg))
+	outputGantt(w, res.Gantt)
+	_, _ = fmt.Fprintln(w, "Pipeline table")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(pipelineHeader)
+	for _, s := range res.Stats {
+		table.Append(pipelineRow(s))
+	}
+	job, swap, ready := averageWaits(res)
+	table.SetFooter([]string{"", "", "", "", "",
+		fmt.Sprintf("Average\n%.2f", job),
+		fmt.Sprintf("Average\n%.2f", swap),
+		fmt.Sprintf("Average\n%.2f", ready),
+		fmt.Sprint(res.SwapOuts), ""})
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Peak resident processes: %d\n", res.PeakResident)
+}
+
+// outputPlainPipeline is the -plain form of outputPipeline.
+func outputPlainPipeline(w io.Writer, cfg pipelineConfig, res pipelineResult) {
+	_, _ = fmt.Fprintf(w, "pipeline: %s\n", pipelineTitle(cfg))
+	for _, s := range withIdle(res.Gantt) {
+		if s.PID == idlePID {
+			_, _ = fmt.Fprintf(w, "idle: start %d, stop %d\n", s.Start, s.Stop)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "slice: pid %d, start %d, stop %d\n", s.PID, s.Start, s.Stop)
+	}
+	for _, s := range res.Stats {
+		row := pipelineRow(s)
+		fields := make([]string, len(row))
+		for i := range row {
+			fields[i] = strings.ToLower(pipelineHeader[i]) + " " + row[i]
+		}
+		_, _ = fmt.Fprintf(w, "process: %s\n", strings.Join(fields, ", "))
+	}
+	job, swap, ready := averageWaits(res)
+	_, _ = fmt.Fprintf(w, "average job wait: %.2f\n", job)
+	_, _ = fmt.Fprintf(w, "average swap wait: %.2f\n", swap)
+	_, _ = fmt.Fprintf(w, "average ready wait: %.2f\n", ready)
+	_, _ = fmt.Fprintf(w, "swap-outs: %d\n", res.SwapOuts)
+	_, _ = fmt.Fprintf(w, "peak resident processes: %d\n", res.PeakResident)
+}
+
+// runPipeline schedules a workload through long-term admission, medium-term swapping and
+// short-term dispatch, each with its own policy, and reports how long processes waited at each.
+func runPipeline(args []string) error {
+	cfg := pipelineConfig{admit: "fcfs", swap: "fcfs", cpu: "rr", quantum: defaultQuantum}
+	fs := flag.NewFlagSet("pipeline", flag.ExitOnError)
+	fs.StringVar(&cfg.admit, "admit", cfg.admit, "long-term `policy` for admitting jobs: fcfs or sjf")
+	fs.IntVar(&cfg.jobs, "jobs", cfg.jobs, "most `jobs` admitted at once, the degree of multiprogramming (0 for no limit)")
+	fs.StringVar(&cfg.swap, "swap", cfg.swap, "medium-term `policy` for loading swapped-out processes: fcfs or smallest")
+	fs.Int64Var(&cfg.memory, "memory", cfg.memory, "memory `size` shared by resident processes (0 for no limit)")
+	fs.StringVar(&cfg.cpu, "cpu", cfg.cpu, "short-term `policy` for dispatching: fcfs, sjf, priority or rr")
+	fs.Int64Var(&cfg.quantum, "quantum", cfg.quantum, "round-robin time slice in `ticks`")
+	fs.BoolVar(&cfg.plain, "plain", cfg.plain, "print labeled key: value lines instead of tables")
+	delim := fs.String("delimiter", ",", "field `separator` of the workload file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: pipeline [-admit policy] [-jobs n] [-swap policy] [-memory size] [-cpu policy] workload.csv", ErrInvalidArgs)
+	}
+	delimiter, err := parseDelimiter(*delim)
+	if err != nil {
+		return err
+	}
+	processes, err := loadWorkloadFile(fs.Arg(0), delimiter)
+	if err != nil {
+		return err
+	}
+	if err := cfg.validate(processes); err != nil {
+		return err
+	}
+
+	res := pipeline(processes, cfg)
+	if cfg.plain {
+		outputPlainPipeline(os.Stdout, cfg, res)
+	} else {
+		outputPipeline(os.Stdout, cfg, res)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_pipeline(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Memory: 60},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Memory: 50},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 4, Memory: 30},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 2, Memory: 20},
+	}
+	tests := []struct {
+		name      string
+		cfg       pipelineConfig
+		wantGantt []TimeSlice
+		// wantWaits holds each process's job, swap and ready wait
+		wantWaits    [][3]int64
+		wantSwapOuts int
+		wantPeak     int
+	}{
+		{
+			name: "no limits is plain fcfs",
+			cfg:  pipelineConfig{admit: "fcfs", swap: "fcfs", cpu: "fcfs"},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}, {PID: 3, Start: 8, Stop: 12}, {PID: 4, Start: 12, Stop: 14},
+			},
+			wantWaits: [][3]int64{{0, 0, 0}, {0, 0, 4}, {0, 0, 6}, {0, 0, 9}},
+			wantPeak:  4,
+		},
+		{
+			name: "job limit holds arrivals in the job queue",
+			cfg:  pipelineConfig{admit: "sjf", jobs: 2, swap: "fcfs", cpu: "fcfs"},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}, {PID: 4, Start: 8, Stop: 10}, {PID: 3, Start: 10, Stop: 14},
+			},
+			// 4 is admitted ahead of 3 at 5, when 1 frees its slot, as the shorter job
+			wantWaits: [][3]int64{{0, 0, 0}, {0, 0, 4}, {6, 0, 2}, {2, 0, 3}},
+			wantPeak:  2,
+		},
+		{
+			name: "memory limit swaps out preempted processes",
+			cfg:  pipelineConfig{admit: "fcfs", jobs: 3, swap: "fcfs", memory: 100, cpu: "rr", quantum: 2},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 6}, {PID: 1, Start: 6, Stop: 8},
+				{PID: 2, Start: 8, Stop: 9}, {PID: 3, Start: 9, Stop: 11}, {PID: 1, Start: 11, Stop: 12}, {PID: 4, Start: 12, Stop: 14},
+			},
+			wantWaits:    [][3]int64{{0, 3, 4}, {0, 5, 0}, {0, 2, 3}, {6, 2, 1}},
+			wantSwapOuts: 4,
+			wantPeak:     2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.cfg.validate(processes); err != nil {
+				t.Fatal(err)
+			}
+			res := pipeline(processes, tt.cfg)
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("pipeline() gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+			for i, s := range res.Stats {
+				if got := [3]int64{s.JobWait, s.SwapWait, s.ReadyWait}; got != tt.wantWaits[i] {
+					t.Errorf("pipeline() process %d waits = %v, want %v", s.ProcessID, got, tt.wantWaits[i])
+				}
+				// every tick between arrival and completion is spent running or waiting at one level
+				if s.JobWait+s.SwapWait+s.ReadyWait != s.Completion-s.ArrivalTime-s.BurstDuration {
+					t.Errorf("pipeline() process %d waits %d+%d+%d do not add up to its wait %d",
+						s.ProcessID, s.JobWait, s.SwapWait, s.ReadyWait, s.Completion-s.ArrivalTime-s.BurstDuration)
+				}
+			}
+			if res.SwapOuts != tt.wantSwapOuts || res.PeakResident != tt.wantPeak {
+				t.Errorf("pipeline() swap-outs %d, peak resident %d, want %d, %d", res.SwapOuts, res.PeakResident, tt.wantSwapOuts, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func Test_pipelineConfig_validate(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 1, Memory: 50}}
+	base := pipelineConfig{admit: "fcfs", swap: "fcfs", cpu: "rr", quantum: 2}
+	tests := []struct {
+		name   string
+		change func(*pipelineConfig)
+		want   error
+	}{
+		{name: "defaults", change: func(*pipelineConfig) {}},
+		{name: "unknown policy", change: func(c *pipelineConfig) { c.swap = "lru" }, want: ErrInvalidArgs},
+		{name: "process larger than memory", change: func(c *pipelineConfig) { c.memory = 40 }, want: ErrInvalidArgs},
+		{name: "negative jobs", change: func(c *pipelineConfig) { c.jobs = -1 }, want: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := base
+			tt.change(&cfg)
+			if err := cfg.validate(processes); !errors.Is(err, tt.want) {
+				t.Errorf("validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputPlainPipeline(t *testing.T) {
+	t.Parallel()
+	cfg := pipelineConfig{admit: "fcfs", jobs: 1, swap: "fcfs", cpu: "fcfs"}
+	processes := []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1, Memory: 8}}
+	var out bytes.Buffer
+	outputPlainPipeline(&out, cfg, pipeline(processes, cfg))
+	for _, want := range []string{
+		"pipeline: admit fcfs, at most 1 jobs | swap fcfs, unlimited memory | cpu fcfs\n",
+		"process: id 2, arrival 0, burst 1, memory 8, admitted 2, job wait 2, swap wait 0, ready wait 0, swap-outs 0, completion 3\n",
+		"average job wait: 1.00\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("outputPlainPipeline() lacks %q:\n%s", want, out.String())
+		}
+	}
+}
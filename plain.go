@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// outputPlain prints a result as labeled "key: value" lines with no box drawing, alignment or
+// escape codes, so screen readers read it naturally and diffs stay line-oriented.
+func outputPlain(w io.Writer, r Result) {
+	_, _ = fmt.Fprintf(w, "algorithm: %s\n", r.Title)
+	for _, s := range withIdle(r.Gantt) {
+		if s.PID == idlePID {
+			_, _ = fmt.Fprintf(w, "idle: start %d, stop %d\n", s.Start, s.Stop)
+			continue
+		}
+		if s.Reason != "" {
+			_, _ = fmt.Fprintf(w, "slice: pid %d, start %d, stop %d, ended by %s\n", s.PID, s.Start, s.Stop, s.Reason)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "slice: pid %d, start %d, stop %d\n", s.PID, s.Start, s.Stop)
+	}
+	for _, row := range r.Schedule {
+		fields := make([]string, 0, len(row))
+		for i := range row {
+			fields = append(fields, strings.ToLower(scheduleHeader[i])+" "+row[i])
+		}
+		_, _ = fmt.Fprintf(w, "process: %s\n", strings.Join(fields, ", "))
+	}
+	for _, p := range r.Predictions {
+		_, _ = fmt.Fprintf(w, "prediction: pid %d, predicted %.2f, actual %d\n", p.PID, p.Predicted, p.Actual)
+	}
+	if len(r.Predictions) > 0 {
+		_, _ = fmt.Fprintf(w, "prediction error: %.2f\n", predictionError(r.Predictions))
+	}
+	_, _ = fmt.Fprintf(w, "average wait: %.2f\n", r.AveWait)
+	_, _ = fmt.Fprintf(w, "average turnaround: %.2f\n", r.AveTurnaround)
+	_, _ = fmt.Fprintf(w, "throughput: %.2f/t\n\n", r.AveThroughput)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputPlain(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputPlain(&w, Result{
+		Title:         "First-come, First-serve",
+		Gantt:         []TimeSlice{{PID: 1, Start: 0, Stop: 5}},
+		Schedule:      [][]string{{"1", "2", "5", "0", "0", "0", "5", "5"}},
+		AveWait:       0,
+		AveTurnaround: 5,
+		AveThroughput: 0.2,
+	})
+
+	want := `algorithm: First-come, First-serve
+slice: pid 1, start 0, stop 5
+process: id 1, priority 2, burst 5, arrival 0, wait 0, response 0, turnaround 5, exit 5
+average wait: 0.00
+average turnaround: 5.00
+throughput: 0.20/t
+
+`
+	if got := w.String(); got != want {
+		t.Errorf("outputPlain() = %v, want %v", got, want)
+	}
+}
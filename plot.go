@@ -0,0 +1,62 @@
+//go:build !nocharts
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+)
+
+// metricPlots lists the charts written by writeMetricPlots, one per averaged metric.
+var metricPlots = []struct {
+	file   string
+	title  string
+	yLabel string
+	value  func(Result) float64
+}{
+	{"wait.png", "Average wait", "ticks", func(r Result) float64 { return r.AveWait }},
+	{"turnaround.png", "Average turnaround", "ticks", func(r Result) float64 { return r.AveTurnaround }},
+	{"throughput.png", "Throughput", "processes / tick", func(r Result) float64 { return r.AveThroughput }},
+}
+
+// writeMetricPlots writes a PNG bar chart per metric into dir comparing every result. gonum/plot
+// renders in pure Go, so this needs no cgo; build with -tags nocharts to leave it and its
+// dependencies out of binaries that never draw charts.
+func writeMetricPlots(dir string, results []Result) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%w: creating plots directory", err)
+	}
+
+	names := make([]string, len(results))
+	for i := range results {
+		names[i] = results[i].Name
+	}
+	for _, m := range metricPlots {
+		values := make(plotter.Values, len(results))
+		for i := range results {
+			values[i] = m.value(results[i])
+		}
+
+		p := plot.New()
+		p.Title.Text = m.title
+		p.Y.Label.Text = m.yLabel
+		p.Y.Min = 0
+		bars, err := plotter.NewBarChart(values, vg.Points(40))
+		if err != nil {
+			return fmt.Errorf("%w: building %s chart", err, m.title)
+		}
+		p.Add(bars)
+		p.NominalX(names...)
+
+		if err := p.Save(6*vg.Inch, 4*vg.Inch, filepath.Join(dir, m.file)); err != nil {
+			return fmt.Errorf("%w: saving %s", err, m.file)
+		}
+	}
+
+	return nil
+}
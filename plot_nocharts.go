@@ -0,0 +1,11 @@
+//go:build nocharts
+
+package main
+
+import "fmt"
+
+// writeMetricPlots stands in for the gonum/plot charts, which binaries built with -tags nocharts
+// leave out; the Gantt SVGs, HTML reports and terminal images need no chart library and remain.
+func writeMetricPlots(string, []Result) error {
+	return fmt.Errorf("%w: -plots is unavailable in a binary built with -tags nocharts", ErrInvalidArgs)
+}
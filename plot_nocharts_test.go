@@ -0,0 +1,15 @@
+//go:build nocharts
+
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_writeMetricPlots_nocharts(t *testing.T) {
+	t.Parallel()
+	if err := writeMetricPlots(t.TempDir(), nil); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("writeMetricPlots() error = %v, want ErrInvalidArgs", err)
+	}
+}
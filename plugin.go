@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"plugin"
+	"strings"
+)
+
+// pluginSchedule is the signature a plugin's Schedule must have. A plugin cannot import this
+// package's types, so it sees each process as {pid, arrival, burst, priority} and answers with the
+// slices to run as {pid, start, stop}, in time order.
+type pluginSchedule = func(processes [][4]int64) [][3]int64
+
+// pluginScheduler is a Scheduler loaded from a Go plugin.
+type pluginScheduler struct {
+	path     string
+	name     string
+	title    string
+	schedule pluginSchedule
+}
+
+// loadPlugin opens a Go plugin (built with go build -buildmode=plugin) that exports:
+//
+//	var Name string                             // used by -algo; must be unique
+//	var Title string                            // optional, defaults to Name
+//	func Schedule([][4]int64) [][3]int64        // see pluginSchedule
+func loadPlugin(path string) (Scheduler, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening plugin", err)
+	}
+	s := &pluginScheduler{path: path}
+
+	sym, err := p.Lookup("Name")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: plugin %s", ErrInvalidArgs, err, path)
+	}
+	name, ok := sym.(*string)
+	if !ok || *name == "" {
+		return nil, fmt.Errorf("%w: plugin %s: Name must be a non-empty string", ErrInvalidArgs, path)
+	}
+	s.name, s.title = *name, *name
+	if sym, err := p.Lookup("Title"); err == nil {
+		if title, ok := sym.(*string); ok && *title != "" {
+			s.title = *title
+		}
+	}
+
+	if sym, err = p.Lookup("Schedule"); err != nil {
+		return nil, fmt.Errorf("%w: %w: plugin %s", ErrInvalidArgs, err, path)
+	}
+	if s.schedule, ok = sym.(pluginSchedule); !ok {
+		return nil, fmt.Errorf("%w: plugin %s: Schedule is %T, want func([][4]int64) [][3]int64", ErrInvalidArgs, path, sym)
+	}
+
+	return s, nil
+}
+
+// registerPlugins loads and registers the schedulers of a comma-separated list of plugin files.
+func registerPlugins(paths string) error {
+	for _, path := range strings.Split(paths, ",") {
+		s, err := loadPlugin(strings.TrimSpace(path))
+		if err != nil {
+			return err
+		}
+		if findAlgorithm(s.Name()) != nil {
+			return fmt.Errorf("%w: plugin %s: algorithm %q already exists", ErrInvalidArgs, path, s.Name())
+		}
+		Register(s)
+	}
+
+	return nil
+}
+
+func (s *pluginScheduler) Name() string  { return s.name }
+func (s *pluginScheduler) Title() string { return s.title }
+
+// ScheduleChecked runs the plugin and reports its slices, or returns an error wrapping
+// ErrInvalidSchedule if they are not a schedule of processes, rather than have its numbers
+// compared against the built-in policies.
+func (s *pluginScheduler) ScheduleChecked(w io.Writer, title string, processes []Process, _ Options) (Result, error) {
+	in := make([][4]int64, len(processes))
+	for i, p := range processes {
+		in[i] = [4]int64{p.ProcessID, p.ArrivalTime, p.BurstDuration, p.Priority}
+	}
+	out := s.schedule(in)
+	gantt := make([]TimeSlice, len(out))
+	for i, slice := range out {
+		gantt[i] = TimeSlice{PID: slice[0], Start: slice[1], Stop: slice[2]}
+	}
+	if err := checkGantt(processes, gantt); err != nil {
+		return Result{}, fmt.Errorf("%w: plugin %s: %w", ErrInvalidSchedule, s.path, err)
+	}
+
+	return resultFromGantt(w, title, processes, gantt), nil
+}
+
+// Schedule is ScheduleChecked for callers that cannot take an error: an invalid schedule is
+// reported in place of the plugin's, which leaves every process unfinished.
+func (s *pluginScheduler) Schedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	result, err := s.ScheduleChecked(w, title, processes, opts)
+	if err != nil {
+		_, _ = fmt.Fprintf(w, "%v\n", err)
+		return resultFromGantt(w, title, processes, nil)
+	}
+
+	return result
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Test_invalidPluginSchedule checks that every caller running a registered plugin reports its
+// invalid schedule instead of results. It is not parallel because it changes the registry;
+// parallel tests only resume once it has restored it.
+func Test_invalidPluginSchedule(t *testing.T) {
+	builtin := algorithms
+	t.Cleanup(func() { algorithms = builtin })
+
+	// a buggy plugin running both processes at once
+	Register(&pluginScheduler{path: "overlap.so", name: "overlap", title: "Overlap", schedule: func([][4]int64) [][3]int64 {
+		return [][3]int64{{1, 0, 4}, {2, 2, 5}}
+	}})
+	overlap := *findAlgorithm("overlap")
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T) error
+	}{
+		{
+			name: "RunAll",
+			run: func(t *testing.T) error {
+				_, err := RunAll(processes)
+				return err
+			},
+		},
+		{
+			name: "experiment",
+			run: func(t *testing.T) error {
+				cfg := defaultGeneratorConfig
+				cfg.Count = 2
+				_, err := experiment(rand.New(rand.NewSource(1)), cfg, 1, []algorithm{overlap}, Options{}, nil)
+				return err
+			},
+		},
+		{
+			name: "autotune",
+			run: func(t *testing.T) error {
+				_, err := autotune([]algorithm{overlap}, [][]Process{processes}, weightedObjective{{1, "avgWait"}}, false, nil, 0)
+				return err
+			},
+		},
+		{
+			name: "measureStability",
+			run: func(t *testing.T) error {
+				_, err := measureStability(overlap, processes, Options{}, 2, 1, rand.New(rand.NewSource(1)))
+				return err
+			},
+		},
+		{
+			name: "outputLookaheadSweep",
+			run: func(t *testing.T) error {
+				return outputLookaheadSweep(io.Discard, processes, []algorithm{overlap}, []int64{0})
+			},
+		},
+		{
+			name: "tui",
+			run: func(t *testing.T) error {
+				m := newTUIModel(processes, []algorithm{overlap}, Options{})
+				m.selectAlgorithm(0)
+				if !strings.Contains(m.View(), "invalid schedule") {
+					t.Errorf("View() = %q, want the error", m.View())
+				}
+				return m.err
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(t); !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("error = %v, want %v", err, ErrInvalidSchedule)
+			}
+		})
+	}
+
+	t.Run("simulate", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := `{"processes":[{"pid":1,"burst":4,"arrival":0},{"pid":2,"burst":3,"arrival":0}],"algorithms":["fcfs","overlap"]}`
+		newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/simulate", strings.NewReader(body)))
+		if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "overlap.so") {
+			t.Errorf("status = %v, body %s; want %v naming the plugin", rec.Code, rec.Body, http.StatusUnprocessableEntity)
+		}
+	})
+}
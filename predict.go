@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+const (
+	// defaultAlpha is the weight of the latest burst in the exponential average when
+	// Options.Alpha is not set.
+	defaultAlpha = 0.5
+	// defaultInitialGuess is the predicted burst of a process with no history when
+	// Options.InitialGuess is not set.
+	defaultInitialGuess = 10
+)
+
+// Prediction is the burst sjf-predicted expected of a process when it dispatched it, next to the
+// burst it turned out to have.
+type Prediction struct {
+	PID       int64   `json:"pid"`
+	Predicted float64 `json:"predicted"`
+	Actual    int64   `json:"actual"`
+}
+
+// predictedReady is the ready set of sjf-predicted. Processes copied from one bulk row share a
+// history: each is the next burst of the same job, and the job's prediction is the exponential
+// average of the bursts it has completed. Processes of no bulk row have no history and keep the
+// initial guess. Processes with the same prediction go in workload order.
+type predictedReady struct {
+	// buckets holds the ready processes of each history, keyed by group (0 for none) in keys order
+	buckets map[int64]*priorityQueue
+	keys    []int64
+	tau     map[int64]float64
+	n       int
+	group   func(i int) int64
+	// predicted records each process's prediction as it is dispatched
+	predicted []float64
+}
+
+func (q *predictedReady) len() int { return q.n }
+
+func (q *predictedReady) push(i int) {
+	q.buckets[q.group(i)].push(i)
+	q.n++
+}
+
+// best returns the history whose head has the shortest predicted burst.
+func (q *predictedReady) best() int64 {
+	found, best := false, int64(0)
+	for _, k := range q.keys {
+		b := q.buckets[k]
+		if b.len() == 0 {
+			continue
+		}
+		if !found || q.tau[k] < q.tau[best] || q.tau[k] == q.tau[best] && b.peek() < q.buckets[best].peek() {
+			found, best = true, k
+		}
+	}
+
+	return best
+}
+
+func (q *predictedReady) peek() int { return q.buckets[q.best()].peek() }
+
+func (q *predictedReady) pop() int {
+	k := q.best()
+	i := q.buckets[k].pop()
+	q.n--
+	q.predicted[i] = q.tau[k]
+
+	return i
+}
+
+func (q *predictedReady) before(_, _ int) bool { return false }
+
+// sjfPredictedSchedule outputs a non-preemptive SJF schedule of processes that orders them by
+// predicted rather than true bursts: an exponential average with weight opts.Alpha over the
+// bursts each job has completed, starting from opts.InitialGuess. Its report adds each process's
+// prediction and the mean absolute prediction error.
+func sjfPredictedSchedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	alpha, guess := opts.Alpha, float64(opts.InitialGuess)
+	if alpha == 0 {
+		alpha = defaultAlpha
+	}
+	if guess == 0 {
+		guess = defaultInitialGuess
+	}
+	ready := &predictedReady{
+		buckets:   map[int64]*priorityQueue{},
+		tau:       map[int64]float64{},
+		group:     func(i int) int64 { return processes[i].Group },
+		predicted: make([]float64, len(processes)),
+	}
+	for _, p := range processes {
+		if _, ok := ready.buckets[p.Group]; !ok {
+			ready.buckets[p.Group] = newPriorityQueue(func(a, b int) bool { return a < b })
+			ready.tau[p.Group] = guess
+			ready.keys = append(ready.keys, p.Group)
+		}
+	}
+	sort.Slice(ready.keys, func(a, b int) bool { return ready.keys[a] < ready.keys[b] })
+
+	result := resultFromGantt(w, title, processes, simulateEvents(opts.Context(), processes, simPolicy{
+		ready: func([]int64) readySet { return ready },
+		// processes run to completion, so every stretch is a whole burst
+		ran: func(i int, run int64) {
+			if g := processes[i].Group; g != 0 {
+				ready.tau[g] = alpha*float64(run) + (1-alpha)*ready.tau[g]
+			}
+		},
+	}))
+	result.Predictions = make([]Prediction, len(processes))
+	for i, p := range processes {
+		result.Predictions[i] = Prediction{PID: p.ProcessID, Predicted: ready.predicted[i], Actual: p.BurstDuration}
+	}
+	outputPredictions(w, result.Predictions)
+
+	return result
+}
+
+// predictionError is the mean absolute difference between predicted and actual bursts.
+func predictionError(predictions []Prediction) float64 {
+	errs := make([]float64, len(predictions))
+	for i, p := range predictions {
+		errs[i] = math.Abs(p.Predicted - float64(p.Actual))
+	}
+
+	return mean(errs)
+}
+
+func outputPredictions(w io.Writer, predictions []Prediction) {
+	if w == io.Discard {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Burst prediction")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Predicted", "Actual", "Error"})
+	for _, p := range predictions {
+		table.Append([]string{fmt.Sprint(p.PID), fmt.Sprintf("%.2f", p.Predicted), fmt.Sprint(p.Actual), fmt.Sprintf("%.2f", p.Predicted-float64(p.Actual))})
+	}
+	table.SetFooter([]string{"", "", "Mean abs error", fmt.Sprintf("%.2f", predictionError(predictions))})
+	table.Render()
+}
+
+// checkPrediction rejects an exponential-average weight outside 0..1 or a negative initial guess;
+// zero means the default for either.
+func checkPrediction(alpha float64, guess int64) error {
+	switch {
+	case alpha < 0 || alpha > 1:
+		return fmt.Errorf("%w: alpha %g must be between 0 and 1", ErrInvalidArgs, alpha)
+	case guess < 0:
+		return fmt.Errorf("%w: initial burst guess %d must not be negative", ErrInvalidArgs, guess)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_sjfPredictedSchedule(t *testing.T) {
+	t.Parallel()
+	// two jobs, groups 1 and 2, each submitting two bursts; the second burst of each is ordered by
+	// what the first taught
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Group: 1},
+		{ProcessID: 2, BurstDuration: 20, Group: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 6, Group: 1},
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 2, Group: 2},
+	}
+	got := sjfPredictedSchedule(io.Discard, "sjf-predicted", processes, Options{})
+	wantGantt := []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 3, Start: 4, Stop: 10}, {PID: 2, Start: 10, Stop: 30}, {PID: 4, Start: 30, Stop: 32}}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("gantt = %v, want %v", got.Gantt, wantGantt)
+	}
+	wantPredictions := []Prediction{{1, 10, 4}, {2, 10, 20}, {3, 7, 6}, {4, 15, 2}}
+	if !reflect.DeepEqual(got.Predictions, wantPredictions) {
+		t.Errorf("predictions = %v, want %v", got.Predictions, wantPredictions)
+	}
+	if e := predictionError(got.Predictions); e != 7.5 {
+		t.Errorf("predictionError() = %v, want 7.5", e)
+	}
+
+	// trusting only the latest burst
+	got = sjfPredictedSchedule(io.Discard, "sjf-predicted", processes, Options{Alpha: 1, InitialGuess: 3})
+	wantPredictions = []Prediction{{1, 3, 4}, {2, 3, 20}, {3, 4, 6}, {4, 20, 2}}
+	if !reflect.DeepEqual(got.Predictions, wantPredictions) {
+		t.Errorf("predictions with alpha 1 = %v, want %v", got.Predictions, wantPredictions)
+	}
+}
+
+func Test_checkPrediction(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		alpha   float64
+		guess   int64
+		wantErr error
+	}{
+		{name: "defaults", alpha: 0, guess: 0},
+		{name: "latest only", alpha: 1, guess: 5},
+		{name: "alpha above 1", alpha: 1.5, wantErr: ErrInvalidArgs},
+		{name: "negative guess", alpha: 0.5, guess: -1, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := checkPrediction(tt.alpha, tt.guess); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkPrediction() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
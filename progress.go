@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// progressDelay is how long a run goes without a progress bar, so quick runs never show one.
+	progressDelay = 500 * time.Millisecond
+	// progressInterval is the least time between redraws of the bar.
+	progressInterval = 100 * time.Millisecond
+	// progressWidth is the width of the bar in characters.
+	progressWidth = 30
+)
+
+var (
+	// progressOut is where long runs draw their progress bars, or nil for none. main sets it to
+	// stderr when that is a terminal and -no-progress is not given.
+	progressOut io.Writer
+	// progressMu serializes drawing, as algorithms running at once share the line; progressLine
+	// is the length of what is on it.
+	progressMu   sync.Mutex
+	progressLine int
+)
+
+// progressKey is the context key of the hook simulateEvents reports the simulated time to,
+// carried the way net/http/httptrace carries its hooks.
+type progressKey struct{}
+
+// withProgress returns ctx carrying report, which simulateEvents calls with the simulated time
+// every so often as it advances.
+func withProgress(ctx context.Context, report func(now int64)) context.Context {
+	return context.WithValue(ctx, progressKey{}, report)
+}
+
+// progressFrom returns the hook ctx carries, or nil.
+func progressFrom(ctx context.Context) func(now int64) {
+	report, _ := ctx.Value(progressKey{}).(func(now int64))
+	return report
+}
+
+// progressBar draws how far a run has simulated towards the end of its workload.
+type progressBar struct {
+	w     io.Writer
+	label string
+	total int64
+	start time.Time
+	drawn time.Time
+}
+
+// startProgress returns ctx with a progress bar for label running processes, drawn to
+// progressOut, and a func that erases the bar once the run is over. Without progressOut it returns
+// ctx unchanged.
+func startProgress(ctx context.Context, label string, processes []Process) (context.Context, func()) {
+	total := estimatedEnd(processes)
+	if progressOut == nil || total == 0 {
+		return ctx, func() {}
+	}
+	bar := &progressBar{w: progressOut, label: label, total: total, start: time.Now()}
+
+	return withProgress(ctx, bar.update), bar.finish
+}
+
+// estimatedEnd is when the last process completes if the CPU is never idle while work is ready:
+// exact for every work-conserving policy, short of dependencies, charged quanta or deliberate
+// idling.
+func estimatedEnd(processes []Process) int64 {
+	var end int64
+	for _, i := range arrivalOrder(processes) {
+		end = max(end, processes[i].ArrivalTime) + processes[i].BurstDuration
+	}
+
+	return end
+}
+
+func (b *progressBar) update(now int64) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	t := time.Now()
+	if t.Sub(b.start) < progressDelay || t.Sub(b.drawn) < progressInterval {
+		return
+	}
+	b.drawn = t
+	// in float64, as now*100 overflows for the huge bursts that make runs long
+	percent := min(100, int64(float64(now)*100/float64(b.total)))
+	filled := int(percent) * progressWidth / 100
+	line := fmt.Sprintf("%s [%s%s] %3d%% t=%d/%d", b.label,
+		strings.Repeat("#", filled), strings.Repeat(".", progressWidth-filled), percent, now, b.total)
+	// padded to cover a longer bar drawn before, by this run or another
+	_, _ = fmt.Fprint(b.w, "\r"+line+strings.Repeat(" ", max(progressLine-len(line), 0)))
+	progressLine = len(line)
+}
+
+// finish erases the line, if this bar was ever drawn, leaving the cursor at its start. A bar of
+// another run still going is drawn again at its next update.
+func (b *progressBar) finish() {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if !b.drawn.IsZero() && progressLine > 0 {
+		// blanked with spaces rather than an escape sequence, which legacy consoles print
+		_, _ = fmt.Fprint(b.w, "\r"+strings.Repeat(" ", progressLine)+"\r")
+		progressLine = 0
+	}
+}
+
+// progressEnabled reports whether progress bars should go to f: it must be a terminal and
+// -no-progress not given.
+func progressEnabled(f *os.File, noProgress bool) bool {
+	fi, err := f.Stat()
+	return !noProgress && err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
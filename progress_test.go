@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_estimatedEnd(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      int64
+	}{
+		{name: "back to back", processes: []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 3}}, want: 8},
+		{name: "idle gap", processes: []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 6, BurstDuration: 3}}, want: 9},
+		{name: "out of order", processes: []Process{{ProcessID: 1, ArrivalTime: 4, BurstDuration: 1}, {ProcessID: 2, BurstDuration: 2}}, want: 5},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := estimatedEnd(tt.processes); got != tt.want {
+				t.Errorf("estimatedEnd() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_progressBar(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	bar := &progressBar{w: &b, label: "rr", total: 200, start: time.Now().Add(-time.Second)}
+	bar.update(50)
+	if got, want := b.String(), "\rrr [#######.......................]  25% t=50/200"; got != want {
+		t.Errorf("update() drew %q, want %q", got, want)
+	}
+	bar.update(100)
+	if strings.Contains(b.String(), "50%") {
+		t.Errorf("update() redrew within progressInterval: %q", b.String())
+	}
+	b.Reset()
+	bar.finish()
+	if got := b.String(); strings.TrimSpace(got) != "" || len(got) < 2 {
+		t.Errorf("finish() wrote %q, want the bar blanked", got)
+	}
+
+	b.Reset()
+	huge := &progressBar{w: &b, label: "rr", total: 4e18, start: time.Now().Add(-time.Second)}
+	huge.update(1e18)
+	if got := b.String(); !strings.Contains(got, " 25% t=1000000000000000000/4000000000000000000") {
+		t.Errorf("update() of a huge workload drew %q, want 25%%", got)
+	}
+	huge.finish()
+
+	b.Reset()
+	quick := &progressBar{w: &b, label: "fcfs", total: 200, start: time.Now()}
+	quick.update(100)
+	quick.finish()
+	if b.Len() != 0 {
+		t.Errorf("a run shorter than progressDelay drew %q", b.String())
+	}
+}
+
+func Test_simulateEvents_progress(t *testing.T) {
+	t.Parallel()
+	processes := make([]Process, 3000)
+	for i := range processes {
+		processes[i] = Process{ProcessID: int64(i + 1), BurstDuration: 2}
+	}
+	var reported []int64
+	ctx := withProgress(context.Background(), func(now int64) { reported = append(reported, now) })
+	simulateEvents(ctx, processes, simPolicy{ready: func([]int64) readySet { return &ringQueue{} }})
+	if len(reported) < 2 || reported[0] != 0 || reported[len(reported)-1] <= reported[0] {
+		t.Errorf("progress hook was told %v, want times advancing from 0", reported)
+	}
+}
@@ -0,0 +1,428 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// errMalformedProto is wrapped by every error decoding protobuf results.
+var errMalformedProto = errors.New("malformed protobuf")
+
+// Protobuf wire types used by result.proto.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// endReasons maps TimeSlice.Reason to the EndReason enum of result.proto, by index.
+var endReasons = []string{"", endCompletion, endQuantum, endArrival}
+
+func appendTag(b []byte, field, wire int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wire))
+}
+
+// appendInt appends an int64 field, omitting zero as proto3 does.
+func appendInt(b []byte, field int, v int64) []byte {
+	if v == 0 {
+		return b
+	}
+
+	return binary.AppendUvarint(appendTag(b, field, wireVarint), uint64(v))
+}
+
+// appendDouble appends a double field, omitting zero as proto3 does.
+func appendDouble(b []byte, field int, v float64) []byte {
+	if v == 0 {
+		return b
+	}
+
+	return binary.LittleEndian.AppendUint64(appendTag(b, field, wireFixed64), math.Float64bits(v))
+}
+
+// appendBytes appends a length-delimited field: a string or an embedded message.
+func appendBytes(b []byte, field int, data []byte) []byte {
+	b = binary.AppendUvarint(appendTag(b, field, wireBytes), uint64(len(data)))
+	return append(b, data...)
+}
+
+func appendString(b []byte, field int, s string) []byte {
+	if s == "" {
+		return b
+	}
+
+	return appendBytes(b, field, []byte(s))
+}
+
+// marshalResults encodes results as a ResultSet message.
+func marshalResults(results []Result) []byte {
+	var b []byte
+	for _, r := range results {
+		b = appendBytes(b, 1, marshalResult(r))
+	}
+
+	return b
+}
+
+func marshalResult(r Result) []byte {
+	var b []byte
+	b = appendString(b, 1, r.Name)
+	b = appendString(b, 2, r.Title)
+	for _, s := range r.Gantt {
+		b = appendBytes(b, 3, marshalTimeSlice(s))
+	}
+	for _, row := range r.Schedule {
+		var cells []byte
+		for _, cell := range row {
+			// every cell is written, even empty ones, so rows keep their width
+			cells = appendBytes(cells, 1, []byte(cell))
+		}
+		b = appendBytes(b, 4, cells)
+	}
+	b = appendDouble(b, 5, r.AveWait)
+	b = appendDouble(b, 6, r.AveTurnaround)
+	b = appendDouble(b, 7, r.AveThroughput)
+	b = appendDouble(b, 8, r.AveResponse)
+	b = appendInt(b, 9, int64(r.ContextSwitches))
+	b = appendInt(b, 10, r.IdleTime)
+	b = appendDouble(b, 11, r.Utilization)
+	for i, d := range []Distribution{r.WaitStats, r.TurnaroundStats, r.ResponseStats} {
+		if d != (Distribution{}) {
+			b = appendBytes(b, 12+i, marshalDistribution(d))
+		}
+	}
+	b = appendDouble(b, 15, r.Fairness)
+	for _, p := range r.Predictions {
+		var prediction []byte
+		prediction = appendInt(prediction, 1, p.PID)
+		prediction = appendDouble(prediction, 2, p.Predicted)
+		prediction = appendInt(prediction, 3, p.Actual)
+		b = appendBytes(b, 16, prediction)
+	}
+	for _, field := range r.Ignored {
+		b = appendString(b, 17, field)
+	}
+	b = appendInt(b, 18, int64(r.Unfinished))
+
+	return b
+}
+
+func marshalTimeSlice(s TimeSlice) []byte {
+	var b []byte
+	b = appendInt(b, 1, s.PID)
+	b = appendInt(b, 2, s.Start)
+	b = appendInt(b, 3, s.Stop)
+	for i, reason := range endReasons {
+		if reason == s.Reason {
+			b = appendInt(b, 4, int64(i))
+		}
+	}
+
+	return b
+}
+
+func marshalDistribution(d Distribution) []byte {
+	var b []byte
+	b = appendDouble(b, 1, d.Min)
+	b = appendDouble(b, 2, d.Max)
+	b = appendDouble(b, 3, d.Median)
+	b = appendDouble(b, 4, d.P95)
+	b = appendDouble(b, 5, d.StdDev)
+
+	return b
+}
+
+// protoField is one decoded field: v holds varint and fixed values, data length-delimited ones.
+type protoField struct {
+	num  int
+	wire int
+	v    uint64
+	data []byte
+}
+
+func (f protoField) double() float64 { return math.Float64frombits(f.v) }
+
+// eachField calls fn for every field of the message in b, in order. Fields fn does not know are
+// its to ignore, which keeps older readers working on newer archives.
+func eachField(b []byte, fn func(protoField) error) error {
+	for len(b) > 0 {
+		tag, n := binary.Uvarint(b)
+		if n <= 0 {
+			return fmt.Errorf("%w: bad tag", errMalformedProto)
+		}
+		b = b[n:]
+		f := protoField{num: int(tag >> 3), wire: int(tag & 7)}
+		switch f.wire {
+		case wireVarint:
+			if f.v, n = binary.Uvarint(b); n <= 0 {
+				return fmt.Errorf("%w: bad varint in field %d", errMalformedProto, f.num)
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return fmt.Errorf("%w: truncated field %d", errMalformedProto, f.num)
+			}
+			f.v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return fmt.Errorf("%w: truncated field %d", errMalformedProto, f.num)
+			}
+			f.v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case wireBytes:
+			size, n := binary.Uvarint(b)
+			if n <= 0 || size > uint64(len(b)-n) {
+				return fmt.Errorf("%w: truncated field %d", errMalformedProto, f.num)
+			}
+			f.data, b = b[n:n+int(size)], b[n+int(size):]
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d in field %d", errMalformedProto, f.wire, f.num)
+		}
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unmarshalResults decodes a ResultSet message.
+func unmarshalResults(b []byte) ([]Result, error) {
+	results := make([]Result, 0)
+	err := eachField(b, func(f protoField) error {
+		if f.num != 1 || f.wire != wireBytes {
+			return nil
+		}
+		r, err := unmarshalResult(f.data)
+		results = append(results, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func unmarshalResult(b []byte) (Result, error) {
+	var r Result
+	err := eachField(b, func(f protoField) error {
+		switch f.num {
+		case 1:
+			r.Name = string(f.data)
+		case 2:
+			r.Title = string(f.data)
+		case 3:
+			var s TimeSlice
+			err := eachField(f.data, func(f protoField) error {
+				switch f.num {
+				case 1:
+					s.PID = int64(f.v)
+				case 2:
+					s.Start = int64(f.v)
+				case 3:
+					s.Stop = int64(f.v)
+				case 4:
+					if f.v < uint64(len(endReasons)) {
+						s.Reason = endReasons[f.v]
+					}
+				}
+				return nil
+			})
+			r.Gantt = append(r.Gantt, s)
+			return err
+		case 4:
+			row := make([]string, 0, len(scheduleHeader))
+			err := eachField(f.data, func(f protoField) error {
+				if f.num == 1 {
+					row = append(row, string(f.data))
+				}
+				return nil
+			})
+			r.Schedule = append(r.Schedule, row)
+			return err
+		case 5:
+			r.AveWait = f.double()
+		case 6:
+			r.AveTurnaround = f.double()
+		case 7:
+			r.AveThroughput = f.double()
+		case 8:
+			r.AveResponse = f.double()
+		case 9:
+			r.ContextSwitches = int(int64(f.v))
+		case 10:
+			r.IdleTime = int64(f.v)
+		case 11:
+			r.Utilization = f.double()
+		case 12:
+			return unmarshalDistribution(f.data, &r.WaitStats)
+		case 13:
+			return unmarshalDistribution(f.data, &r.TurnaroundStats)
+		case 14:
+			return unmarshalDistribution(f.data, &r.ResponseStats)
+		case 15:
+			r.Fairness = f.double()
+		case 16:
+			if f.wire != wireBytes {
+				return nil
+			}
+			var p Prediction
+			err := eachField(f.data, func(f protoField) error {
+				switch f.num {
+				case 1:
+					p.PID = int64(f.v)
+				case 2:
+					p.Predicted = f.double()
+				case 3:
+					p.Actual = int64(f.v)
+				}
+				return nil
+			})
+			r.Predictions = append(r.Predictions, p)
+			return err
+		case 17:
+			if f.wire == wireBytes {
+				r.Ignored = append(r.Ignored, string(f.data))
+			}
+		case 18:
+			r.Unfinished = int(int64(f.v))
+		}
+		return nil
+	})
+
+	return r, err
+}
+
+// marshalWorkload encodes processes as a Workload message.
+func marshalWorkload(processes []Process) []byte {
+	var b []byte
+	for _, p := range processes {
+		var process []byte
+		process = appendInt(process, 1, p.ProcessID)
+		process = appendInt(process, 2, p.ArrivalTime)
+		process = appendInt(process, 3, p.BurstDuration)
+		process = appendInt(process, 4, p.Priority)
+		process = appendInt(process, 5, p.Deadline)
+		process = appendInt(process, 6, p.Memory)
+		process = appendInt(process, 7, p.Weight)
+		if len(p.Depends) > 0 {
+			var depends []byte
+			for _, d := range p.Depends {
+				depends = binary.AppendUvarint(depends, uint64(d))
+			}
+			process = appendBytes(process, 8, depends)
+		}
+		b = appendBytes(b, 1, process)
+	}
+
+	return b
+}
+
+// unmarshalWorkload decodes a Workload message.
+func unmarshalWorkload(b []byte) ([]Process, error) {
+	var processes []Process
+	err := eachField(b, func(f protoField) error {
+		if f.num != 1 || f.wire != wireBytes {
+			return nil
+		}
+		p, err := unmarshalProcess(f.data)
+		processes = append(processes, p)
+		return err
+	})
+
+	return processes, err
+}
+
+func unmarshalProcess(b []byte) (Process, error) {
+	var p Process
+	err := eachField(b, func(f protoField) error {
+		switch f.num {
+		case 1:
+			p.ProcessID = int64(f.v)
+		case 2:
+			p.ArrivalTime = int64(f.v)
+		case 3:
+			p.BurstDuration = int64(f.v)
+		case 4:
+			p.Priority = int64(f.v)
+		case 5:
+			p.Deadline = int64(f.v)
+		case 6:
+			p.Memory = int64(f.v)
+		case 7:
+			p.Weight = int64(f.v)
+		case 8:
+			if f.wire != wireBytes {
+				p.Depends = append(p.Depends, int64(f.v))
+				return nil
+			}
+			// proto3 packs repeated scalars, but readers must take both forms
+			for data := f.data; len(data) > 0; {
+				v, n := binary.Uvarint(data)
+				if n <= 0 {
+					return fmt.Errorf("%w: bad packed depends", errMalformedProto)
+				}
+				p.Depends, data = append(p.Depends, int64(v)), data[n:]
+			}
+		}
+		return nil
+	})
+
+	return p, err
+}
+
+func unmarshalDistribution(b []byte, d *Distribution) error {
+	return eachField(b, func(f protoField) error {
+		switch f.num {
+		case 1:
+			d.Min = f.double()
+		case 2:
+			d.Max = f.double()
+		case 3:
+			d.Median = f.double()
+		case 4:
+			d.P95 = f.double()
+		case 5:
+			d.StdDev = f.double()
+		}
+		return nil
+	})
+}
+
+// runConvert converts saved results between JSON ({"results": [...]}, as -format json writes
+// them) and the protobuf ResultSet of result.proto, by the input file's extension: .json becomes
+// protobuf, anything else is read as protobuf and becomes JSON. Either file may be gzipped by
+// ending its name in .gz.
+func runConvert(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("%w: usage: convert in.json out.pb, or convert in.pb out.json", ErrInvalidArgs)
+	}
+	results, err := loadResults(args[0])
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if isJSONResults(args[0]) {
+		out = marshalResults(results)
+	} else {
+		if out, err = json.Marshal(simulateResponse{Results: results}); err != nil {
+			return fmt.Errorf("%w: encoding JSON results", err)
+		}
+		out = append(out, '\n')
+	}
+	w, closeOut, err := createOutput(args[1], false)
+	if err != nil {
+		return fmt.Errorf("%w: writing results", err)
+	}
+	if _, err := w.Write(out); err != nil {
+		_ = closeOut()
+		return fmt.Errorf("%w: writing results", err)
+	}
+
+	return closeOut()
+}
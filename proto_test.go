@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_marshalResults_roundTrip(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3, Deadline: 20},
+	}
+	var results []Result
+	for _, a := range algorithms {
+		results = append(results, runAlgorithm(a, io.Discard, processes, Options{}))
+	}
+	b := marshalResults(results)
+	got, err := unmarshalResults(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, results) {
+		t.Errorf("unmarshalResults(marshalResults()) =\n%+v\nwant\n%+v", got, results)
+	}
+	js, err := json.Marshal(simulateResponse{Results: results})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b) >= len(js)/2 {
+		t.Errorf("protobuf is %d bytes against %d for JSON, want under half", len(b), len(js))
+	}
+}
+
+func Test_marshalResult_wire(t *testing.T) {
+	t.Parallel()
+	r := Result{Name: "rr", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2, Reason: endQuantum}}}
+	want := []byte{
+		0x0a, 0x02, 'r', 'r', // name
+		0x1a, 0x06, 0x08, 0x01, 0x18, 0x02, 0x20, 0x02, // gantt: pid 1, stop 2, reason QUANTUM; start 0 omitted
+	}
+	if got := marshalResult(r); !bytes.Equal(got, want) {
+		t.Errorf("marshalResult() = % x, want % x", got, want)
+	}
+}
+
+func Test_unmarshalResults_errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      []byte
+		want    []Result
+		wantErr error
+	}{
+		// field 16 as a varint rather than a Prediction, and field 17 (fixed32), are unknown and skipped
+		{name: "unknown fields", in: []byte{0x0a, 0x0c, 0x0a, 0x01, 'x', 0x80, 0x01, 0x05, 0x8d, 0x01, 0, 0, 0, 0}, want: []Result{{Name: "x"}}},
+		{name: "truncated", in: []byte{0x0a, 0x05, 0x0a}, wantErr: errMalformedProto},
+		{name: "bad wire type", in: []byte{0x0b}, wantErr: errMalformedProto},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := unmarshalResults(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unmarshalResults() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unmarshalResults() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runConvert(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	results := []Result{runAlgorithm(*findAlgorithm("fcfs"), io.Discard, []Process{{ProcessID: 1, BurstDuration: 3}}, Options{})}
+	js, err := json.Marshal(simulateResponse{Results: results})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, pb, out := filepath.Join(dir, "in.json"), filepath.Join(dir, "out.pb"), filepath.Join(dir, "back.json")
+	if err := os.WriteFile(in, js, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := runConvert([]string{in, pb}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runConvert([]string{pb, out}); err != nil {
+		t.Fatal(err)
+	}
+	back, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(back), js) {
+		t.Errorf("JSON after a protobuf round trip = %s, want %s", back, js)
+	}
+	if err := runConvert([]string{in}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("runConvert() with one file error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// quantumMetrics are the Result figures a quantum objective can minimize or constrain.
+var quantumMetrics = map[string]func(Result) float64{
+	"wait":       func(r Result) float64 { return r.AveWait },
+	"turnaround": func(r Result) float64 { return r.AveTurnaround },
+	"response":   func(r Result) float64 { return r.AveResponse },
+	"switches":   func(r Result) float64 { return float64(r.ContextSwitches) },
+}
+
+// quantumConstraint bounds one metric from above: below it when strict, else at most it.
+type quantumConstraint struct {
+	metric string
+	limit  float64
+	strict bool
+}
+
+func (c quantumConstraint) holds(r Result) bool {
+	v := quantumMetrics[c.metric](r)
+	if c.strict {
+		return v < c.limit
+	}
+
+	return v <= c.limit
+}
+
+func (c quantumConstraint) String() string {
+	op := "<="
+	if c.strict {
+		op = "<"
+	}
+
+	return fmt.Sprintf("%s %s %g", c.metric, op, c.limit)
+}
+
+// quantumObjective picks the quantum minimizing a metric among those meeting every constraint.
+type quantumObjective struct {
+	minimize    string
+	constraints []quantumConstraint
+}
+
+// parseQuantumObjective reads "metric[,metric<limit|metric<=limit...]", e.g. "response,switches<20".
+func parseQuantumObjective(spec string) (quantumObjective, error) {
+	terms := strings.Split(spec, ",")
+	objective := quantumObjective{minimize: strings.TrimSpace(terms[0])}
+	if _, ok := quantumMetrics[objective.minimize]; !ok {
+		return quantumObjective{}, fmt.Errorf("%w: -quantum-objective metric %q (want wait, turnaround, response or switches)", ErrInvalidArgs, objective.minimize)
+	}
+	for _, term := range terms[1:] {
+		metric, limit, ok := strings.Cut(term, "<")
+		if !ok {
+			return quantumObjective{}, fmt.Errorf("%w: -quantum-objective constraint %q must look like metric<limit", ErrInvalidArgs, term)
+		}
+		c := quantumConstraint{metric: strings.TrimSpace(metric), strict: true}
+		if rest, ok := strings.CutPrefix(limit, "="); ok {
+			limit, c.strict = rest, false
+		}
+		if _, ok := quantumMetrics[c.metric]; !ok {
+			return quantumObjective{}, fmt.Errorf("%w: -quantum-objective constraint on unknown metric %q", ErrInvalidArgs, c.metric)
+		}
+		var err error
+		if c.limit, err = strconv.ParseFloat(strings.TrimSpace(limit), 64); err != nil {
+			return quantumObjective{}, fmt.Errorf("%w: %w: -quantum-objective limit", ErrInvalidArgs, err)
+		}
+		objective.constraints = append(objective.constraints, c)
+	}
+
+	return objective, nil
+}
+
+// recommend returns the index of the best result, or -1 when none meets the constraints, with a
+// sentence explaining the choice. Ties go to the earlier quantum in the sweep.
+func (o quantumObjective) recommend(quanta []int64, results []Result) (int, string) {
+	best := -1
+	var excluded []string
+	for i, r := range results {
+		if failed := o.violated(r); failed != "" {
+			excluded = append(excluded, fmt.Sprintf("%d (%s)", quanta[i], failed))
+			continue
+		}
+		if best == -1 || quantumMetrics[o.minimize](r) < quantumMetrics[o.minimize](results[best]) {
+			best = i
+		}
+	}
+
+	var reason strings.Builder
+	if best == -1 {
+		_, _ = fmt.Fprintf(&reason, "no quantum meets the constraints; excluded %s", strings.Join(excluded, ", "))
+		return best, reason.String()
+	}
+	_, _ = fmt.Fprintf(&reason, "quantum %d has the lowest %s (%.2f) of the %d quanta",
+		quanta[best], o.minimize, quantumMetrics[o.minimize](results[best]), len(results)-len(excluded))
+	if len(o.constraints) > 0 {
+		limits := make([]string, len(o.constraints))
+		for i, c := range o.constraints {
+			limits[i] = c.String()
+		}
+		_, _ = fmt.Fprintf(&reason, " meeting %s", strings.Join(limits, " and "))
+	}
+	if len(excluded) > 0 {
+		_, _ = fmt.Fprintf(&reason, "; excluded %s", strings.Join(excluded, ", "))
+	}
+
+	return best, reason.String()
+}
+
+// violated describes the first constraint r breaks, or returns "" when it meets them all.
+func (o quantumObjective) violated(r Result) string {
+	for _, c := range o.constraints {
+		if !c.holds(r) {
+			return fmt.Sprintf("%s %g", c.metric, quantumMetrics[c.metric](r))
+		}
+	}
+
+	return ""
+}
+
+// outputQuantumSweep reruns each quantum-based algorithm once per quantum, tabulates the results
+// and recommends a quantum for the objective, explaining why. It stops at the first run that fails.
+func outputQuantumSweep(w io.Writer, processes []Process, selected []algorithm, opts Options, quanta []int64, objective quantumObjective) error {
+	_, _ = fmt.Fprintln(w, "Quantum sweep")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Quantum", "Avg wait", "Avg turnaround", "Avg response", "Switches"})
+	var advice []string
+	for _, a := range selected {
+		if !a.quantum {
+			continue
+		}
+		results := make([]Result, len(quanta))
+		for i, q := range quanta {
+			opts.Quantum = q
+			r, err := runAlgorithmChecked(a, io.Discard, processes, opts)
+			if err != nil {
+				return err
+			}
+			results[i] = r
+			table.Append([]string{
+				a.name,
+				fmt.Sprint(q),
+				fmt.Sprintf("%.2f", r.AveWait),
+				fmt.Sprintf("%.2f", r.AveTurnaround),
+				fmt.Sprintf("%.2f", r.AveResponse),
+				fmt.Sprint(r.ContextSwitches),
+			})
+		}
+		_, reason := objective.recommend(quanta, results)
+		advice = append(advice, fmt.Sprintf("recommendation: %s: %s", a.name, reason))
+	}
+	table.Render()
+	for _, line := range advice {
+		_, _ = fmt.Fprintln(w, line)
+	}
+
+	return nil
+}
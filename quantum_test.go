@@ -0,0 +1,167 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func Test_rrSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 20, BurstDuration: 1},
+	}
+	tests := []struct {
+		name     string
+		quantum  int64
+		rounding string
+		want     []TimeSlice
+	}{
+		{
+			name: "default quantum",
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 5},
+				{PID: 1, Start: 5, Stop: 7}, {PID: 2, Start: 7, Stop: 8}, {PID: 1, Start: 8, Stop: 9},
+				{PID: 4, Start: 20, Stop: 21},
+			},
+		},
+		{
+			name:     "full rounding holds the CPU for the rest of the quantum",
+			rounding: "full",
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 5},
+				{PID: 1, Start: 6, Stop: 8}, {PID: 2, Start: 8, Stop: 9}, {PID: 1, Start: 10, Stop: 11},
+				{PID: 4, Start: 20, Stop: 21},
+			},
+		},
+		{
+			name:    "quantum longer than every burst is fcfs",
+			quantum: 10,
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}, {PID: 3, Start: 8, Stop: 9},
+				{PID: 4, Start: 20, Stop: 21},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := rrSchedule(io.Discard, "", processes, Options{Quantum: tt.quantum, Rounding: tt.rounding})
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("rrSchedule() gantt = %v, want %v", got.Gantt, tt.want)
+			}
+			if err := checkGantt(processes, got.Gantt); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func Test_rrSchedule_tie(t *testing.T) {
+	t.Parallel()
+	// PID 2 arrives just as PID 1's first quantum expires
+	processes := []Process{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 1}}
+	tests := []struct {
+		name           string
+		preemptedFirst bool
+		want           []TimeSlice
+	}{
+		{name: "arrival first", want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 5}}},
+		{name: "preempted first", preemptedFirst: true, want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 5}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := rrSchedule(io.Discard, "", processes, Options{PreemptedFirst: tt.preemptedFirst})
+			if !reflect.DeepEqual(got.Gantt, tt.want) {
+				t.Errorf("rrSchedule() gantt = %v, want %v", got.Gantt, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseQuantumObjective(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    quantumObjective
+		wantErr error
+	}{
+		{name: "metric only", spec: "wait", want: quantumObjective{minimize: "wait"}},
+		{
+			name: "constraints",
+			spec: "response, switches<20,turnaround<=12.5",
+			want: quantumObjective{minimize: "response", constraints: []quantumConstraint{
+				{metric: "switches", limit: 20, strict: true},
+				{metric: "turnaround", limit: 12.5},
+			}},
+		},
+		{name: "unknown metric", spec: "fairness", wantErr: ErrInvalidArgs},
+		{name: "not a bound", spec: "response,switches>2", wantErr: ErrInvalidArgs},
+		{name: "bad limit", spec: "response,switches<few", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseQuantumObjective(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseQuantumObjective() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseQuantumObjective() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_quantumObjective_recommend(t *testing.T) {
+	t.Parallel()
+	quanta := []int64{1, 2, 4}
+	results := []Result{
+		{AveResponse: 1, ContextSwitches: 30},
+		{AveResponse: 2, ContextSwitches: 15},
+		{AveResponse: 3, ContextSwitches: 8},
+	}
+	tests := []struct {
+		name       string
+		spec       string
+		want       int
+		wantReason string
+	}{
+		{name: "unconstrained", spec: "response", want: 0, wantReason: "quantum 1 has the lowest response (1.00) of the 3 quanta"},
+		{
+			name:       "constrained",
+			spec:       "response,switches<20",
+			want:       1,
+			wantReason: "quantum 2 has the lowest response (2.00) of the 2 quanta meeting switches < 20; excluded 1 (switches 30)",
+		},
+		{
+			name:       "infeasible",
+			spec:       "response,switches<=5",
+			want:       -1,
+			wantReason: "no quantum meets the constraints; excluded 1 (switches 30), 2 (switches 15), 4 (switches 8)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			objective, err := parseQuantumObjective(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, reason := objective.recommend(quanta, results)
+			if got != tt.want || reason != tt.wantReason {
+				t.Errorf("recommend() = %d, %q, want %d, %q", got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
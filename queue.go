@@ -0,0 +1,89 @@
+package main
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// ringQueue is a FIFO of workload indexes in a ring buffer that grows by doubling, so pushing and
+// popping stay O(1) without the backing array creeping forward as a re-sliced queue does.
+type ringQueue struct {
+	items      []int
+	head, size int
+}
+
+func (q *ringQueue) len() int { return q.size }
+
+func (q *ringQueue) push(i int) {
+	if q.size == len(q.items) {
+		grown := make([]int, max(2*len(q.items), 8))
+		n := copy(grown, q.items[q.head:])
+		copy(grown[n:], q.items[:q.head])
+		q.items, q.head = grown, 0
+	}
+	q.items[(q.head+q.size)%len(q.items)] = i
+	q.size++
+}
+
+// peek returns the oldest index without removing it; the queue must not be empty.
+func (q *ringQueue) peek() int { return q.items[q.head] }
+
+// before is always false: a FIFO never lets a newcomer overtake.
+func (q *ringQueue) before(int, int) bool { return false }
+
+// pop removes and returns the oldest index; the queue must not be empty.
+func (q *ringQueue) pop() int {
+	i := q.items[q.head]
+	q.head = (q.head + 1) % len(q.items)
+	q.size--
+
+	return i
+}
+
+// readyHeap is a min-heap of workload indexes ordered by less, for the ready queues of the
+// shortest-job and priority schedulers. It implements container/heap.Interface.
+type readyHeap struct {
+	items []int
+	less  func(a, b int) bool
+}
+
+func (h *readyHeap) Len() int           { return len(h.items) }
+func (h *readyHeap) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *readyHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *readyHeap) Push(x any)         { h.items = append(h.items, x.(int)) }
+
+func (h *readyHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+
+	return last
+}
+
+// priorityQueue is a readySet over a readyHeap.
+type priorityQueue struct {
+	h readyHeap
+}
+
+func newPriorityQueue(less func(a, b int) bool) *priorityQueue {
+	return &priorityQueue{h: readyHeap{less: less}}
+}
+
+func (q *priorityQueue) len() int             { return q.h.Len() }
+func (q *priorityQueue) push(i int)           { heap.Push(&q.h, i) }
+func (q *priorityQueue) pop() int             { return heap.Pop(&q.h).(int) }
+func (q *priorityQueue) peek() int            { return q.h.items[0] }
+func (q *priorityQueue) before(a, b int) bool { return q.h.less(a, b) }
+
+// arrivalOrder returns the workload indexes of processes sorted by arrival time, ties in workload
+// order.
+func arrivalOrder(processes []Process) []int {
+	order := make([]int, len(processes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return processes[order[a]].ArrivalTime < processes[order[b]].ArrivalTime
+	})
+
+	return order
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"container/heap"
+	"reflect"
+	"testing"
+)
+
+func Test_ringQueue(t *testing.T) {
+	t.Parallel()
+	var q ringQueue
+	var got []int
+	// interleave so the ring wraps around before it has to grow
+	for i := 0; i < 20; i++ {
+		q.push(i)
+		q.push(100 + i)
+		got = append(got, q.pop())
+	}
+	for q.len() > 0 {
+		got = append(got, q.pop())
+	}
+
+	var want []int
+	for i := 0; i < 20; i++ {
+		want = append(want, i, 100+i)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ringQueue popped %v, want %v", got, want)
+	}
+}
+
+func Test_readyHeap(t *testing.T) {
+	t.Parallel()
+	bursts := []int64{5, 2, 9, 2, 1}
+	h := &readyHeap{less: func(a, b int) bool {
+		if bursts[a] != bursts[b] {
+			return bursts[a] < bursts[b]
+		}
+		return a < b
+	}}
+	for i := range bursts {
+		heap.Push(h, i)
+	}
+	var got []int
+	for h.Len() > 0 {
+		got = append(got, heap.Pop(h).(int))
+	}
+	if want := []int{4, 1, 3, 0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("readyHeap popped %v, want %v", got, want)
+	}
+}
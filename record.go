@@ -0,0 +1,187 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// marshalRecording encodes a run as a Recording message.
+func marshalRecording(processes []Process, results []Result) []byte {
+	b := appendBytes(nil, 1, marshalWorkload(processes))
+	for _, r := range results {
+		b = appendBytes(b, 2, marshalResult(r))
+	}
+
+	return b
+}
+
+func unmarshalRecording(b []byte) ([]Process, []Result, error) {
+	var processes []Process
+	results := make([]Result, 0)
+	err := eachField(b, func(f protoField) error {
+		if f.wire != wireBytes {
+			return nil
+		}
+		switch f.num {
+		case 1:
+			var err error
+			processes, err = unmarshalWorkload(f.data)
+			return err
+		case 2:
+			r, err := unmarshalResult(f.data)
+			results = append(results, r)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return processes, results, nil
+}
+
+// writeRecording saves the workload and results of a run for -record, gzipped if path ends in .gz.
+func writeRecording(path string, processes []Process, results []Result) error {
+	w, closeFn, err := createOutput(path, false)
+	if err != nil {
+		return fmt.Errorf("%w: creating recording", err)
+	}
+	if _, err := w.Write(marshalRecording(processes, results)); err != nil {
+		_ = closeFn()
+		return fmt.Errorf("%w: writing recording", err)
+	}
+
+	return closeFn()
+}
+
+func loadRecording(path string) ([]Process, []Result, error) {
+	r, closeIn, err := openInput(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: reading recording", err)
+	}
+	in, err := io.ReadAll(r)
+	_ = closeIn()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: reading recording", err)
+	}
+	processes, results, err := unmarshalRecording(in)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w: decoding recording %s", ErrInvalidArgs, err, path)
+	}
+
+	return processes, results, nil
+}
+
+// outputReport writes the text report of a recorded result as the algorithm wrote it when it ran.
+func outputReport(w io.Writer, r Result) {
+	outputTitle(w, r.Title)
+	outputGantt(w, r.Gantt)
+	outputSchedule(w, r.Schedule, r.AveWait, r.AveResponse, r.AveTurnaround, r.AveThroughput)
+}
+
+// runReplay is the replay subcommand: it renders a run saved with -record in any output format,
+// without scheduling anything again.
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	algo := fs.String("algo", "", "comma-separated `names` of the recorded algorithms to render (default all)")
+	format := fs.String("format", "text", "output `format`: text, json, proto or mermaid, as for a live run")
+	plain := fs.Bool("plain", false, "print labeled key: value lines instead of charts and tables")
+	quiet := fs.Bool("quiet", false, "print only the comparison, not each algorithm's report")
+	ganttSVG := fs.String("gantt-svg", "", "write an SVG Gantt chart per algorithm into `dir`")
+	htmlDir := fs.String("html", "", "write an HTML report per algorithm and an index.html into `dir`")
+	xlsxPath := fs.String("xlsx", "", "write an Excel workbook to `file`: a comparison sheet with charts, then a sheet per algorithm")
+	trace := fs.String("trace", "", "write every arrival, dispatch, preemption and completion as CSV to `file`")
+	chrome := fs.String("chrome-trace", "", "write every algorithm's timeline as Chrome trace event JSON to `file`")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: replay [-format text|json|proto|mermaid] [-plain] [-algo names] run.bin", ErrInvalidArgs)
+	}
+	if *format != "text" && *format != "json" && *format != "proto" && *format != "mermaid" {
+		return fmt.Errorf("%w: unknown -format %q", ErrInvalidArgs, *format)
+	}
+	processes, results, err := loadRecording(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *algo != "" {
+		var kept []Result
+		for _, name := range strings.Split(*algo, ",") {
+			found := false
+			for _, r := range results {
+				if r.Name == strings.TrimSpace(name) {
+					kept, found = append(kept, r), true
+				}
+			}
+			if !found {
+				return fmt.Errorf("%w: %s has no run of algorithm %q", ErrInvalidArgs, fs.Arg(0), name)
+			}
+		}
+		results = kept
+	}
+
+	switch {
+	case *format == "json":
+		if err := json.NewEncoder(os.Stdout).Encode(simulateResponse{Results: results}); err != nil {
+			return fmt.Errorf("%w: writing JSON results", err)
+		}
+	case *format == "mermaid":
+		if err := writeMermaid(os.Stdout, results); err != nil {
+			return err
+		}
+	case *format == "proto":
+		if _, err := os.Stdout.Write(marshalResults(results)); err != nil {
+			return fmt.Errorf("%w: writing protobuf results", err)
+		}
+	case *plain:
+		for _, r := range results {
+			if !*quiet {
+				outputPlain(os.Stdout, r)
+			}
+		}
+		outputPlainComparison(os.Stdout, results)
+	default:
+		for _, r := range results {
+			if !*quiet {
+				outputReport(os.Stdout, r)
+			}
+		}
+		outputComparison(os.Stdout, results)
+	}
+
+	if *ganttSVG != "" {
+		for _, r := range results {
+			if err := writeGanttSVG(*ganttSVG, r.Name, r.Title, r.Gantt); err != nil {
+				return err
+			}
+		}
+	}
+	if *htmlDir != "" {
+		if err := writeHTMLReports(*htmlDir, []htmlWorkload{{Name: fs.Arg(0), Results: results}}); err != nil {
+			return err
+		}
+	}
+	if *xlsxPath != "" {
+		if err := writeXLSX(*xlsxPath, results); err != nil {
+			return err
+		}
+	}
+	if *trace != "" {
+		if err := writeTrace(*trace, false, processes, results); err != nil {
+			return err
+		}
+	}
+	if *chrome != "" {
+		if err := writeChromeTrace(*chrome, processes, results); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_writeRecording_roundTrip(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2, Weight: 3},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1, Deadline: 20, Memory: 64},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3, Depends: []int64{1, 2}},
+	}
+	var results []Result
+	for _, a := range algorithms {
+		results = append(results, runAlgorithm(a, io.Discard, processes, Options{}))
+	}
+	for _, name := range []string{"run.bin", "run.bin.gz"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := writeRecording(path, processes, results); err != nil {
+			t.Fatal(err)
+		}
+		gotProcesses, gotResults, err := loadRecording(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(gotProcesses, processes) {
+			t.Errorf("%s: processes = %+v, want %+v", name, gotProcesses, processes)
+		}
+		if !reflect.DeepEqual(gotResults, results) {
+			t.Errorf("%s: results =\n%+v\nwant\n%+v", name, gotResults, results)
+		}
+	}
+}
+
+func Test_loadRecording_malformed(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "run.bin")
+	if err := os.WriteFile(path, []byte{0x0a, 0x05, 0x01}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loadRecording(path); !errors.Is(err, errMalformedProto) || !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("loadRecording() error = %v, want malformed protobuf and ErrInvalidArgs", err)
+	}
+}
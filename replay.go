@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type (
+	// replayer plays schedules back in scaled real time, one line of state per tick.
+	replayer struct {
+		w    io.Writer
+		tick time.Duration
+		// burn runs every process as a busy-looping goroutine that spins only while the schedule
+		// has it on the CPU.
+		burn bool
+		// sleep waits out a tick; tests replace it to replay without waiting.
+		sleep func(time.Duration)
+	}
+	// burner holds one spinning goroutine per process, gated so that only the running one burns
+	// CPU.
+	burner struct {
+		running atomic.Int64
+		wake    map[int64]chan struct{}
+		spun    map[int64]time.Duration
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+	}
+	// burnRow compares the CPU time a process was scheduled for with the time its goroutine spun.
+	burnRow struct {
+		PID       int64
+		Scheduled time.Duration
+		Burned    time.Duration
+	}
+)
+
+func newReplayer(w io.Writer, tick time.Duration, burn bool) replayer {
+	return replayer{w: w, tick: tick, burn: burn, sleep: time.Sleep}
+}
+
+// replay plays the schedule of result back at r.tick per tick, printing the running and ready
+// processes as each tick starts, and returns what each process burned when r.burn is set.
+func (r replayer) replay(processes []Process, result Result) []burnRow {
+	var b *burner
+	if r.burn {
+		// one P for the running process's goroutine and one for the replay itself, so the
+		// simulated CPU is a single real core whatever the machine has
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+		b = newBurner(processes)
+	}
+	var end int64
+	for _, s := range result.Gantt {
+		if s.Stop > end {
+			end = s.Stop
+		}
+	}
+	for t := int64(0); t < end; t++ {
+		running, ready := tickState(processes, result.Gantt, t)
+		state := "idle"
+		if running != 0 {
+			state = fmt.Sprintf("P%d", running)
+		}
+		pids := make([]string, 0, len(ready))
+		for _, pid := range ready {
+			pids = append(pids, fmt.Sprintf("P%d", pid))
+		}
+		_, _ = fmt.Fprintf(r.w, "%s t=%d running %s ready [%s]\n", result.Name, t, state, strings.Join(pids, " "))
+		if b != nil {
+			b.run(running)
+		}
+		r.sleep(r.tick)
+	}
+	_, _ = fmt.Fprintf(r.w, "%s t=%d done\n", result.Name, end)
+	if b == nil {
+		return nil
+	}
+	b.stop()
+
+	rows := make([]burnRow, 0, len(processes))
+	for _, p := range processes {
+		var ticks int64
+		for _, s := range result.Gantt {
+			if s.PID == p.ProcessID {
+				ticks += s.Stop - s.Start
+			}
+		}
+		rows = append(rows, burnRow{PID: p.ProcessID, Scheduled: time.Duration(ticks) * r.tick, Burned: b.spun[p.ProcessID]})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].PID < rows[j].PID })
+
+	return rows
+}
+
+// newBurner starts a parked goroutine per process, each locked to its own OS thread.
+func newBurner(processes []Process) *burner {
+	b := &burner{wake: make(map[int64]chan struct{}, len(processes)), spun: make(map[int64]time.Duration, len(processes))}
+	for _, p := range processes {
+		wake := make(chan struct{}, 1)
+		b.wake[p.ProcessID] = wake
+		b.wg.Add(1)
+		go b.work(p.ProcessID, wake)
+	}
+
+	return b
+}
+
+func (b *burner) work(pid int64, wake <-chan struct{}) {
+	defer b.wg.Done()
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	for range wake {
+		start := time.Now()
+		for b.running.Load() == pid {
+		}
+		b.mu.Lock()
+		b.spun[pid] += time.Since(start)
+		b.mu.Unlock()
+	}
+}
+
+// run lets pid spin, parking whichever process spun before; 0 parks them all.
+func (b *burner) run(pid int64) {
+	if b.running.Swap(pid) == pid || pid == 0 {
+		return
+	}
+	select {
+	case b.wake[pid] <- struct{}{}:
+	default:
+	}
+}
+
+// stop parks every goroutine and waits for them to exit.
+func (b *burner) stop() {
+	b.running.Store(0)
+	for _, wake := range b.wake {
+		close(wake)
+	}
+	b.wg.Wait()
+}
+
+func outputBurn(w io.Writer, name string, rows []burnRow) {
+	_, _ = fmt.Fprintf(w, "%s CPU burned\n", name)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Scheduled", "Burned"})
+	for _, row := range rows {
+		table.Append([]string{fmt.Sprint(row.PID), row.Scheduled.String(), row.Burned.Round(time.Millisecond).String()})
+	}
+	table.Render()
+}
+
+func outputPlainBurn(w io.Writer, name string, rows []burnRow) {
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(w, "burn: algorithm %s pid %d scheduled %s burned %s\n", name, row.PID, row.Scheduled, row.Burned.Round(time.Millisecond))
+	}
+}
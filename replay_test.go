@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_replayer_replay(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1},
+	}
+	result := Result{Name: "fcfs", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 3, Start: 5, Stop: 6}}}
+
+	var out bytes.Buffer
+	var slept time.Duration
+	r := newReplayer(&out, 100*time.Millisecond, false)
+	r.sleep = func(d time.Duration) { slept += d }
+	if rows := r.replay(processes, result); rows != nil {
+		t.Errorf("replay() without burn = %v, want nil", rows)
+	}
+	want := `fcfs t=0 running P1 ready []
+fcfs t=1 running P1 ready [P2]
+fcfs t=2 running P2 ready []
+fcfs t=3 running idle ready []
+fcfs t=4 running idle ready []
+fcfs t=5 running P3 ready []
+fcfs t=6 done
+`
+	if out.String() != want {
+		t.Errorf("replay() printed\n%s\nwant\n%s", out.String(), want)
+	}
+	if slept != 600*time.Millisecond {
+		t.Errorf("replay() slept %v, want 600ms", slept)
+	}
+
+	// burning for real: every process is scheduled for its burst and spins for some of it
+	rows := newReplayer(io.Discard, 5*time.Millisecond, true).replay(processes, result)
+	if len(rows) != len(processes) {
+		t.Fatalf("replay() with burn = %v, want a row per process", rows)
+	}
+	for i, row := range rows {
+		if row.PID != processes[i].ProcessID || row.Scheduled != time.Duration(processes[i].BurstDuration)*5*time.Millisecond {
+			t.Errorf("replay() with burn row %d = %+v, want P%d scheduled for its burst", i, row, processes[i].ProcessID)
+		}
+		if row.Burned <= 0 {
+			t.Errorf("replay() with burn: P%d never spun", row.PID)
+		}
+	}
+}
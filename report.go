@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// reportWriter returns where one algorithm's report goes: stdout when dir is empty, else
+// dir/name.txt, created along with dir as needed, and copied to stdout too when tee is set. The
+// returned close must be called once the report is written.
+func reportWriter(dir, name string, tee bool) (io.Writer, func() error, error) {
+	if dir == "" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, fmt.Errorf("%w: creating report directory", err)
+	}
+	f, err := os.Create(filepath.Join(dir, name+".txt"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: creating report file", err)
+	}
+	closeFn := func() error {
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("%w: closing report file", err)
+		}
+		return nil
+	}
+	if tee {
+		return io.MultiWriter(os.Stdout, textFile(f)), closeFn, nil
+	}
+
+	return textFile(f), closeFn, nil
+}
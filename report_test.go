@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_reportWriter(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "reports")
+	w, closeFn, err := reportWriter(dir, "fcfs", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = fmt.Fprint(w, "report")
+	if err := closeFn(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "fcfs.txt"))
+	if err != nil || string(got) != "report" {
+		t.Errorf("fcfs.txt = %q, %v, want %q", got, err, "report")
+	}
+
+	if w, _, err := reportWriter("", "fcfs", false); err != nil || w != os.Stdout {
+		t.Errorf("reportWriter() without a directory = %v, %v, want stdout", w, err)
+	}
+}
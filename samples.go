@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:embed web/examples/*.csv
+var sampleFiles embed.FS
+
+type (
+	// sampleWorkload describes one bundled example: what it shows and the algorithms and options
+	// that show it best.
+	sampleWorkload struct {
+		Name        string   `json:"name"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Algorithms  []string `json:"algorithms"`
+		Options     Options  `json:"options"`
+	}
+	// sampleResponse is a bundled example with its processes, which is also a simulateRequest
+	// a client can post back to /simulate unchanged.
+	sampleResponse struct {
+		sampleWorkload
+		Processes []Process `json:"processes"`
+	}
+	samplesResponse struct {
+		Samples []sampleWorkload `json:"samples"`
+	}
+)
+
+// sampleWorkloads lists the examples in web/examples, in menu order. Each file is <name>.csv.
+var sampleWorkloads = []sampleWorkload{
+	{
+		Name:        "staggered",
+		Title:       "Staggered arrivals",
+		Description: "Three processes arriving over time; the workload the web UI starts with.",
+		Algorithms:  []string{"fcfs", "sjf", "sjf-priority", "rr"},
+		Options:     Options{Quantum: defaultQuantum},
+	},
+	{
+		Name:        "convoy",
+		Title:       "Convoy effect",
+		Description: "A long CPU-bound job ahead of two short ones. FCFS makes them wait behind it; SJF and round-robin do not.",
+		Algorithms:  []string{"fcfs", "sjf", "rr"},
+		Options:     Options{Quantum: 4},
+	},
+	{
+		Name:        "priority",
+		Title:       "Priority scheduling",
+		Description: "Five processes arriving together with distinct priorities, 1 being the highest.",
+		Algorithms:  []string{"sjf-priority", "fcfs"},
+		Options:     Options{Quantum: defaultQuantum},
+	},
+	{
+		Name:        "classes",
+		Title:       "Foreground and background classes",
+		Description: "Interactive jobs of priority 1 and 2 mixed with long batch jobs, for the multilevel queue's inter-queue policies.",
+		Algorithms:  []string{"mlq", "rr", "fcfs"},
+		Options:     Options{Quantum: defaultQuantum, Foreground: 2, InterQueue: "sliced", ForegroundSlice: defaultForegroundSlice, BackgroundSlice: defaultBackgroundSlice},
+	},
+}
+
+// findSample returns the bundled example called name with its processes.
+func findSample(name string) (sampleResponse, error) {
+	for _, s := range sampleWorkloads {
+		if s.Name != name {
+			continue
+		}
+		data, err := sampleFiles.ReadFile("web/examples/" + name + ".csv")
+		if err != nil {
+			return sampleResponse{}, fmt.Errorf("%w: reading sample %q", err, name)
+		}
+		processes, err := loadProcesses(bytes.NewReader(data))
+		if err != nil {
+			return sampleResponse{}, fmt.Errorf("%w: sample %q", err, name)
+		}
+		return sampleResponse{sampleWorkload: s, Processes: processes}, nil
+	}
+
+	return sampleResponse{}, fmt.Errorf("%w: no sample named %q", ErrInvalidArgs, name)
+}
+
+// handleSamples answers GET /samples with the bundled examples' metadata and GET /samples/<name>
+// with one example and its processes.
+func handleSamples(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "use GET"})
+		return
+	}
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/samples"), "/")
+	if name == "" {
+		writeJSON(w, http.StatusOK, samplesResponse{Samples: sampleWorkloads})
+		return
+	}
+	sample, err := findSample(name)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, sample)
+}
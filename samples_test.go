@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_sampleWorkloads(t *testing.T) {
+	t.Parallel()
+	for _, s := range sampleWorkloads {
+		sample, err := findSample(s.Name)
+		if err != nil {
+			t.Errorf("findSample(%q) error = %v", s.Name, err)
+			continue
+		}
+		// every sample must run as the request it advertises
+		if _, err := simulate(context.Background(), simulateRequest{Processes: sample.Processes, Algorithms: s.Algorithms, Options: s.Options}, defaultSimulationLimits()); err != nil {
+			t.Errorf("sample %q does not simulate: %v", s.Name, err)
+		}
+	}
+}
+
+func Test_handleSamples(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "list", method: http.MethodGet, path: "/samples", wantStatus: http.StatusOK},
+		{name: "one", method: http.MethodGet, path: "/samples/convoy", wantStatus: http.StatusOK},
+		{name: "unknown", method: http.MethodGet, path: "/samples/nope", wantStatus: http.StatusNotFound},
+		{name: "wrong method", method: http.MethodPost, path: "/samples", wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+		})
+	}
+
+	// a sample posts back to /simulate as it is
+	rec := httptest.NewRecorder()
+	newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/samples/convoy", nil))
+	body := rec.Body.Bytes()
+	var sample sampleResponse
+	if err := json.Unmarshal(body, &sample); err != nil {
+		t.Fatal(err)
+	}
+	if len(sample.Processes) != 3 || sample.Options.Quantum != 4 {
+		t.Errorf("convoy sample = %+v, want 3 processes and quantum 4", sample)
+	}
+	rec = httptest.NewRecorder()
+	newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/simulate", bytes.NewReader(body)))
+	var resp simulateResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || rec.Code != http.StatusOK || len(resp.Results) != 3 {
+		t.Errorf("posting the convoy sample to /simulate = %d, %+v, %v, want 3 results", rec.Code, resp, err)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrInvalidSchedule is wrapped by the error of a scheduler, such as a plugin, whose schedule
+// fails checkGantt.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Scheduler is a scheduling policy that can be added to the built-in ones without changing
+// them: a file in this package registers it from an init function, after which it is selectable
+// with -algo, listed by -list-algos and included in every comparison.
+type Scheduler interface {
+	// Name is the short name used by -algo and the serve API; it must be unique.
+	Name() string
+	// Title heads the scheduler's report.
+	Title() string
+	// Schedule writes the report for processes to w and returns its result. The metrics derived
+	// from the gantt chart, such as response time and context switches, are filled in afterwards.
+	Schedule(w io.Writer, title string, processes []Process, opts Options) Result
+}
+
+// nonWorkConservingScheduler is implemented by schedulers that honor Options.NonWorkConserving
+// and Options.Lookahead, so they take part in the idle comparison and -lookahead-sweep.
+type nonWorkConservingScheduler interface {
+	Scheduler
+	NonWorkConserving() bool
+}
+
+// checkedScheduler is implemented by schedulers whose output cannot be trusted, such as plugins,
+// so callers that can report an error get one for an invalid schedule rather than its result.
+type checkedScheduler interface {
+	Scheduler
+	ScheduleChecked(w io.Writer, title string, processes []Process, opts Options) (Result, error)
+}
+
+// Register adds s after the algorithms already registered. It panics if the name is empty or
+// taken, as registration happens during init where there is no one to return an error to.
+func Register(s Scheduler) {
+	name := s.Name()
+	if name == "" {
+		panic("scheduler: Register with an empty name")
+	}
+	if findAlgorithm(name) != nil {
+		panic(fmt.Sprintf("scheduler: Register called twice for %q", name))
+	}
+	a := algorithm{name: name, title: s.Title(), schedule: s.Schedule}
+	if nwc, ok := s.(nonWorkConservingScheduler); ok {
+		a.nonWorkConserving = nwc.NonWorkConserving()
+	}
+	if checked, ok := s.(checkedScheduler); ok {
+		a.scheduleChecked = checked.ScheduleChecked
+	}
+	algorithms = append(algorithms, a)
+}
+
+// resultFromGantt reports a schedule that was decided elsewhere, as the slices each process ran
+// in, the way the built-in schedulers report theirs. gantt must be valid for processes (see
+// checkGantt), except that a run cut short may leave processes unfinished.
+func resultFromGantt(w io.Writer, title string, processes []Process, gantt []TimeSlice) Result {
+	times := processTimesFromGantt(processes, gantt)
+	_, last := sliceBounds(len(processes), gantt)
+	finished := finishedProcesses(processes, gantt)
+	schedule := make([][]string, len(processes))
+	var lastCompletion int64
+	var unfinished int
+	for i, p := range processes {
+		if !finished[i] {
+			unfinished++
+			schedule[i] = []string{fmt.Sprint(p.ProcessID), fmt.Sprint(p.Priority), fmt.Sprint(p.BurstDuration), fmt.Sprint(p.ArrivalTime), "-", "-", "-", "-"}
+			continue
+		}
+		exit := last[p.ProcessID-1]
+		lastCompletion = max(lastCompletion, exit)
+		schedule[i] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Priority),
+			fmt.Sprint(p.BurstDuration),
+			fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(times.wait[i]),
+			fmt.Sprint(times.response[i]),
+			fmt.Sprint(times.turnaround[i]),
+			fmt.Sprint(exit),
+		}
+	}
+
+	aveWait := mean(onlyFinished(times.wait, finished))
+	aveTurnaround := mean(onlyFinished(times.turnaround, finished))
+	var aveThroughput float64
+	if lastCompletion > 0 {
+		aveThroughput = float64(len(processes)-unfinished) / float64(lastCompletion)
+	}
+
+	outputTitle(w, title)
+	outputGantt(w, gantt)
+	if unfinished > 0 {
+		_, _ = fmt.Fprintf(w, "Cut short with %d of %d processes unfinished\n", unfinished, len(processes))
+	}
+	outputSchedule(w, schedule, aveWait, mean(onlyFinished(times.response, finished)), aveTurnaround, aveThroughput)
+
+	return Result{
+		Title:         title,
+		Gantt:         gantt,
+		Schedule:      schedule,
+		AveWait:       aveWait,
+		AveTurnaround: aveTurnaround,
+		AveThroughput: aveThroughput,
+		Unfinished:    unfinished,
+	}
+}
+
+// checkGantt reports the first way gantt fails to be a schedule of processes on one CPU: slices
+// out of time order or overlapping, unknown PIDs, work before arrival or before a predecessor
+// completes, or a process given more or less than its burst.
+func checkGantt(processes []Process, gantt []TimeSlice) error {
+	ran := make([]int64, len(processes))
+	var prevStop int64
+	for i, s := range gantt {
+		switch {
+		case s.PID < 1 || s.PID > int64(len(processes)):
+			return fmt.Errorf("slice %d: unknown PID %d", i+1, s.PID)
+		case s.Stop <= s.Start:
+			return fmt.Errorf("slice %d: PID %d stops at %d, not after its start %d", i+1, s.PID, s.Stop, s.Start)
+		case s.Start < prevStop:
+			return fmt.Errorf("slice %d: PID %d starts at %d, before the previous slice stops at %d", i+1, s.PID, s.Start, prevStop)
+		}
+		prevStop = s.Stop
+		ran[s.PID-1] += s.Stop - s.Start
+	}
+	for _, p := range processes {
+		if ran[p.ProcessID-1] != p.BurstDuration {
+			return fmt.Errorf("PID %d ran %d ticks, want its burst of %d", p.ProcessID, ran[p.ProcessID-1], p.BurstDuration)
+		}
+	}
+	first, last := sliceBounds(len(processes), gantt)
+	for _, p := range processes {
+		if first[p.ProcessID-1] < p.ArrivalTime {
+			return fmt.Errorf("PID %d runs at %d, before it arrives at %d", p.ProcessID, first[p.ProcessID-1], p.ArrivalTime)
+		}
+		for _, pid := range p.Depends {
+			if first[p.ProcessID-1] < last[pid-1] {
+				return fmt.Errorf("PID %d runs at %d, before PID %d it depends on completes at %d", p.ProcessID, first[p.ProcessID-1], pid, last[pid-1])
+			}
+		}
+	}
+
+	return nil
+}
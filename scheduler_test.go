@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// lifoScheduler runs the latest arrival first, to exercise registration.
+type lifoScheduler struct{}
+
+func (lifoScheduler) Name() string  { return "lifo" }
+func (lifoScheduler) Title() string { return "Last-come, first-serve" }
+
+func (lifoScheduler) Schedule(w io.Writer, title string, processes []Process, opts Options) Result {
+	policy, _ := parseChain("lifo")
+	return policy.schedule(w, title, processes, opts)
+}
+
+// Test_Register is not parallel because it changes the registry; parallel tests only resume
+// once it has restored it.
+func Test_Register(t *testing.T) {
+	builtin := algorithms
+	t.Cleanup(func() { algorithms = builtin })
+
+	Register(lifoScheduler{})
+	selected, err := selectAlgorithms([]string{"lifo", "fcfs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+	}
+	got := runAlgorithm(selected[0], io.Discard, processes, Options{})
+	if got.Name != "lifo" || got.Title != "Last-come, first-serve" {
+		t.Errorf("runAlgorithm() name, title = %q, %q", got.Name, got.Title)
+	}
+	if got.Gantt[1].PID != 3 || got.ContextSwitches != 2 {
+		t.Errorf("runAlgorithm() gantt = %v, switches %d; want PID 3 second", got.Gantt, got.ContextSwitches)
+	}
+	if all, _ := selectAlgorithms(nil); all[len(all)-1].name != "lifo" {
+		t.Errorf("registered scheduler missing from the default selection")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() of a duplicate name did not panic")
+		}
+	}()
+	Register(lifoScheduler{})
+}
+
+func Test_checkGantt(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	tests := []struct {
+		name    string
+		gantt   []TimeSlice
+		wantErr bool
+	}{
+		{name: "valid", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 5, Stop: 6}}},
+		{name: "unknown pid", gantt: []TimeSlice{{PID: 3, Start: 0, Stop: 5}}, wantErr: true},
+		{name: "overlap", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 1, Stop: 4}}, wantErr: true},
+		{name: "before arrival", gantt: []TimeSlice{{PID: 2, Start: 0, Stop: 3}, {PID: 1, Start: 3, Stop: 5}}, wantErr: true},
+		{name: "short burst", gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := checkGantt(processes, tt.gantt); (err != nil) != tt.wantErr {
+				t.Errorf("checkGantt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_resultFromGantt_unfinished(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}
+	// cut short one tick into process 2
+	got := resultFromGantt(io.Discard, "Cut", processes, []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}})
+	if got.Unfinished != 1 {
+		t.Errorf("resultFromGantt() Unfinished = %d, want 1", got.Unfinished)
+	}
+	if want := []string{"2", "0", "3", "1", "-", "-", "-", "-"}; !reflect.DeepEqual(got.Schedule[1], want) {
+		t.Errorf("resultFromGantt() row of the unfinished process = %v, want %v", got.Schedule[1], want)
+	}
+	if got.AveTurnaround != 2 || got.AveThroughput != 0.5 {
+		t.Errorf("resultFromGantt() turnaround %v, throughput %v, want 2 and 0.5 from process 1 alone", got.AveTurnaround, got.AveThroughput)
+	}
+}
+
+func Test_pluginScheduler_Schedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	// runs the workload back to back in order, which is FCFS for this workload
+	s := &pluginScheduler{name: "fake", title: "Fake", schedule: func(in [][4]int64) [][3]int64 {
+		var out [][3]int64
+		var clock int64
+		for _, p := range in {
+			clock = max(clock, p[1])
+			out = append(out, [3]int64{p[0], clock, clock + p[2]})
+			clock += p[2]
+		}
+		return out
+	}}
+	got := s.Schedule(io.Discard, "Fake", processes, Options{})
+	want := FCFSSchedule(io.Discard, "Fake", processes)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Schedule() = %+v, want %+v", got, want)
+	}
+
+	s.schedule = func([][4]int64) [][3]int64 { return [][3]int64{{1, 0, 5}} }
+	if _, err := s.ScheduleChecked(io.Discard, "Fake", processes, Options{}); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("ScheduleChecked() of an incomplete schedule error = %v, want %v", err, ErrInvalidSchedule)
+	}
+}
+
+func Test_pluginScheduler_overlapping(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3},
+	}
+	// a buggy plugin running both processes at once
+	s := &pluginScheduler{path: "overlap.so", name: "overlap", title: "Overlap", schedule: func([][4]int64) [][3]int64 {
+		return [][3]int64{{1, 0, 4}, {2, 2, 5}}
+	}}
+	a := algorithm{name: s.Name(), title: s.Title(), schedule: s.Schedule, scheduleChecked: s.ScheduleChecked}
+
+	_, err := runAlgorithmChecked(a, io.Discard, processes, Options{})
+	if !errors.Is(err, ErrInvalidSchedule) || !strings.Contains(err.Error(), "overlap.so") {
+		t.Errorf("runAlgorithmChecked() error = %v, want %v naming the plugin", err, ErrInvalidSchedule)
+	}
+	if code := newJSONError(err).Code; code != "invalid_schedule" {
+		t.Errorf("newJSONError() code = %q, want invalid_schedule", code)
+	}
+	runs := runConcurrently([]algorithm{a}, processes, Options{}, newResourceGuard(0, 0), 1, true, nil)
+	if !errors.Is(runs[0].err, ErrInvalidSchedule) {
+		t.Errorf("runConcurrently() error = %v, want %v", runs[0].err, ErrInvalidSchedule)
+	}
+
+	// callers that cannot take an error get every process unfinished rather than a panic
+	var w bytes.Buffer
+	if got := runAlgorithm(a, &w, processes, Options{}); got.Unfinished != len(processes) || !strings.Contains(w.String(), "invalid schedule") {
+		t.Errorf("runAlgorithm() left %d unfinished and wrote %q, want %d and the error", got.Unfinished, w.String(), len(processes))
+	}
+}
+
+func Test_loadPlugin_missing(t *testing.T) {
+	t.Parallel()
+	if _, err := loadPlugin(filepath.Join(t.TempDir(), "missing.so")); err == nil {
+		t.Error("loadPlugin() of a missing file succeeded")
+	}
+}
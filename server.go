@@ -0,0 +1,268 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+const maxRequestBytes = 1 << 20
+
+// ErrRequestTooLarge is wrapped by the error of a hosted request whose workload is over the
+// server's limits.
+var ErrRequestTooLarge = errors.New("request too large")
+
+// simulationLimits bound the work of one hosted request, so no single POST or RPC can hold a core
+// or the heap: a workload's total burst bounds how many events a run simulates, and the slice
+// count how large its results grow. Zero disables a limit.
+type simulationLimits struct {
+	timeout    time.Duration
+	memLimit   uint64
+	totalBurst int64
+	slices     int
+}
+
+// defaultSimulationLimits are the limits serve applies unless its flags say otherwise.
+func defaultSimulationLimits() simulationLimits {
+	return simulationLimits{timeout: 10 * time.Second, memLimit: 1 << 30, totalBurst: 1_000_000, slices: 2_000_000}
+}
+
+//go:embed web/index.html
+var indexHTML []byte
+
+type (
+	simulateRequest struct {
+		Processes  []Process `json:"processes"`
+		Algorithms []string  `json:"algorithms"` // run in this order; empty runs every algorithm
+		Options    Options   `json:"options"`
+		// StrictFeatures rejects processes using fields that a selected algorithm ignores.
+		StrictFeatures bool `json:"strictFeatures,omitempty"`
+	}
+	simulateResponse struct {
+		Results []Result `json:"results"`
+	}
+	// ganttChart is one algorithm's laid-out schedule in a /gantt response.
+	ganttChart struct {
+		Name  string     `json:"name"`
+		Gantt GanttModel `json:"gantt"`
+	}
+	ganttResponse struct {
+		Charts []ganttChart `json:"charts"`
+	}
+	errorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// runServe hosts the simulator over HTTP: a web UI at / and a JSON API at POST /simulate, with
+// POST /gantt returning the laid-out charts (see GanttModel) for clients that draw their own and
+// GET /samples listing bundled example workloads. GET /metrics reports usage to Prometheus, and
+// the Simulator gRPC service of simulator.proto is served on the same port over HTTP/2.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "listen `address`")
+	limits := defaultSimulationLimits()
+	fs.DurationVar(&limits.timeout, "timeout", limits.timeout, "wall-clock `limit` of each simulation request")
+	memLimit := fs.Uint64("mem-limit", limits.memLimit>>20, "heap `MiB` past which a simulation request is stopped")
+	fs.Int64Var(&limits.totalBurst, "max-burst", limits.totalBurst, "largest total `burst` of a request's workload")
+	fs.IntVar(&limits.slices, "max-slices", limits.slices, "most gantt `slices` a request's results may hold")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	limits.memLimit = *memLimit << 20
+
+	// gRPC clients speak HTTP/2 without TLS, so accept it next to HTTP/1
+	srv := &http.Server{Addr: *addr, Handler: newServeMux(limits), Protocols: new(http.Protocols)}
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetUnencryptedHTTP2(true)
+	log.Printf("serving on %s", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		return fmt.Errorf("%w: serving HTTP", err)
+	}
+
+	return nil
+}
+
+// newServeMux routes serve's endpoints, running simulations within limits.
+func newServeMux(limits simulationLimits) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", handleIndex)
+	mux.HandleFunc("/simulate", handleSimulate(limits))
+	mux.HandleFunc("/gantt", handleGantt(limits))
+	mux.HandleFunc("/samples", handleSamples)
+	mux.HandleFunc("/samples/", handleSamples)
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/scheduler.Simulator/", handleGRPC(limits))
+
+	return mux
+}
+
+func handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(indexHTML)
+}
+
+func handleSimulate(limits simulationLimits) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		results, ok := simulateHTTP(w, r, limits)
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, simulateResponse{Results: results})
+	}
+}
+
+func handleGantt(limits simulationLimits) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		results, ok := simulateHTTP(w, r, limits)
+		if !ok {
+			return
+		}
+		response := ganttResponse{Charts: make([]ganttChart, len(results))}
+		for i, result := range results {
+			response.Charts[i] = ganttChart{Name: result.Name, Gantt: NewGanttModel(result.Gantt)}
+		}
+		writeJSON(w, http.StatusOK, response)
+	}
+}
+
+// simulateHTTP decodes a simulateRequest from r and runs it within limits, answering w with the
+// error and returning false when that fails.
+func simulateHTTP(w http.ResponseWriter, r *http.Request, limits simulationLimits) ([]Result, bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "use POST"})
+		return nil, false
+	}
+
+	var req simulateRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: fmt.Sprintf("decoding request: %v", err)})
+		return nil, false
+	}
+	results, err := simulate(r.Context(), req, limits)
+	if r.Context().Err() != nil {
+		// the client is gone, so there is no one to answer
+		return nil, false
+	}
+	if err != nil {
+		metrics.reject()
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, ErrRequestTooLarge):
+			status = http.StatusRequestEntityTooLarge
+		case errors.Is(err, ErrResourceLimit), errors.Is(err, ErrInvalidSchedule):
+			status = http.StatusUnprocessableEntity
+		}
+		writeJSON(w, status, errorResponse{Error: err.Error()})
+		return nil, false
+	}
+	metrics.observe(req.Processes, results)
+
+	return results, true
+}
+
+// simulate runs the requested algorithms over the request's processes, giving up once ctx is done.
+// A workload over limits is rejected with ErrRequestTooLarge before it runs, and a run that goes
+// over them is stopped with ErrResourceLimit. The slice limit is a budget shared by all the
+// algorithms, drawn on as the engine schedules (see sliceBudget).
+func simulate(ctx context.Context, req simulateRequest, limits simulationLimits) ([]Result, error) {
+	if err := validateProcesses(req.Processes); err != nil {
+		return nil, err
+	}
+	if limits.totalBurst > 0 {
+		var total int64
+		for _, p := range req.Processes {
+			// compared before adding, as bursts near the int64 limit would overflow the sum
+			if p.BurstDuration > limits.totalBurst-total {
+				return nil, fmt.Errorf("%w: the workload's total burst is over the limit of %d", ErrRequestTooLarge, limits.totalBurst)
+			}
+			total += p.BurstDuration
+		}
+	}
+	if _, ok := sliceRoundings[req.Options.Rounding]; !ok && req.Options.Rounding != "" {
+		return nil, fmt.Errorf("%w: unknown rounding %q", ErrInvalidArgs, req.Options.Rounding)
+	}
+	if err := checkInterQueue(req.Options.InterQueue); err != nil {
+		return nil, err
+	}
+	if err := checkPrediction(req.Options.Alpha, req.Options.InitialGuess); err != nil {
+		return nil, err
+	}
+	selected, err := selectAlgorithms(req.Algorithms)
+	if err != nil {
+		return nil, err
+	}
+	if req.StrictFeatures {
+		if err := checkFeatures(selected, req.Processes); err != nil {
+			return nil, err
+		}
+	}
+
+	if limits.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.timeout)
+		defer cancel()
+	}
+	guard := newResourceGuard(limits.timeout, limits.memLimit)
+	var budget *sliceBudget
+	if limits.slices > 0 {
+		budget = newSliceBudget(limits.slices)
+	}
+	results := make([]Result, 0, len(selected))
+	var slices int
+	for _, a := range selected {
+		var scheduleErr error
+		result, err := guard.run(func(guardCtx context.Context) Result {
+			// stopped by whichever of the request and the guard is done first
+			runCtx, cancel := context.WithCancel(guardCtx)
+			defer cancel()
+			defer context.AfterFunc(ctx, cancel)()
+			if budget != nil {
+				runCtx = withSliceBudget(runCtx, budget)
+			}
+			result, err := runAlgorithmChecked(a, io.Discard, req.Processes, req.Options.WithContext(runCtx))
+			scheduleErr = err
+			return result
+		})
+		if err != nil {
+			return nil, err
+		}
+		// set only once the run is over, so only read when the guard did not cut it short
+		if scheduleErr != nil {
+			return nil, scheduleErr
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: simulation ran past the server's %v limit", ErrResourceLimit, limits.timeout)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%w: simulation abandoned", err)
+		}
+		// schedulers outside the engine do not draw on the budget, so count their slices too
+		if slices += len(result.Gantt); budget != nil && (budget.spent() || slices > limits.slices) {
+			return nil, fmt.Errorf("%w: the schedules are over the limit of %d slices", ErrResourceLimit, limits.slices)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("%v: writing response", err)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_handleSimulate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantNames  []string
+	}{
+		{
+			name:       "selected algorithms",
+			method:     http.MethodPost,
+			body:       `{"processes":[{"pid":1,"burst":5,"arrival":0,"priority":2},{"pid":2,"burst":9,"arrival":3,"priority":1}],"algorithms":["sjf","fcfs"]}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"sjf", "fcfs"},
+		},
+		{
+			name:       "all algorithms",
+			method:     http.MethodPost,
+			body:       `{"processes":[{"pid":1,"burst":5,"arrival":0,"priority":2}]}`,
+			wantStatus: http.StatusOK,
+			wantNames:  []string{"fcfs", "sjf", "sjf-predicted", "sjf-priority", "rr", "mlq", "wrr", "drr"},
+		},
+		{
+			name:       "ignored feature under strictFeatures",
+			method:     http.MethodPost,
+			body:       `{"processes":[{"pid":1,"burst":5,"arrival":0,"deadline":9}],"algorithms":["fcfs"],"strictFeatures":true}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown algorithm",
+			method:     http.MethodPost,
+			body:       `{"processes":[{"pid":1,"burst":5,"arrival":0}],"algorithms":["lottery"]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty workload",
+			method:     http.MethodPost,
+			body:       `{"processes":[]}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "total burst over the limit",
+			method:     http.MethodPost,
+			body:       `{"processes":[{"pid":1,"burst":1000000000000000000,"arrival":0},{"pid":2,"burst":9000000000000000000,"arrival":0}],"algorithms":["rr"],"options":{"quantum":1}}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "malformed JSON",
+			method:     http.MethodPost,
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "wrong method",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(tt.method, "/simulate", strings.NewReader(tt.body)))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp simulateResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Results) != len(tt.wantNames) {
+				t.Fatalf("got %d results, want %d", len(resp.Results), len(tt.wantNames))
+			}
+			for i, name := range tt.wantNames {
+				if resp.Results[i].Name != name {
+					t.Errorf("result %d = %v, want %v", i, resp.Results[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func Test_handleGantt(t *testing.T) {
+	t.Parallel()
+	body := `{"processes":[{"pid":1,"burst":2,"arrival":0},{"pid":2,"burst":1,"arrival":4}],"algorithms":["fcfs"]}`
+	rec := httptest.NewRecorder()
+	newServeMux(defaultSimulationLimits()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/gantt", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var got ganttResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Charts) != 1 || got.Charts[0].Name != "fcfs" || len(got.Charts[0].Gantt.Bars) != 3 || !got.Charts[0].Gantt.Bars[1].Idle {
+		t.Errorf("charts = %+v, want fcfs with bars P1, idle, P2", got.Charts)
+	}
+}
+
+func Test_simulate_limits(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 50}, {ProcessID: 2, BurstDuration: 50}}
+	tests := []struct {
+		name    string
+		limits  simulationLimits
+		wantErr error
+	}{
+		{name: "within limits", limits: simulationLimits{timeout: time.Minute, totalBurst: 100, slices: 100}},
+		{name: "total burst", limits: simulationLimits{totalBurst: 99}, wantErr: ErrRequestTooLarge},
+		{name: "slices", limits: simulationLimits{slices: 99}, wantErr: ErrResourceLimit},
+		{name: "timeout", limits: simulationLimits{timeout: time.Nanosecond}, wantErr: ErrResourceLimit},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// rr with quantum 1 alternates the processes every tick, for 100 slices
+			req := simulateRequest{Processes: processes, Algorithms: []string{"rr"}, Options: Options{Quantum: 1}}
+			results, err := simulate(context.Background(), req, tt.limits)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("simulate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && len(results[0].Gantt) != 100 {
+				t.Errorf("simulate() rr has %d slices, want 100", len(results[0].Gantt))
+			}
+		})
+	}
+}
+
+func Test_simulate_sliceBudgetShared(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 50}, {ProcessID: 2, BurstDuration: 50}}
+	// rr with quantum 1 takes 100 slices and fcfs 2, so each fits alone but not both together
+	req := simulateRequest{Processes: processes, Algorithms: []string{"rr", "fcfs"}, Options: Options{Quantum: 1}}
+	if _, err := simulate(context.Background(), req, simulationLimits{slices: 101}); !errors.Is(err, ErrResourceLimit) {
+		t.Errorf("simulate() error = %v, want %v", err, ErrResourceLimit)
+	}
+	if _, err := simulate(context.Background(), req, simulationLimits{slices: 102}); err != nil {
+		t.Errorf("simulate() error = %v, want none", err)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+// The SJF and SJF-priority schedulers were rewritten to pick among arrived processes on every
+// decision instead of walking a pre-sorted queue. was records what the replaced implementations
+// produced for the same workload (nil where they never finished) so the change stays reviewable.
+func TestSJFSchedulers_tiesAndLateArrivals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		schedule  func(io.Writer, string, []Process) Result
+		processes []Process
+		was       []TimeSlice
+		want      []TimeSlice
+	}{
+		{
+			name:     "sjf equal bursts",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+			},
+			was:  []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 0, Stop: 6}, {PID: 3, Start: 0, Stop: 9}},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 6}, {PID: 3, Start: 6, Stop: 9}},
+		},
+		{
+			name:     "sjf-priority equal bursts",
+			schedule: SJFPrioritySchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 2, Start: 0, Stop: 3}, {PID: 3, Start: 3, Stop: 6}, {PID: 1, Start: 6, Stop: 9}},
+		},
+		{
+			name:     "sjf equal bursts and priorities",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+			},
+			was:  []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 0, Stop: 4}},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+		},
+		{
+			name:     "sjf-priority equal bursts and priorities",
+			schedule: SJFPrioritySchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+		},
+		{
+			name:     "sjf nothing arrived at zero",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 1, Priority: 2},
+			},
+			want: []TimeSlice{{PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 5, Stop: 6}},
+		},
+		{
+			name:     "sjf-priority nothing arrived at zero",
+			schedule: SJFPrioritySchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 1, Priority: 2},
+			},
+			was:  []TimeSlice{{PID: 0, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 0, Start: 3, Stop: 4}},
+			want: []TimeSlice{{PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 5, Stop: 6}},
+		},
+		{
+			name:     "sjf idle gap",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 2, Priority: 1},
+			},
+			was:  []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 5, Stop: 7}},
+		},
+		{
+			name:     "sjf-priority idle gap",
+			schedule: SJFPrioritySchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 2, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 5, Stop: 7}},
+		},
+		{
+			name:     "sjf shorter job arrives late",
+			schedule: SJFSchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 5}},
+		},
+		{
+			name:     "sjf-priority higher priority arrives late",
+			schedule: SJFPrioritySchedule,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+			},
+			want: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 5}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.schedule(io.Discard, tt.name, tt.processes).Gantt
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("schedule() = %+v, want %+v (before the rewrite: %+v)", got, tt.want, tt.was)
+			}
+		})
+	}
+}
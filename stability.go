@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// stabilitySeed fixes the perturbations so stability reports are reproducible.
+const stabilitySeed = 1
+
+// stability summarizes how far an algorithm's dispatch order moves under perturbed inputs.
+type stability struct {
+	runs int
+	mean float64 // mean edit distance from the unperturbed dispatch order
+	max  int
+	// normalized is mean divided by the unperturbed order's length, so workloads of different
+	// sizes compare.
+	normalized float64
+}
+
+// dispatchOrder is the sequence of PIDs the CPU switches to, one entry per dispatch.
+func dispatchOrder(gantt []TimeSlice) []int64 {
+	order := make([]int64, 0, len(gantt))
+	for i, s := range gantt {
+		if i == 0 || s.PID != gantt[i-1].PID {
+			order = append(order, s.PID)
+		}
+	}
+
+	return order
+}
+
+// editDistance is the Levenshtein distance between two dispatch orders: the insertions, deletions
+// and substitutions needed to turn a into b.
+func editDistance(a, b []int64) int {
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+// perturb shifts every burst and arrival by a uniform amount in [-jitter, jitter], keeping bursts
+// at least 1 and arrivals at least 0.
+func perturb(rng *rand.Rand, processes []Process, jitter int64) []Process {
+	perturbed := make([]Process, len(processes))
+	for i, p := range processes {
+		p.BurstDuration = max(1, p.BurstDuration+rng.Int63n(2*jitter+1)-jitter)
+		p.ArrivalTime = max(0, p.ArrivalTime+rng.Int63n(2*jitter+1)-jitter)
+		perturbed[i] = p
+	}
+
+	return perturbed
+}
+
+// measureStability reruns a over runs perturbed copies of processes and compares each dispatch
+// order against the unperturbed one.
+func measureStability(a algorithm, processes []Process, opts Options, runs int, jitter int64, rng *rand.Rand) (stability, error) {
+	r, err := runAlgorithmChecked(a, io.Discard, processes, opts)
+	if err != nil {
+		return stability{}, err
+	}
+	base := dispatchOrder(r.Gantt)
+	s := stability{runs: runs}
+	var total int
+	for i := 0; i < runs; i++ {
+		r, err := runAlgorithmChecked(a, io.Discard, perturb(rng, processes, jitter), opts)
+		if err != nil {
+			return stability{}, fmt.Errorf("%w: perturbed run %d", err, i+1)
+		}
+		d := editDistance(base, dispatchOrder(r.Gantt))
+		total += d
+		s.max = max(s.max, d)
+	}
+	if runs > 0 {
+		s.mean = float64(total) / float64(runs)
+		s.normalized = s.mean / float64(len(base))
+	}
+
+	return s, nil
+}
+
+// stabilities measures every selected algorithm against the same sequence of perturbations.
+func stabilities(processes []Process, selected []algorithm, opts Options, runs int, jitter int64) ([]stability, error) {
+	all := make([]stability, len(selected))
+	for i, a := range selected {
+		s, err := measureStability(a, processes, opts, runs, jitter, rand.New(rand.NewSource(stabilitySeed)))
+		if err != nil {
+			return nil, err
+		}
+		all[i] = s
+	}
+
+	return all, nil
+}
+
+// outputStability prints how much each algorithm's dispatch order changes when every burst and
+// arrival is jittered by up to jitter ticks; lower is more robust.
+func outputStability(w io.Writer, processes []Process, selected []algorithm, opts Options, runs int, jitter int64) error {
+	all, err := stabilities(processes, selected, opts, runs, jitter)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprint(w, consoleText(fmt.Sprintf("Stability (%d runs, jitter ±%d)\n", runs, jitter)))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Mean edit distance", "Max edit distance", "Normalized"})
+	for i, s := range all {
+		table.Append([]string{
+			selected[i].name,
+			fmt.Sprintf("%.2f", s.mean),
+			fmt.Sprint(s.max),
+			fmt.Sprintf("%.3f", s.normalized),
+		})
+	}
+	table.Render()
+
+	return nil
+}
+
+// outputPlainStability is the -plain form of outputStability.
+func outputPlainStability(w io.Writer, processes []Process, selected []algorithm, opts Options, runs int, jitter int64) error {
+	all, err := stabilities(processes, selected, opts, runs, jitter)
+	if err != nil {
+		return err
+	}
+	for i, s := range all {
+		_, _ = fmt.Fprintf(w, "stability: %s, runs %d, jitter %d, mean edit distance %.2f, max edit distance %d, normalized %.3f\n",
+			selected[i].name, s.runs, jitter, s.mean, s.max, s.normalized)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_editDistance(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		a, b []int64
+		want int
+	}{
+		{name: "equal", a: []int64{1, 2, 3}, b: []int64{1, 2, 3}},
+		{name: "swap", a: []int64{1, 2, 3}, b: []int64{2, 1, 3}, want: 2},
+		{name: "insert", a: []int64{1, 2}, b: []int64{1, 3, 2}, want: 1},
+		{name: "empty", a: nil, b: []int64{1, 2}, want: 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dispatchOrder(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 4}, {PID: 1, Start: 4, Stop: 5}}
+	if got, want := dispatchOrder(gantt), []int64{1, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dispatchOrder() = %v, want %v", got, want)
+	}
+}
+
+func Test_measureStability(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 1},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3},
+	}
+	for _, p := range perturb(rand.New(rand.NewSource(1)), processes, 5) {
+		if p.BurstDuration < 1 || p.ArrivalTime < 0 {
+			t.Fatalf("perturb() produced %+v", p)
+		}
+	}
+	// fcfs keeps workload order whatever the timing; sjf reorders the equal bursts
+	fcfs, err := measureStability(*findAlgorithm("fcfs"), processes, Options{}, 10, 1, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fcfs.mean != 0 || fcfs.max != 0 {
+		t.Errorf("fcfs stability = %+v, want no movement", fcfs)
+	}
+	sjf, err := measureStability(*findAlgorithm("sjf"), processes, Options{}, 10, 1, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sjf.max == 0 || sjf.normalized != sjf.mean/3 {
+		t.Errorf("sjf stability = %+v, want some movement normalized by 3 dispatches", sjf)
+	}
+}
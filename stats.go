@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Distribution summarizes one per-process metric across a workload.
+type Distribution struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Median float64 `json:"median"`
+	P95    float64 `json:"p95"`
+	StdDev float64 `json:"stddev"`
+}
+
+// processTimes holds per-process metrics in workload order.
+type processTimes struct {
+	wait       []float64
+	turnaround []float64
+	response   []float64
+}
+
+// processTimesFromGantt measures every process against the slices it was given: completion is
+// the end of its last slice and response the start of its first. It gathers both into arrays
+// indexed like processes (IDs are 1..n once validated) and then derives each metric in one pass
+// over those arrays, so the per-slice loop does no float work.
+func processTimesFromGantt(processes []Process, gantt []TimeSlice) processTimes {
+	n := len(processes)
+	first, last := sliceBounds(n, gantt)
+
+	times := processTimes{
+		wait:       make([]float64, n),
+		turnaround: make([]float64, n),
+		response:   make([]float64, n),
+	}
+	for i, p := range processes {
+		turnaround := last[p.ProcessID-1] - p.ArrivalTime
+		times.turnaround[i] = float64(turnaround)
+		times.wait[i] = float64(turnaround - p.BurstDuration)
+		times.response[i] = float64(first[p.ProcessID-1] - p.ArrivalTime)
+	}
+
+	return times
+}
+
+// finishedProcesses reports, indexed like processes, whether gantt runs each for its whole burst,
+// which only a schedule cut short fails to do.
+func finishedProcesses(processes []Process, gantt []TimeSlice) []bool {
+	ran := make([]int64, len(processes))
+	for _, s := range gantt {
+		ran[s.PID-1] += s.Stop - s.Start
+	}
+	finished := make([]bool, len(processes))
+	for i, p := range processes {
+		finished[i] = ran[p.ProcessID-1] >= p.BurstDuration
+	}
+
+	return finished
+}
+
+// onlyFinished keeps the values, indexed like processes, of the processes that finished.
+func onlyFinished(values []float64, finished []bool) []float64 {
+	kept := make([]float64, 0, len(values))
+	for i, v := range values {
+		if finished[i] {
+			kept = append(kept, v)
+		}
+	}
+
+	return kept
+}
+
+// sliceBounds returns, indexed by PID-1, the start of each process's first slice and the stop of
+// its last.
+func sliceBounds(n int, gantt []TimeSlice) (first, last []int64) {
+	first, last = make([]int64, n), make([]int64, n)
+	seen := make([]bool, n)
+	for _, s := range gantt {
+		i := s.PID - 1
+		if !seen[i] || s.Start < first[i] {
+			first[i], seen[i] = s.Start, true
+		}
+		if s.Stop > last[i] {
+			last[i] = s.Stop
+		}
+	}
+
+	return first, last
+}
+
+// mean is the arithmetic mean of values, or 0 when there are none.
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+// cpuShares returns, indexed like processes, the share of its time in the system each process spent
+// on the CPU: its burst over its turnaround.
+func cpuShares(processes []Process, turnaround []float64) []float64 {
+	shares := make([]float64, len(processes))
+	for i, p := range processes {
+		if turnaround[i] > 0 {
+			shares[i] = float64(p.BurstDuration) / turnaround[i]
+		}
+	}
+
+	return shares
+}
+
+// jainIndex is Jain's fairness index of values, (Σx)² / (n·Σx²): 1 when they are all equal, down
+// to 1/n when one value holds everything. It is 0 when there are no values, and 1 when they are
+// all zero, as those are equal too.
+func jainIndex(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum, squares float64
+	for _, v := range values {
+		sum += v
+		squares += v * v
+	}
+	if squares == 0 {
+		return 1
+	}
+
+	return sum * sum / (float64(len(values)) * squares)
+}
+
+// describe computes the distribution of values. The 95th percentile uses the nearest-rank
+// method and the standard deviation is the population one, since the workload is the whole
+// population rather than a sample.
+func describe(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(n)
+	var squares float64
+	for _, v := range sorted {
+		squares += (v - mean) * (v - mean)
+	}
+
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	return Distribution{
+		Min:    sorted[0],
+		Max:    sorted[n-1],
+		Median: median,
+		P95:    sorted[int(math.Ceil(0.95*float64(n)))-1],
+		StdDev: math.Sqrt(squares / float64(n)),
+	}
+}
+
+// distributionRows flattens each result's distributions into labeled rows.
+func distributionRows(r Result) []struct {
+	metric string
+	dist   Distribution
+} {
+	return []struct {
+		metric string
+		dist   Distribution
+	}{
+		{"wait", r.WaitStats},
+		{"turnaround", r.TurnaroundStats},
+		{"response", r.ResponseStats},
+	}
+}
+
+// outputDistributions prints min/max/median/p95/stddev of each metric per algorithm.
+func outputDistributions(w io.Writer, results []Result) {
+	_, _ = fmt.Fprintln(w, "Distribution")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Metric", "Min", "Max", "Median", "P95", "StdDev"})
+	for _, r := range results {
+		for _, row := range distributionRows(r) {
+			table.Append([]string{
+				r.Name,
+				row.metric,
+				fmt.Sprintf("%.2f", row.dist.Min),
+				fmt.Sprintf("%.2f", row.dist.Max),
+				fmt.Sprintf("%.2f", row.dist.Median),
+				fmt.Sprintf("%.2f", row.dist.P95),
+				fmt.Sprintf("%.2f", row.dist.StdDev),
+			})
+		}
+	}
+	table.Render()
+}
+
+// outputPlainDistributions is the -plain form of outputDistributions.
+func outputPlainDistributions(w io.Writer, results []Result) {
+	for _, r := range results {
+		for _, row := range distributionRows(r) {
+			_, _ = fmt.Fprintf(w, "distribution: %s %s, min %.2f, max %.2f, median %.2f, p95 %.2f, stddev %.2f\n",
+				r.Name, row.metric, row.dist.Min, row.dist.Max, row.dist.Median, row.dist.P95, row.dist.StdDev)
+		}
+	}
+}
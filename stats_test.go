@@ -0,0 +1,128 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_describe(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []float64
+		want   Distribution
+	}{
+		{name: "empty"},
+		{
+			name:   "single",
+			values: []float64{4},
+			want:   Distribution{Min: 4, Max: 4, Median: 4, P95: 4},
+		},
+		{
+			name:   "even count",
+			values: []float64{8, 2, 4, 6},
+			want:   Distribution{Min: 2, Max: 8, Median: 5, P95: 8, StdDev: 2.23606797749979},
+		},
+		{
+			name:   "tail",
+			values: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 100},
+			want:   Distribution{Min: 1, Max: 100, Median: 1, P95: 1, StdDev: 21.576549770526334},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := describe(tt.values); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("describe() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_processTimesFromGantt(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+	}
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 13}, {PID: 1, Start: 13, Stop: 16}}
+	want := processTimes{
+		wait:       []float64{11, 1},
+		turnaround: []float64{16, 10},
+		response:   []float64{0, 1},
+	}
+	if got := processTimesFromGantt(processes, gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("processTimesFromGantt() = %+v, want %+v", got, want)
+	}
+}
+
+// benchmarkWorkload is a 1M-process FCFS-style schedule: process i runs for 3 ticks as soon as
+// the previous one finishes.
+func benchmarkWorkload() ([]Process, []TimeSlice) {
+	const n = 1_000_000
+	processes := make([]Process, n)
+	gantt := make([]TimeSlice, n)
+	for i := range processes {
+		processes[i] = Process{ProcessID: int64(i + 1), ArrivalTime: int64(i), BurstDuration: 3}
+		gantt[i] = TimeSlice{PID: int64(i + 1), Start: int64(3 * i), Stop: int64(3*i + 3)}
+	}
+
+	return processes, gantt
+}
+
+func Benchmark_processTimesFromGantt(b *testing.B) {
+	processes, gantt := benchmarkWorkload()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		processTimesFromGantt(processes, gantt)
+	}
+}
+
+func Benchmark_deriveMetrics(b *testing.B) {
+	processes, gantt := benchmarkWorkload()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		deriveMetrics(&Result{Gantt: append([]TimeSlice(nil), gantt...)}, processes)
+	}
+}
+
+func Test_jainIndex(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{name: "equal shares", values: []float64{0.5, 0.5, 0.5, 0.5}, want: 1},
+		{name: "one takes everything", values: []float64{1, 0, 0, 0}, want: 0.25},
+		{name: "two of four", values: []float64{1, 1, 0, 0}, want: 0.5},
+		{name: "uneven", values: []float64{1, 2, 3}, want: 36.0 / 42},
+		{name: "all zero", values: []float64{0, 0}, want: 1},
+		{name: "empty", want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := jainIndex(tt.values); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("jainIndex(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_deriveMetrics_fairness(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+	}
+	// back to back, process 1 runs its whole time in the system and process 2 half of it
+	result := Result{Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}}}
+	deriveMetrics(&result, processes)
+	if want := 1.5 * 1.5 / (2 * 1.25); math.Abs(result.Fairness-want) > 1e-12 {
+		t.Errorf("deriveMetrics() fairness = %v, want %v", result.Fairness, want)
+	}
+}
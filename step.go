@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// stepper walks a schedule for -step, printing the state at each tick, or at each event with
+// byEvent, and waiting for a line on in before moving on.
+type stepper struct {
+	in      *bufio.Reader
+	w       io.Writer
+	byEvent bool
+}
+
+func newStepper(in io.Reader, w io.Writer, byEvent bool) stepper {
+	return stepper{in: bufio.NewReader(in), w: w, byEvent: byEvent}
+}
+
+// stepTimes are the instants a step stops at: every tick up to the end of the schedule, or with
+// byEvent only those where a process arrives or a slice starts or stops.
+func stepTimes(processes []Process, gantt []TimeSlice, byEvent bool) []int64 {
+	var end int64
+	for _, s := range gantt {
+		end = max(end, s.Stop)
+	}
+	var times []int64
+	if !byEvent {
+		for t := int64(0); t <= end; t++ {
+			times = append(times, t)
+		}
+		return times
+	}
+	seen := make(map[int64]bool)
+	add := func(t int64) {
+		if !seen[t] && t <= end {
+			seen[t] = true
+			times = append(times, t)
+		}
+	}
+	for _, p := range processes {
+		add(p.ArrivalTime)
+	}
+	for _, s := range gantt {
+		add(s.Start)
+		add(s.Stop)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	return times
+}
+
+// stepEvents describes what happens at t: the slice that stops and why, the processes that
+// arrive, and the dispatch that follows.
+func stepEvents(processes []Process, gantt []TimeSlice, t int64) []string {
+	var next int64
+	for _, s := range gantt {
+		if s.Start == t {
+			next = s.PID
+		}
+	}
+	var events []string
+	for _, s := range gantt {
+		if s.Stop != t {
+			continue
+		}
+		switch {
+		case s.Reason == endCompletion:
+			events = append(events, fmt.Sprintf("P%d completes", s.PID))
+		case s.Reason == endArrival && next != 0:
+			events = append(events, fmt.Sprintf("P%d is preempted for P%d", s.PID, next))
+		case s.Reason == endQuantum:
+			events = append(events, fmt.Sprintf("P%d's quantum expires", s.PID))
+		default:
+			events = append(events, fmt.Sprintf("P%d stops", s.PID))
+		}
+	}
+	for _, p := range processes {
+		if p.ArrivalTime == t {
+			events = append(events, fmt.Sprintf("P%d arrives", p.ProcessID))
+		}
+	}
+	if next != 0 {
+		events = append(events, fmt.Sprintf("dispatch P%d", next))
+	}
+
+	return events
+}
+
+// step walks result's schedule, printing the running and ready processes and what just happened
+// at every stop. It returns false once the user enters q, or in runs out, to stop stepping.
+func (s stepper) step(processes []Process, result Result) bool {
+	times := stepTimes(processes, result.Gantt, s.byEvent)
+	for i, t := range times {
+		running, ready := tickState(processes, result.Gantt, t)
+		state := "idle"
+		if running != 0 {
+			state = fmt.Sprintf("P%d", running)
+		}
+		pids := make([]string, 0, len(ready))
+		for _, pid := range ready {
+			pids = append(pids, fmt.Sprintf("P%d", pid))
+		}
+		if i < len(times)-1 {
+			_, _ = fmt.Fprintf(s.w, "%s t=%d running %s ready [%s]", result.Name, t, state, strings.Join(pids, " "))
+		} else {
+			_, _ = fmt.Fprintf(s.w, "%s t=%d done", result.Name, t)
+		}
+		if events := stepEvents(processes, result.Gantt, t); len(events) > 0 {
+			_, _ = fmt.Fprintf(s.w, ": %s", strings.Join(events, ", "))
+		}
+		_, _ = fmt.Fprintln(s.w)
+		if i == len(times)-1 {
+			break
+		}
+		_, _ = fmt.Fprint(s.w, "[enter: step, q: quit] ")
+		line, err := s.in.ReadString('\n')
+		if err != nil || strings.TrimSpace(line) == "q" {
+			return false
+		}
+	}
+
+	return true
+}
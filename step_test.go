@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_stepTimes(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1}}
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4}}
+	tests := []struct {
+		name    string
+		byEvent bool
+		want    []int64
+	}{
+		{name: "tick", want: []int64{0, 1, 2, 3, 4}},
+		{name: "event", byEvent: true, want: []int64{0, 1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := stepTimes(processes, gantt, tt.byEvent); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("stepTimes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	long := []TimeSlice{{PID: 1, Start: 0, Stop: 10}}
+	if got, want := stepTimes(processes[:1], long, true), []int64{0, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("stepTimes() by event = %v, want %v", got, want)
+	}
+}
+
+func Test_stepEvents(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 1}}
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2, Reason: endArrival},
+		{PID: 2, Start: 2, Stop: 3, Reason: endCompletion},
+		{PID: 1, Start: 3, Stop: 6, Reason: endCompletion},
+	}
+	tests := []struct {
+		t    int64
+		want []string
+	}{
+		{t: 0, want: []string{"P1 arrives", "dispatch P1"}},
+		{t: 2, want: []string{"P1 is preempted for P2", "P2 arrives", "dispatch P2"}},
+		{t: 3, want: []string{"P2 completes", "dispatch P1"}},
+		{t: 4, want: nil},
+	}
+	for _, tt := range tests {
+		if got := stepEvents(processes, gantt, tt.t); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("stepEvents(%d) = %q, want %q", tt.t, got, tt.want)
+		}
+	}
+}
+
+func Test_stepper_step(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}
+	result := Result{Name: "fcfs", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2, Reason: endCompletion}, {PID: 2, Start: 2, Stop: 3, Reason: endCompletion}}}
+
+	var out strings.Builder
+	if !newStepper(strings.NewReader("\n\n\n"), &out, false).step(processes, result) {
+		t.Fatal("step() quit before the schedule ended")
+	}
+	want := "fcfs t=0 running P1 ready [P2]: P1 arrives, P2 arrives, dispatch P1\n[enter: step, q: quit] " +
+		"fcfs t=1 running P1 ready [P2]\n[enter: step, q: quit] " +
+		"fcfs t=2 running P2 ready []: P1 completes, dispatch P2\n[enter: step, q: quit] " +
+		"fcfs t=3 done: P2 completes\n"
+	if out.String() != want {
+		t.Errorf("step() output =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if newStepper(strings.NewReader("q\n"), &out, true).step(processes, result) {
+		t.Error("step() went on after q")
+	}
+	if strings.Count(out.String(), "fcfs t=") != 1 {
+		t.Errorf("step() printed past q:\n%s", out.String())
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	svgChartWidth = 800 // width of the time axis in pixels
+	svgBarHeight  = 40
+	svgMargin     = 20
+)
+
+// writeGanttSVG writes the gantt chart of one algorithm to dir/name.svg, creating dir as needed.
+func writeGanttSVG(dir, name, title string, gantt []TimeSlice) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%w: creating SVG directory", err)
+	}
+	f, err := os.Create(filepath.Join(dir, name+".svg"))
+	if err != nil {
+		return fmt.Errorf("%w: creating SVG file", err)
+	}
+	outputGanttSVG(f, title, gantt)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing SVG file", err)
+	}
+
+	return nil
+}
+
+// outputGanttSVG renders a gantt chart as SVG where each bar's width is proportional to its duration.
+func outputGanttSVG(w io.Writer, title string, gantt []TimeSlice) {
+	var start, stop int64
+	if len(gantt) > 0 {
+		start, stop = gantt[0].Start, gantt[len(gantt)-1].Stop
+	}
+	scale := float64(svgChartWidth)
+	if stop > start {
+		scale /= float64(stop - start)
+	}
+	x := func(t int64) float64 { return svgMargin + float64(t-start)*scale }
+	barY := 2 * svgMargin
+
+	_, _ = fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="12">`+"\n",
+		svgChartWidth+2*svgMargin, barY+svgBarHeight+2*svgMargin)
+	_, _ = fmt.Fprintf(w, `<text x="%d" y="%d" font-size="14">%s</text>`+"\n", svgMargin, svgMargin, html.EscapeString(title))
+	for i := range gantt {
+		left, right := x(gantt[i].Start), x(gantt[i].Stop)
+		_, _ = fmt.Fprintf(w, `<rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" stroke="#333"/>`+"\n",
+			left, barY, right-left, svgBarHeight, pidColor(gantt[i].PID))
+		_, _ = fmt.Fprintf(w, `<text x="%.2f" y="%d" text-anchor="middle">%d</text>`+"\n",
+			(left+right)/2, barY+svgBarHeight/2+4, gantt[i].PID)
+		_, _ = fmt.Fprintf(w, `<text x="%.2f" y="%d" text-anchor="middle">%d</text>`+"\n",
+			left, barY+svgBarHeight+svgMargin, gantt[i].Start)
+	}
+	if len(gantt) > 0 {
+		_, _ = fmt.Fprintf(w, `<text x="%.2f" y="%d" text-anchor="middle">%d</text>`+"\n",
+			x(stop), barY+svgBarHeight+svgMargin, stop)
+	}
+	_, _ = fmt.Fprintln(w, "</svg>")
+}
+
+// pidColor returns a stable fill color for a process, spacing hues by the golden angle so
+// neighbouring PIDs stay distinguishable.
+func pidColor(pid int64) string {
+	return fmt.Sprintf("hsl(%d, 65%%, 60%%)", pidHue(pid))
+}
+
+func pidHue(pid int64) int64 {
+	return pid * 137 % 360
+}
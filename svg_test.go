@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_outputGanttSVG(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		gantt     []TimeSlice
+		wantParts []string
+	}{
+		{
+			name: "proportional widths",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2},
+				{PID: 2, Start: 2, Stop: 10},
+			},
+			wantParts: []string{
+				`<rect x="20.00" y="40" width="160.00" height="40" fill="hsl(137, 65%, 60%)" stroke="#333"/>`,
+				`<rect x="180.00" y="40" width="640.00" height="40" fill="hsl(274, 65%, 60%)" stroke="#333"/>`,
+				`<text x="820.00" y="100" text-anchor="middle">10</text>`,
+			},
+		},
+		{
+			name:      "empty",
+			wantParts: []string{"<svg", "</svg>"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGanttSVG(&w, "<title>", tt.gantt)
+			got := w.String()
+			if !strings.Contains(got, "&lt;title&gt;") {
+				t.Errorf("outputGanttSVG() title not escaped: %v", got)
+			}
+			for _, part := range tt.wantParts {
+				if !strings.Contains(got, part) {
+					t.Errorf("outputGanttSVG() = %v, want to contain %v", got, part)
+				}
+			}
+		})
+	}
+}
+
+func Test_writeGanttSVG(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "charts")
+	if err := writeGanttSVG(dir, "fcfs", "FCFS", []TimeSlice{{PID: 1, Start: 0, Stop: 5}}); err != nil {
+		t.Fatalf("writeGanttSVG() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "fcfs.svg")); err != nil {
+		t.Fatalf("writeGanttSVG() did not create file: %v", err)
+	}
+}
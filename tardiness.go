@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// tardiness summarizes how late one schedule finishes the processes that have deadlines.
+type tardiness struct {
+	jobs     int   // processes with a deadline
+	tardy    int   // of those, processes completing after it
+	total    int64 // sum of completion - deadline over tardy processes
+	maximum  int64
+	fraction float64 // tardy / jobs, or 0 without deadlines
+}
+
+// hasDeadlines reports whether any process carries a deadline.
+func hasDeadlines(processes []Process) bool {
+	for _, p := range processes {
+		if p.Deadline != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// measureTardiness compares each process's completion in gantt against its deadline.
+func measureTardiness(processes []Process, gantt []TimeSlice) tardiness {
+	_, last := sliceBounds(len(processes), gantt)
+	var t tardiness
+	for _, p := range processes {
+		if p.Deadline == 0 {
+			continue
+		}
+		t.jobs++
+		if late := last[p.ProcessID-1] - p.Deadline; late > 0 {
+			t.tardy++
+			t.total += late
+			t.maximum = max(t.maximum, late)
+		}
+	}
+	if t.jobs > 0 {
+		t.fraction = float64(t.tardy) / float64(t.jobs)
+	}
+
+	return t
+}
+
+// outputTardiness prints each algorithm's soft real-time tardiness over the processes with deadlines.
+func outputTardiness(w io.Writer, processes []Process, results []Result) {
+	_, _ = fmt.Fprintln(w, "Tardiness")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Deadlines", "Tardy", "Tardy fraction", "Total tardiness", "Max tardiness"})
+	for _, r := range results {
+		t := measureTardiness(processes, r.Gantt)
+		table.Append([]string{
+			r.Name,
+			fmt.Sprint(t.jobs),
+			fmt.Sprint(t.tardy),
+			fmt.Sprintf("%.1f%%", t.fraction*100),
+			fmt.Sprint(t.total),
+			fmt.Sprint(t.maximum),
+		})
+	}
+	table.Render()
+}
+
+// outputPlainTardiness is the -plain form of outputTardiness.
+func outputPlainTardiness(w io.Writer, processes []Process, results []Result) {
+	for _, r := range results {
+		t := measureTardiness(processes, r.Gantt)
+		_, _ = fmt.Fprintf(w, "tardiness: %s, deadlines %d, tardy %d, tardy fraction %.1f%%, total tardiness %d, max tardiness %d\n",
+			r.Name, t.jobs, t.tardy, t.fraction*100, t.total, t.maximum)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_measureTardiness(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Deadline: 5},
+		{ProcessID: 2, BurstDuration: 3, Deadline: 6},
+		{ProcessID: 3, BurstDuration: 2},
+		{ProcessID: 4, BurstDuration: 1, Deadline: 8},
+	}
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  tardiness
+	}{
+		{
+			name:  "in order",
+			gantt: []TimeSlice{{PID: 1, Stop: 4}, {PID: 2, Start: 4, Stop: 7}, {PID: 3, Start: 7, Stop: 9}, {PID: 4, Start: 9, Stop: 10}},
+			want:  tardiness{jobs: 3, tardy: 2, total: 3, maximum: 2, fraction: 2.0 / 3},
+		},
+		{
+			name:  "deadline jobs first",
+			gantt: []TimeSlice{{PID: 1, Stop: 4}, {PID: 4, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 8}, {PID: 3, Start: 8, Stop: 10}},
+			want:  tardiness{jobs: 3, tardy: 1, total: 2, maximum: 2, fraction: 1.0 / 3},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := measureTardiness(processes, tt.gantt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("measureTardiness() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
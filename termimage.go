@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+type graphicsProtocol int
+
+const (
+	graphicsNone graphicsProtocol = iota
+	graphicsSixel
+	graphicsKitty
+)
+
+const (
+	termImageWidth  = 800 // width of the time axis in pixels
+	termImageHeight = 36
+	kittyChunkSize  = 4096 // max base64 payload per kitty graphics escape
+)
+
+// detectGraphicsProtocol reports which inline image protocol the terminal behind f understands.
+// Anything that isn't a terminal, such as a pipe or regular file, gets graphicsNone.
+func detectGraphicsProtocol(f *os.File) graphicsProtocol {
+	fi, err := f.Stat()
+	if err != nil || fi.Mode()&os.ModeCharDevice == 0 || legacyConsole {
+		return graphicsNone
+	}
+
+	return graphicsProtocolFor(os.Getenv("TERM"), os.Getenv("TERM_PROGRAM"), os.Getenv("KITTY_WINDOW_ID"))
+}
+
+// graphicsProtocolFor maps terminal environment variables to a protocol. Querying the terminal
+// would be more precise, but needs raw mode and a read timeout; the environment is good enough
+// for the terminals that support images today.
+func graphicsProtocolFor(term, termProgram, kittyWindowID string) graphicsProtocol {
+	switch {
+	case kittyWindowID != "", strings.Contains(term, "kitty"), termProgram == "WezTerm", termProgram == "ghostty":
+		return graphicsKitty
+	case strings.Contains(term, "sixel"), term == "mlterm", strings.HasPrefix(term, "foot"),
+		strings.HasPrefix(term, "yaft"), termProgram == "iTerm.app":
+		return graphicsSixel
+	}
+
+	return graphicsNone
+}
+
+// outputGanttImage draws a time-scaled gantt chart inline, followed by a legend of slice bounds
+// since the image itself carries no text.
+func outputGanttImage(w io.Writer, protocol graphicsProtocol, gantt []TimeSlice) {
+	img := rasterizeGantt(gantt)
+	switch protocol {
+	case graphicsKitty:
+		encodeKitty(w, img)
+	case graphicsSixel:
+		encodeSixel(w, img)
+	}
+	_, _ = fmt.Fprintln(w)
+	for i := range gantt {
+		_, _ = fmt.Fprintf(w, "%d:%d-%d ", gantt[i].PID, gantt[i].Start, gantt[i].Stop)
+	}
+	_, _ = fmt.Fprintf(w, "\n\n")
+}
+
+// rasterizeGantt draws one bar per slice with widths proportional to duration.
+// Palette index 0 is the background, 1 the slice border, and the rest one color per PID. Past 254
+// PIDs the colors cycle, so later processes share a color rather than vanish into the background.
+func rasterizeGantt(gantt []TimeSlice) *image.Paletted {
+	const reserved = 2
+	palette := color.Palette{color.White, color.RGBA{0x33, 0x33, 0x33, 0xff}}
+	index := map[int64]uint8{}
+	for i := range gantt {
+		if _, ok := index[gantt[i].PID]; ok {
+			continue
+		}
+		n := len(index)
+		index[gantt[i].PID] = uint8(reserved + n%(256-reserved))
+		if len(palette) < 256 {
+			palette = append(palette, pidRGBA(gantt[i].PID))
+		}
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, termImageWidth, termImageHeight), palette)
+	start, stop := gantt[0].Start, gantt[len(gantt)-1].Stop
+	scale := float64(termImageWidth)
+	if stop > start {
+		scale /= float64(stop - start)
+	}
+	for i := range gantt {
+		left := int(float64(gantt[i].Start-start) * scale)
+		right := int(float64(gantt[i].Stop-start) * scale)
+		for x := left; x < right && x < termImageWidth; x++ {
+			for y := 0; y < termImageHeight; y++ {
+				c := index[gantt[i].PID]
+				if x == left || x == right-1 || y == 0 || y == termImageHeight-1 {
+					c = 1
+				}
+				img.SetColorIndex(x, y, c)
+			}
+		}
+	}
+
+	return img
+}
+
+// pidRGBA converts the PID's hue from pidHue to RGB at the same saturation and lightness
+// used for SVG output.
+func pidRGBA(pid int64) color.RGBA {
+	const s, l = 0.65, 0.60
+	h := float64(pidHue(pid)) / 60
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g, b = c, x, 0
+	case 1:
+		r, g, b = x, c, 0
+	case 2:
+		r, g, b = 0, c, x
+	case 3:
+		r, g, b = 0, x, c
+	case 4:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	m := l - c/2
+	to8 := func(v float64) uint8 { return uint8(math.Round((v + m) * 255)) }
+
+	return color.RGBA{R: to8(r), G: to8(g), B: to8(b), A: 0xff}
+}
+
+// encodeKitty transmits img as PNG using the kitty graphics protocol, split into chunks.
+func encodeKitty(w io.Writer, img image.Image) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return
+	}
+	payload := base64.StdEncoding.EncodeToString(buf.Bytes())
+	for first := true; first || len(payload) > 0; first = false {
+		chunk := payload
+		if len(chunk) > kittyChunkSize {
+			chunk = chunk[:kittyChunkSize]
+		}
+		payload = payload[len(chunk):]
+		more := 0
+		if len(payload) > 0 {
+			more = 1
+		}
+		if first {
+			_, _ = fmt.Fprintf(w, "\x1b_Gf=100,a=T,m=%d;%s\x1b\\", more, chunk)
+		} else {
+			_, _ = fmt.Fprintf(w, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+}
+
+// encodeSixel writes img as a DEC sixel image: bands of six pixel rows, one pass per palette
+// color, run-length encoded.
+func encodeSixel(w io.Writer, img *image.Paletted) {
+	bounds := img.Bounds()
+	_, _ = fmt.Fprintf(w, "\x1bPq\"1;1;%d;%d", bounds.Dx(), bounds.Dy())
+	for i, c := range img.Palette {
+		r, g, b, _ := c.RGBA()
+		_, _ = fmt.Fprintf(w, "#%d;2;%d;%d;%d", i, r*100/0xffff, g*100/0xffff, b*100/0xffff)
+	}
+
+	row := make([]byte, bounds.Dx())
+	for y0 := bounds.Min.Y; y0 < bounds.Max.Y; y0 += 6 {
+		for ci := range img.Palette {
+			used := false
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				var bits byte
+				for dy := 0; dy < 6 && y0+dy < bounds.Max.Y; dy++ {
+					if img.ColorIndexAt(x, y0+dy) == uint8(ci) {
+						bits |= 1 << dy
+					}
+				}
+				row[x-bounds.Min.X] = '?' + bits
+				used = used || bits != 0
+			}
+			if !used {
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "#%d", ci)
+			writeSixelRuns(w, row)
+			_, _ = fmt.Fprint(w, "$")
+		}
+		_, _ = fmt.Fprint(w, "-")
+	}
+	_, _ = fmt.Fprint(w, "\x1b\\")
+}
+
+// writeSixelRuns writes sixel characters, collapsing repeats with the !<count><char> form.
+func writeSixelRuns(w io.Writer, row []byte) {
+	for i := 0; i < len(row); {
+		j := i
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			_, _ = fmt.Fprintf(w, "!%d%c", n, row[i])
+		} else {
+			_, _ = fmt.Fprint(w, strings.Repeat(string(row[i]), n))
+		}
+		i = j
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func Test_graphicsProtocolFor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		term          string
+		termProgram   string
+		kittyWindowID string
+		want          graphicsProtocol
+	}{
+		{name: "kitty window", term: "xterm-256color", kittyWindowID: "1", want: graphicsKitty},
+		{name: "kitty term", term: "xterm-kitty", want: graphicsKitty},
+		{name: "wezterm", term: "xterm-256color", termProgram: "WezTerm", want: graphicsKitty},
+		{name: "foot", term: "foot-extra", want: graphicsSixel},
+		{name: "mlterm", term: "mlterm", want: graphicsSixel},
+		{name: "plain xterm", term: "xterm-256color", want: graphicsNone},
+		{name: "dumb", term: "dumb", want: graphicsNone},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := graphicsProtocolFor(tt.term, tt.termProgram, tt.kittyWindowID); got != tt.want {
+				t.Errorf("graphicsProtocolFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeSixel(t *testing.T) {
+	t.Parallel()
+	img := image.NewPaletted(image.Rect(0, 0, 5, 6), color.Palette{color.White, color.Black})
+	for y := 0; y < 6; y++ {
+		img.SetColorIndex(0, y, 1)
+	}
+
+	var w bytes.Buffer
+	encodeSixel(&w, img)
+
+	want := "\x1bPq\"1;1;5;6#0;2;100;100;100#1;2;0;0;0#0?!4~$#1~!4?$-\x1b\\"
+	if got := w.String(); got != want {
+		t.Errorf("encodeSixel() = %q, want %q", got, want)
+	}
+}
+
+func Test_encodeKitty(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	encodeKitty(&w, rasterizeGantt([]TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 9}}))
+
+	got := w.String()
+	if !strings.HasPrefix(got, "\x1b_Gf=100,a=T,m=") {
+		t.Errorf("encodeKitty() = %q, want a transmit-and-display header", got[:20])
+	}
+	if !strings.HasSuffix(got, "\x1b\\") || strings.Count(got, "m=0;") != 1 {
+		t.Errorf("encodeKitty() does not end with exactly one final chunk")
+	}
+}
+
+func Test_rasterizeGantt_manyPIDs(t *testing.T) {
+	t.Parallel()
+	var gantt []TimeSlice
+	for pid := int64(1); pid < 300; pid++ {
+		gantt = append(gantt, TimeSlice{PID: pid, Start: pid - 1, Stop: pid})
+	}
+	// a long last slice, so its middle is well clear of the borders
+	gantt = append(gantt, TimeSlice{PID: 300, Start: 299, Stop: 599})
+
+	img := rasterizeGantt(gantt)
+	if len(img.Palette) > 256 {
+		t.Errorf("rasterizeGantt() palette has %d colors, want at most 256", len(img.Palette))
+	}
+	if c := img.ColorIndexAt(termImageWidth*3/4, termImageHeight/2); c < 2 {
+		t.Errorf("PID 300 drawn with palette index %d, want a PID color", c)
+	}
+}
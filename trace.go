@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Reasons a time slice ends. The simulator has no I/O model, so no slice ends by blocking.
+const (
+	endCompletion = "completion"
+	endQuantum    = "quantum"
+	endArrival    = "arrival"
+)
+
+// traceEvent is one scheduler decision or process state change. Reason is set on events that end
+// a slice.
+type traceEvent struct {
+	Time   int64
+	Event  string
+	PID    int64
+	Reason string
+}
+
+// traceOrder ranks events that share a timestamp in the order the scheduler handles them: the
+// running slice ends, newcomers arrive, then the next process is dispatched.
+var traceOrder = map[string]int{"preempt": 0, "complete": 0, "arrival": 1, "dispatch": 2}
+
+// sliceEndReasons explains every slice boundary. A process's last slice ends in completion; any
+// other slice was preempted, by an arrival when the next slice belongs to a process arriving at
+// that instant and by quantum expiry otherwise.
+func sliceEndReasons(processes []Process, gantt []TimeSlice) []string {
+	_, last := sliceBounds(len(processes), gantt)
+	arrival := make([]int64, len(processes))
+	for _, p := range processes {
+		arrival[p.ProcessID-1] = p.ArrivalTime
+	}
+
+	reasons := make([]string, len(gantt))
+	for i, s := range gantt {
+		switch {
+		case s.Stop == last[s.PID-1]:
+			reasons[i] = endCompletion
+		case i+1 < len(gantt) && gantt[i+1].PID != s.PID && arrival[gantt[i+1].PID-1] == s.Stop:
+			reasons[i] = endArrival
+		default:
+			reasons[i] = endQuantum
+		}
+	}
+
+	return reasons
+}
+
+// traceEvents derives the event sequence of a schedule: every arrival, every dispatch at a slice
+// start, and at each slice stop either completion or preemption, with the reason it ended.
+func traceEvents(processes []Process, gantt []TimeSlice) []traceEvent {
+	events := make([]traceEvent, 0, len(processes)+2*len(gantt))
+	for _, p := range processes {
+		events = append(events, traceEvent{Time: p.ArrivalTime, Event: "arrival", PID: p.ProcessID})
+	}
+	reasons := sliceEndReasons(processes, gantt)
+	for i, s := range gantt {
+		events = append(events, traceEvent{Time: s.Start, Event: "dispatch", PID: s.PID})
+		end := "preempt"
+		if reasons[i] == endCompletion {
+			end = "complete"
+		}
+		events = append(events, traceEvent{Time: s.Stop, Event: end, PID: s.PID, Reason: reasons[i]})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Time != events[j].Time {
+			return events[i].Time < events[j].Time
+		}
+		return traceOrder[events[i].Event] < traceOrder[events[j].Event]
+	})
+
+	return events
+}
+
+// writeTrace writes the event trace of every result to path as CSV, gzipped as createOutput
+// decides.
+func writeTrace(path string, compress bool, processes []Process, results []Result) error {
+	w, closeFn, err := createOutput(path, compress)
+	if err != nil {
+		return fmt.Errorf("%w: creating trace file", err)
+	}
+	if err := outputTrace(w, processes, results); err != nil {
+		_ = closeFn()
+		return err
+	}
+
+	return closeFn()
+}
+
+// outputTrace renders the event traces as CSV with an algorithm,time,event,pid,reason header.
+func outputTrace(w io.Writer, processes []Process, results []Result) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = crlfFiles
+	_ = cw.Write([]string{"algorithm", "time", "event", "pid", "reason"})
+	for _, r := range results {
+		for _, e := range traceEvents(processes, r.Gantt) {
+			_ = cw.Write([]string{r.Name, fmt.Sprint(e.Time), e.Event, fmt.Sprint(e.PID), e.Reason})
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("%w: writing trace", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_traceEvents(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+	}
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 5}}
+	want := []traceEvent{
+		{Time: 0, Event: "arrival", PID: 1},
+		{Time: 0, Event: "dispatch", PID: 1},
+		{Time: 2, Event: "preempt", PID: 1, Reason: endArrival},
+		{Time: 2, Event: "arrival", PID: 2},
+		{Time: 2, Event: "dispatch", PID: 2},
+		{Time: 3, Event: "complete", PID: 2, Reason: endCompletion},
+		{Time: 3, Event: "dispatch", PID: 1},
+		{Time: 5, Event: "complete", PID: 1, Reason: endCompletion},
+	}
+	if got := traceEvents(processes, gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("traceEvents() = %v, want %v", got, want)
+	}
+}
+
+func Test_sliceEndReasons(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 0, BurstDuration: 1},
+	}
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1},
+		{PID: 2, Start: 1, Stop: 3},
+		{PID: 1, Start: 3, Stop: 5},
+		{PID: 3, Start: 5, Stop: 6},
+		{PID: 1, Start: 6, Stop: 8},
+	}
+	want := []string{endArrival, endCompletion, endQuantum, endCompletion, endCompletion}
+	if got := sliceEndReasons(processes, gantt); !reflect.DeepEqual(got, want) {
+		t.Errorf("sliceEndReasons() = %v, want %v", got, want)
+	}
+}
+
+func Test_outputTrace(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 1, BurstDuration: 2}}
+	var w bytes.Buffer
+	if err := outputTrace(&w, processes, []Result{{Name: "fcfs", Gantt: []TimeSlice{{PID: 1, Start: 1, Stop: 3}}}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "algorithm,time,event,pid,reason\nfcfs,1,arrival,1,\nfcfs,1,dispatch,1,\nfcfs,3,complete,1,completion\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputTrace() = %q, want %q", got, want)
+	}
+}
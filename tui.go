@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const tuiTickInterval = 300 * time.Millisecond
+
+type (
+	tuiTickMsg struct{}
+	// tuiResultKey identifies one simulation of the session's workload: which algorithm, with what
+	// options. The workload never changes within a session, so it is not part of the key.
+	tuiResultKey struct {
+		algo string
+		opts Options
+	}
+	// tuiModel replays one algorithm's schedule tick by tick.
+	tuiModel struct {
+		processes  []Process
+		algorithms []algorithm
+		opts       Options
+		algo       int
+		result     Result
+		// err is why the selected algorithm has no schedule to replay, such as a plugin's
+		// invalid one.
+		err error
+		// results caches every simulation run this session so switching back is instant.
+		results map[tuiResultKey]Result
+		tick    int64
+		end     int64
+		paused  bool
+	}
+)
+
+// runTUI shows the ready queue, running process and growing Gantt chart live.
+func runTUI(processes []Process, algorithms []algorithm, opts Options) error {
+	m := newTUIModel(processes, algorithms, opts)
+	m.selectAlgorithm(0)
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		return fmt.Errorf("%w: running TUI", err)
+	}
+
+	return nil
+}
+
+func newTUIModel(processes []Process, algorithms []algorithm, opts Options) *tuiModel {
+	return &tuiModel{
+		processes:  processes,
+		algorithms: algorithms,
+		opts:       opts,
+		results:    make(map[tuiResultKey]Result),
+	}
+}
+
+// selectAlgorithm switches to algorithm i, simulating it only if this algorithm and options have
+// not been run before, and restarts the replay. A run that fails is shown as its error and not
+// cached.
+func (m *tuiModel) selectAlgorithm(i int) {
+	m.algo = (i + len(m.algorithms)) % len(m.algorithms)
+	a := m.algorithms[m.algo]
+	key := tuiResultKey{algo: a.name, opts: m.opts}
+	result, ok := m.results[key]
+	m.err = nil
+	if !ok {
+		var err error
+		if result, err = runAlgorithmChecked(a, io.Discard, m.processes, m.opts); err != nil {
+			result, m.err = Result{Name: a.name, Title: a.title}, err
+		} else {
+			m.results[key] = result
+		}
+	}
+	m.result = result
+	m.tick, m.end = 0, 0
+	if n := len(m.result.Gantt); n > 0 {
+		m.tick, m.end = m.result.Gantt[0].Start, m.result.Gantt[n-1].Stop
+	}
+}
+
+func tuiTick() tea.Cmd {
+	return tea.Tick(tuiTickInterval, func(time.Time) tea.Msg { return tuiTickMsg{} })
+}
+
+func (m *tuiModel) Init() tea.Cmd {
+	return tuiTick()
+}
+
+func (m *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return m, tea.Quit
+		case " ", "p":
+			m.paused = !m.paused
+		case "s", ".":
+			m.paused = true
+			m.step()
+		case "tab", "right", "n":
+			m.selectAlgorithm(m.algo + 1)
+		case "shift+tab", "left":
+			m.selectAlgorithm(m.algo - 1)
+		case "r":
+			m.selectAlgorithm(m.algo)
+		case "w":
+			m.opts.NonWorkConserving = !m.opts.NonWorkConserving
+			m.selectAlgorithm(m.algo)
+		}
+	case tuiTickMsg:
+		if !m.paused {
+			m.step()
+		}
+		return m, tuiTick()
+	}
+
+	return m, nil
+}
+
+func (m *tuiModel) step() {
+	if m.tick < m.end {
+		m.tick++
+	}
+}
+
+func (m *tuiModel) View() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%s  [%d/%d]", m.result.Title, m.algo+1, len(m.algorithms))
+	if m.opts.NonWorkConserving {
+		b.WriteString("  non-work-conserving")
+	}
+	b.WriteString("\n\n")
+	if m.err != nil {
+		_, _ = fmt.Fprintf(&b, "error: %v\n", m.err)
+	}
+
+	running, ready := tickState(m.processes, m.result.Gantt, m.tick)
+	_, _ = fmt.Fprintf(&b, "t = %d / %d", m.tick, m.end)
+	if m.paused {
+		b.WriteString("  (paused)")
+	}
+	b.WriteString("\nrunning: ")
+	if running == 0 {
+		b.WriteString("idle")
+	} else {
+		_, _ = fmt.Fprintf(&b, "P%d", running)
+	}
+	b.WriteString("\nready:   ")
+	for _, pid := range ready {
+		_, _ = fmt.Fprintf(&b, "P%d ", pid)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(ganttSoFar(m.result.Gantt, m.tick))
+	b.WriteString(consoleText("\n\nspace pause • s step • tab/←/→ switch algorithm • w toggle idling • r restart • q quit\n"))
+
+	return b.String()
+}
+
+// tickState reports which process runs during tick t and which arrived processes are waiting.
+// A PID of 0 means the CPU is idle.
+func tickState(processes []Process, gantt []TimeSlice, t int64) (running int64, ready []int64) {
+	finished := make(map[int64]int64, len(processes))
+	for _, s := range gantt {
+		if s.Start <= t && t < s.Stop {
+			running = s.PID
+		}
+		if s.Stop > finished[s.PID] {
+			finished[s.PID] = s.Stop
+		}
+	}
+	for _, p := range processes {
+		if p.ArrivalTime <= t && t < finished[p.ProcessID] && p.ProcessID != running {
+			ready = append(ready, p.ProcessID)
+		}
+	}
+
+	return running, ready
+}
+
+// ganttSoFar draws the slices that have started by tick t, two columns per tick.
+func ganttSoFar(gantt []TimeSlice, t int64) string {
+	var bar, axis strings.Builder
+	for _, s := range gantt {
+		if s.Start >= t {
+			break
+		}
+		stop := s.Stop
+		if stop > t {
+			stop = t
+		}
+		width := int(stop-s.Start) * 2
+		label := fmt.Sprint(s.PID)
+		if len(label) > width-1 {
+			label = ""
+		}
+		bar.WriteString("|" + label + strings.Repeat(" ", width-1-len(label)))
+		start := fmt.Sprint(s.Start)
+		axis.WriteString(start + strings.Repeat(" ", max(width-len(start), 1)))
+	}
+	bar.WriteString("|")
+	axis.WriteString(fmt.Sprint(t))
+
+	return bar.String() + "\n" + axis.String()
+}
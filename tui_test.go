@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_tickState(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6},
+	}
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}, {PID: 3, Start: 14, Stop: 20}}
+	tests := []struct {
+		name        string
+		tick        int64
+		wantRunning int64
+		wantReady   []int64
+	}{
+		{name: "start", tick: 0, wantRunning: 1},
+		{name: "second arrival", tick: 3, wantRunning: 1, wantReady: []int64{2}},
+		{name: "third arrival", tick: 6, wantRunning: 2, wantReady: []int64{3}},
+		{name: "last", tick: 19, wantRunning: 3},
+		{name: "done", tick: 20},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			running, ready := tickState(processes, gantt, tt.tick)
+			if running != tt.wantRunning {
+				t.Errorf("tickState() running = %v, want %v", running, tt.wantRunning)
+			}
+			if !reflect.DeepEqual(ready, tt.wantReady) {
+				t.Errorf("tickState() ready = %v, want %v", ready, tt.wantReady)
+			}
+		})
+	}
+}
+
+func Test_ganttSoFar(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 6}}
+	want := "|1  |2  |\n0   2   4"
+	if got := ganttSoFar(gantt, 4); got != want {
+		t.Errorf("ganttSoFar() = %q, want %q", got, want)
+	}
+}
+
+func Test_tuiModel_selectAlgorithm_cache(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	}
+	m := newTUIModel(processes, []algorithm{*findAlgorithm("fcfs"), *findAlgorithm("sjf")}, Options{Lookahead: -1})
+	m.selectAlgorithm(0)
+	m.selectAlgorithm(1)
+	m.selectAlgorithm(0)
+	if len(m.results) != 2 {
+		t.Errorf("cached %d results after revisiting fcfs, want 2", len(m.results))
+	}
+
+	m.opts.NonWorkConserving = true
+	m.selectAlgorithm(1)
+	if len(m.results) != 3 {
+		t.Errorf("cached %d results after changing options, want 3", len(m.results))
+	}
+	if m.result.AveWait != 1 {
+		t.Errorf("non-work-conserving sjf average wait = %v, want 1", m.result.AveWait)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidWorkload is wrapped by every error validateProcesses returns.
+var ErrInvalidWorkload = errors.New("invalid workload")
+
+// workloadError pins a validation failure to the row of the offending process.
+type workloadError struct {
+	Row    int
+	Reason string
+}
+
+func (e *workloadError) Error() string {
+	return fmt.Sprintf("%v: row %d: %s", ErrInvalidWorkload, e.Row, e.Reason)
+}
+
+func (e *workloadError) Unwrap() error { return ErrInvalidWorkload }
+
+// validateProcesses rejects workloads the schedulers cannot run: negative or zero bursts, negative
+// arrivals, priorities, memory or weights, deadlines that are not after arrival, process IDs that
+// are duplicated or fall outside 1..n, since the schedule tables are indexed by ID, and
+// dependencies on unknown processes or in a cycle.
+func validateProcesses(processes []Process) error {
+	return validateLines(processes, nil)
+}
+
+// validateLines is validateProcesses for a workload read from a file, reporting lines[i] as the
+// row of processes[i]; a nil lines reports positions instead.
+func validateLines(processes []Process, lines []int) error {
+	var v workloadValidator
+	for i, p := range processes {
+		row := i + 1
+		if lines != nil {
+			row = lines[i]
+		}
+		if err := v.add(p, row); err != nil {
+			return err
+		}
+	}
+
+	return v.finish()
+}
+
+// denseIDs bounds the IDs a workloadValidator tracks in a slice; larger ones, which only a
+// workload of that many processes could use, go in a map so a stray huge ID cannot exhaust memory.
+const denseIDs = 1 << 24
+
+// workloadValidator applies validateProcesses' rules one process at a time, so a workload can be
+// checked while it streams in. IDs beyond the workload's size can only be caught by finish.
+type workloadValidator struct {
+	dense  []int // row of ID i+1, or 0 when unseen
+	sparse map[int64]int
+	count  int
+	maxID  int64
+	maxRow int
+	// depends holds the processes that depend on others, checked by finish once all IDs are known
+	depends []dependent
+}
+
+// dependent is a process with predecessors and the row it was found on.
+type dependent struct {
+	row     int
+	pid     int64
+	depends []int64
+}
+
+// rowOf returns the row an ID was first seen on, or 0.
+func (v *workloadValidator) rowOf(id int64) int {
+	if id <= int64(len(v.dense)) {
+		return v.dense[id-1]
+	}
+
+	return v.sparse[id]
+}
+
+// add checks p, found on row, against itself and the processes added before it.
+func (v *workloadValidator) add(p Process, row int) error {
+	switch first := v.rowOf(max(p.ProcessID, 1)); {
+	case p.ProcessID < 1:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process ID %d is below 1 (IDs must be contiguous from 1)", p.ProcessID)}
+	case first != 0:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("duplicate process ID %d (first on row %d)", p.ProcessID, first)}
+	case p.BurstDuration <= 0:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process %d has burst %d, want at least 1", p.ProcessID, p.BurstDuration)}
+	case p.ArrivalTime < 0:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process %d has negative arrival %d", p.ProcessID, p.ArrivalTime)}
+	case p.Priority < 0:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process %d has negative priority %d", p.ProcessID, p.Priority)}
+	case p.Deadline != 0 && p.Deadline <= p.ArrivalTime:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process %d has deadline %d, not after its arrival %d", p.ProcessID, p.Deadline, p.ArrivalTime)}
+	case p.Memory < 0:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process %d has negative memory %d", p.ProcessID, p.Memory)}
+	case p.Weight < 0:
+		return &workloadError{Row: row, Reason: fmt.Sprintf("process %d has negative weight %d", p.ProcessID, p.Weight)}
+	}
+	switch {
+	case p.ProcessID <= int64(len(v.dense)):
+		v.dense[p.ProcessID-1] = row
+	case p.ProcessID <= denseIDs:
+		v.dense = append(v.dense, make([]int, int(p.ProcessID)-len(v.dense))...)
+		v.dense[p.ProcessID-1] = row
+	default:
+		if v.sparse == nil {
+			v.sparse = make(map[int64]int)
+		}
+		v.sparse[p.ProcessID] = row
+	}
+	v.count++
+	if len(p.Depends) > 0 {
+		v.depends = append(v.depends, dependent{row: row, pid: p.ProcessID, depends: p.Depends})
+	}
+	if p.ProcessID > v.maxID {
+		v.maxID, v.maxRow = p.ProcessID, row
+	}
+
+	return nil
+}
+
+// finish checks the workload as a whole once every process has been added.
+func (v *workloadValidator) finish() error {
+	n := v.count
+	switch {
+	case n == 0:
+		return fmt.Errorf("%w: workload has no processes", ErrInvalidWorkload)
+	case v.maxID > int64(n):
+		return &workloadError{Row: v.maxRow, Reason: fmt.Sprintf("process ID %d outside 1..%d (IDs must be contiguous)", v.maxID, n)}
+	}
+
+	return v.checkDependencies()
+}
+
+// checkDependencies rejects a dependency on a process outside the workload or on itself, and
+// any cycle, which would leave its processes waiting forever.
+func (v *workloadValidator) checkDependencies() error {
+	edges := make(map[int64][]int64, len(v.depends))
+	rows := make(map[int64]int, len(v.depends))
+	for _, d := range v.depends {
+		for _, pid := range d.depends {
+			switch {
+			case pid < 1 || pid > int64(v.count):
+				return &workloadError{Row: d.row, Reason: fmt.Sprintf("process %d depends on unknown process %d", d.pid, pid)}
+			case pid == d.pid:
+				return &workloadError{Row: d.row, Reason: fmt.Sprintf("process %d depends on itself", d.pid)}
+			}
+		}
+		edges[d.pid], rows[d.pid] = d.depends, d.row
+	}
+	// depth-first search, where a process met again while still on the stack closes a cycle
+	const (
+		unvisited = iota
+		onStack
+		finished
+	)
+	state := make(map[int64]int, len(edges))
+	var visit func(pid int64) int64
+	visit = func(pid int64) int64 {
+		state[pid] = onStack
+		for _, dep := range edges[pid] {
+			switch state[dep] {
+			case onStack:
+				return pid
+			case unvisited:
+				if in := visit(dep); in != 0 {
+					return in
+				}
+			}
+		}
+		state[pid] = finished
+		return 0
+	}
+	for _, d := range v.depends {
+		if state[d.pid] != unvisited {
+			continue
+		}
+		if in := visit(d.pid); in != 0 {
+			return &workloadError{Row: rows[in], Reason: fmt.Sprintf("process %d is in a dependency cycle", in)}
+		}
+	}
+
+	return nil
+}
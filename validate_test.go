@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_validateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   string
+	}{
+		{
+			name: "valid out of order",
+			processes: []Process{
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+				{ProcessID: 1, ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+			},
+		},
+		{name: "empty", wantErr: "invalid workload: workload has no processes"},
+		{
+			name: "duplicate ID",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 1, BurstDuration: 1},
+			},
+			wantErr: "invalid workload: row 2: duplicate process ID 1 (first on row 1)",
+		},
+		{
+			name: "non-contiguous IDs",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 5, BurstDuration: 1},
+			},
+			wantErr: "invalid workload: row 2: process ID 5 outside 1..2 (IDs must be contiguous)",
+		},
+		{
+			name:      "zero burst",
+			processes: []Process{{ProcessID: 1}},
+			wantErr:   "invalid workload: row 1: process 1 has burst 0, want at least 1",
+		},
+		{
+			name:      "negative arrival",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, ArrivalTime: -2}},
+			wantErr:   "invalid workload: row 1: process 1 has negative arrival -2",
+		},
+		{
+			name:      "negative priority",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, Priority: -1}},
+			wantErr:   "invalid workload: row 1: process 1 has negative priority -1",
+		},
+		{
+			name:      "negative memory",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, Memory: -4}},
+			wantErr:   "invalid workload: row 1: process 1 has negative memory -4",
+		},
+		{
+			name:      "negative weight",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, Weight: -2}},
+			wantErr:   "invalid workload: row 1: process 1 has negative weight -2",
+		},
+		{
+			name:      "unknown dependency",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 2, BurstDuration: 1, Depends: []int64{3}}},
+			wantErr:   "invalid workload: row 2: process 2 depends on unknown process 3",
+		},
+		{
+			name:      "self dependency",
+			processes: []Process{{ProcessID: 1, BurstDuration: 1, Depends: []int64{1}}},
+			wantErr:   "invalid workload: row 1: process 1 depends on itself",
+		},
+		{
+			name: "dependency cycle",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1},
+				{ProcessID: 2, BurstDuration: 1, Depends: []int64{1, 4}},
+				{ProcessID: 3, BurstDuration: 1, Depends: []int64{2}},
+				{ProcessID: 4, BurstDuration: 1, Depends: []int64{3}},
+			},
+			wantErr: "invalid workload: row 3: process 3 is in a dependency cycle",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateProcesses(tt.processes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateProcesses() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr || !errors.Is(err, ErrInvalidWorkload) {
+				t.Errorf("validateProcesses() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dispatchRules say why each built-in algorithm picks the process it dispatches; other
+// algorithms' dispatches are logged without a rule.
+var dispatchRules = map[string]string{
+	"fcfs":          "earliest arrival",
+	"sjf":           "shortest burst",
+	"sjf-predicted": "shortest predicted burst",
+	"sjf-priority":  "highest priority, then shortest remaining",
+	"rr":            "head of the queue",
+	"mlq":           "foreground queue first, round-robin within it",
+	"wrr":           "head of the queue, for a quantum scaled by its weight",
+	"drr":           "first in the queue whose deficit covers its remaining burst",
+}
+
+// outputDecisions logs every decision in the schedule gantt of algorithm name, one line per
+// event: dispatches with the ready processes they were chosen from, preemptions with what
+// replaced the running process, completions, and idle time.
+func outputDecisions(w io.Writer, name string, processes []Process, gantt []TimeSlice) {
+	_, _ = fmt.Fprintf(w, "%s decisions:\n", name)
+	remaining := make([]int64, len(processes))
+	byPID := make([]Process, len(processes))
+	for _, p := range processes {
+		remaining[p.ProcessID-1] = p.BurstDuration
+		byPID[p.ProcessID-1] = p
+	}
+	reasons := sliceEndReasons(processes, gantt)
+	var clock int64
+	for i, s := range gantt {
+		switch {
+		case s.Start > clock && byPID[s.PID-1].ArrivalTime == s.Start:
+			_, _ = fmt.Fprintf(w, "t=%d: idle until P%d arrives at %d\n", clock, s.PID, s.Start)
+		case s.Start > clock:
+			_, _ = fmt.Fprintf(w, "t=%d: idle until %d\n", clock, s.Start)
+		}
+		var ready []string
+		for _, q := range processes {
+			if q.ArrivalTime <= s.Start && remaining[q.ProcessID-1] > 0 {
+				ready = append(ready, fmt.Sprintf("P%d", q.ProcessID))
+			}
+		}
+		line := fmt.Sprintf("t=%d: dispatch P%d (remaining %d, priority %d) from ready %s", s.Start, s.PID, remaining[s.PID-1], byPID[s.PID-1].Priority, strings.Join(ready, ", "))
+		if rule, ok := dispatchRules[name]; ok {
+			line += ": " + rule
+		}
+		_, _ = fmt.Fprintln(w, line)
+
+		remaining[s.PID-1] -= s.Stop - s.Start
+		switch reasons[i] {
+		case endCompletion:
+			_, _ = fmt.Fprintf(w, "t=%d: P%d completes\n", s.Stop, s.PID)
+		case endArrival:
+			next := gantt[i+1].PID
+			_, _ = fmt.Fprintf(w, "t=%d: preempt P%d (remaining %d) for P%d (burst %d)\n",
+				s.Stop, s.PID, remaining[s.PID-1], next, byPID[next-1].BurstDuration)
+		default:
+			_, _ = fmt.Fprintf(w, "t=%d: quantum expires for P%d (remaining %d)\n", s.Stop, s.PID, remaining[s.PID-1])
+		}
+		clock = s.Stop
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputDecisions(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 8, BurstDuration: 1, Priority: 1},
+	}
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 5}, {PID: 3, Start: 8, Stop: 9},
+	}
+	want := `sjf-priority decisions:
+t=0: dispatch P1 (remaining 4, priority 2) from ready P1: highest priority, then shortest remaining
+t=1: preempt P1 (remaining 3) for P2 (burst 1)
+t=1: dispatch P2 (remaining 1, priority 1) from ready P1, P2: highest priority, then shortest remaining
+t=2: P2 completes
+t=2: dispatch P1 (remaining 3, priority 2) from ready P1: highest priority, then shortest remaining
+t=5: P1 completes
+t=5: idle until P3 arrives at 8
+t=8: dispatch P3 (remaining 1, priority 1) from ready P3: highest priority, then shortest remaining
+t=9: P3 completes
+`
+	var out bytes.Buffer
+	outputDecisions(&out, "sjf-priority", processes, gantt)
+	if out.String() != want {
+		t.Errorf("outputDecisions() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import "fmt"
+
+// ProcessChange is a what-if edit to one process: its burst and arrival shift by the deltas.
+type ProcessChange struct {
+	PID          int64
+	BurstDelta   int64
+	ArrivalDelta int64
+}
+
+// WhatIf returns result as it would be had change been made to processes before scheduling,
+// replaying only the part of the schedule the change can affect instead of rerunning it all. The
+// slices dispatched before the changed process could be seen keep their timing and schedule rows;
+// the rest are rescheduled. It supports the non-preemptive, work-conserving policies fcfs and sjf,
+// and result must come from runAlgorithm over processes with default options.
+func WhatIf(processes []Process, result Result, change ProcessChange) (Result, error) {
+	if change.PID < 1 || change.PID > int64(len(processes)) {
+		return Result{}, fmt.Errorf("%w: what-if PID %d outside 1..%d", ErrInvalidArgs, change.PID, len(processes))
+	}
+	changed := make([]Process, len(processes))
+	copy(changed, processes)
+	at := -1
+	for i := range changed {
+		if changed[i].ProcessID == change.PID {
+			at = i
+			changed[i].BurstDuration += change.BurstDelta
+			changed[i].ArrivalTime += change.ArrivalDelta
+		}
+	}
+	if err := validateProcesses(changed); err != nil {
+		return Result{}, fmt.Errorf("%w: what-if change to PID %d", err, change.PID)
+	}
+
+	var keep int
+	var replay func(remaining []Process, clock int64) []TimeSlice
+	switch result.Name {
+	case "fcfs":
+		// FCFS runs in workload order, so everything before the changed process stands
+		keep = at
+		replay = replayFCFS
+	case "sjf":
+		// decisions made before the process arrived, at either arrival, never saw it
+		visible := min(processes[at].ArrivalTime, changed[at].ArrivalTime)
+		for keep < len(result.Gantt) && result.Gantt[keep].Start < visible {
+			keep++
+		}
+		replay = replaySJF
+	default:
+		return Result{}, fmt.Errorf("%w: what-if supports fcfs and sjf, not %q", ErrInvalidArgs, result.Name)
+	}
+
+	var clock int64
+	kept := make(map[int64]bool, keep)
+	for _, s := range result.Gantt[:keep] {
+		kept[s.PID] = true
+		clock = s.Stop
+	}
+	remaining := make([]Process, 0, len(changed)-keep)
+	for _, p := range changed {
+		if !kept[p.ProcessID] {
+			remaining = append(remaining, p)
+		}
+	}
+	gantt := append(append(make([]TimeSlice, 0, len(result.Gantt)), result.Gantt[:keep]...), replay(remaining, clock)...)
+
+	// only the replayed processes' rows and their share of the averages change
+	n := float64(len(processes))
+	old := processTimesFromGantt(processes, result.Gantt)
+	updated := result
+	updated.Gantt = gantt
+	updated.Schedule = make([][]string, len(result.Schedule))
+	copy(updated.Schedule, result.Schedule)
+	_, last := sliceBounds(len(changed), gantt)
+	for i, p := range changed {
+		if kept[p.ProcessID] {
+			continue
+		}
+		exit := last[p.ProcessID-1]
+		turnaround := exit - p.ArrivalTime
+		wait := turnaround - p.BurstDuration
+		updated.AveWait += (float64(wait) - old.wait[i]) / n
+		updated.AveTurnaround += (float64(turnaround) - old.turnaround[i]) / n
+		row := scheduleRow(result.Schedule, p.ProcessID, i)
+		updated.Schedule[row] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Priority),
+			fmt.Sprint(p.BurstDuration),
+			fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(wait),
+			fmt.Sprint(wait), // non-preemptive, so response equals wait
+			fmt.Sprint(turnaround),
+			fmt.Sprint(exit),
+		}
+	}
+	updated.AveThroughput = n / float64(gantt[len(gantt)-1].Stop)
+	deriveMetrics(&updated, changed)
+
+	return updated, nil
+}
+
+// scheduleRow finds the schedule row of pid, falling back to the workload position i.
+func scheduleRow(schedule [][]string, pid int64, i int) int {
+	id := fmt.Sprint(pid)
+	if i < len(schedule) && schedule[i] != nil && schedule[i][0] == id {
+		return i
+	}
+	for j, row := range schedule {
+		if row != nil && row[0] == id {
+			return j
+		}
+	}
+
+	return i
+}
+
+// replayFCFS runs remaining in order from clock, as FCFSSchedule does.
+func replayFCFS(remaining []Process, clock int64) []TimeSlice {
+	gantt := make([]TimeSlice, 0, len(remaining))
+	for _, p := range remaining {
+		clock = max(clock, p.ArrivalTime)
+		gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: clock, Stop: clock + p.BurstDuration})
+		clock += p.BurstDuration
+	}
+
+	return gantt
+}
+
+// replaySJF runs remaining from clock as sjfSchedule does: the shortest arrived burst first, ties in
+// workload order, idling to the next arrival when nothing is ready.
+func replaySJF(remaining []Process, clock int64) []TimeSlice {
+	gantt := make([]TimeSlice, 0, len(remaining))
+	done := make([]bool, len(remaining))
+	for range remaining {
+		next, earliest := -1, -1
+		for i, p := range remaining {
+			switch {
+			case done[i]:
+			case p.ArrivalTime > clock:
+				if earliest == -1 || p.ArrivalTime < remaining[earliest].ArrivalTime {
+					earliest = i
+				}
+			case next == -1 || p.BurstDuration < remaining[next].BurstDuration:
+				next = i
+			}
+		}
+		if next == -1 {
+			// wait for the next process to arrive; the earliest arrival, shortest burst on ties
+			clock = remaining[earliest].ArrivalTime
+			for i, p := range remaining {
+				if !done[i] && p.ArrivalTime <= clock && (next == -1 || p.BurstDuration < remaining[next].BurstDuration) {
+					next = i
+				}
+			}
+		}
+		p := remaining[next]
+		done[next] = true
+		gantt = append(gantt, TimeSlice{PID: p.ProcessID, Start: clock, Stop: clock + p.BurstDuration})
+		clock += p.BurstDuration
+	}
+
+	return gantt
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestWhatIf(t *testing.T) {
+	t.Parallel()
+	rng := rand.New(rand.NewSource(7))
+	for _, name := range []string{"fcfs", "sjf"} {
+		a := *findAlgorithm(name)
+		for trial := 0; trial < 200; trial++ {
+			processes := generateWorkload(rng, generatorConfig{
+				Count: 12, ArrivalRate: 0.3, MinBurst: 1, MaxBurst: 6, BatchSize: 1 + trial%3,
+			})
+			change := ProcessChange{
+				PID:          1 + rng.Int63n(int64(len(processes))),
+				BurstDelta:   rng.Int63n(5) - 2,
+				ArrivalDelta: rng.Int63n(5) - 2,
+			}
+			changed := make([]Process, len(processes))
+			copy(changed, processes)
+			changed[change.PID-1].BurstDuration += change.BurstDelta
+			changed[change.PID-1].ArrivalTime += change.ArrivalDelta
+			if validateProcesses(changed) != nil {
+				continue
+			}
+
+			got, err := WhatIf(processes, runAlgorithm(a, io.Discard, processes, Options{}), change)
+			if err != nil {
+				t.Fatalf("%s: WhatIf(%+v) error = %v", name, change, err)
+			}
+			want := runAlgorithm(a, io.Discard, changed, Options{})
+			if !reflect.DeepEqual(got.Gantt, want.Gantt) || !reflect.DeepEqual(got.Schedule, want.Schedule) ||
+				math.Abs(got.AveWait-want.AveWait) > 1e-9 || math.Abs(got.AveTurnaround-want.AveTurnaround) > 1e-9 ||
+				got.AveThroughput != want.AveThroughput || got.ResponseStats != want.ResponseStats {
+				t.Fatalf("%s: WhatIf(%v, %+v) =\n%+v\nwant a full rerun's\n%+v", name, processes, change, got, want)
+			}
+		}
+	}
+}
+
+func TestWhatIf_errors(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 3}}
+	tests := []struct {
+		name    string
+		algo    string
+		change  ProcessChange
+		wantErr error
+	}{
+		{name: "preemptive policy", algo: "rr", change: ProcessChange{PID: 1, BurstDelta: 1}, wantErr: ErrInvalidArgs},
+		{name: "unknown pid", algo: "fcfs", change: ProcessChange{PID: 3}, wantErr: ErrInvalidArgs},
+		{name: "burst below one", algo: "fcfs", change: ProcessChange{PID: 1, BurstDelta: -2}, wantErr: ErrInvalidWorkload},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := runAlgorithm(*findAlgorithm(tt.algo), io.Discard, processes, Options{})
+			if _, err := WhatIf(processes, result, tt.change); !errors.Is(err, tt.wantErr) {
+				t.Errorf("WhatIf() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
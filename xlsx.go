@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// comparisonSheet is the first sheet of an -xlsx workbook.
+const comparisonSheet = "Comparison"
+
+// comparisonColumns head the comparison sheet, one row per algorithm.
+var comparisonColumns = []string{"Algorithm", "Avg wait", "Avg turnaround", "Avg response", "Throughput", "Switches", "Idle", "Utilization %", "Unfinished", "Fairness"}
+
+// writeXLSX writes an Excel workbook of results to path: a comparison sheet of every algorithm's
+// averages with charts of them, then a sheet per algorithm with its schedule and gantt slices.
+// Numbers are stored as numbers, so the sheets can be sorted, filtered and computed on.
+func writeXLSX(path string, results []Result) error {
+	f := excelize.NewFile()
+	defer func() { _ = f.Close() }()
+	bold, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return fmt.Errorf("%w: creating workbook style", err)
+	}
+	if err := f.SetSheetName("Sheet1", comparisonSheet); err != nil {
+		return fmt.Errorf("%w: naming comparison sheet", err)
+	}
+	if err := writeComparisonSheet(f, bold, results); err != nil {
+		return err
+	}
+	names := map[string]bool{strings.ToLower(comparisonSheet): true}
+	for _, r := range results {
+		name := xlsxSheetName(r.Name, names)
+		if _, err := f.NewSheet(name); err != nil {
+			return fmt.Errorf("%w: adding sheet %s", err, name)
+		}
+		if err := writeAlgorithmSheet(f, name, bold, r); err != nil {
+			return err
+		}
+	}
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("%w: writing workbook", err)
+	}
+
+	return nil
+}
+
+func writeComparisonSheet(f *excelize.File, bold int, results []Result) error {
+	rows := [][]any{toAny(comparisonColumns)}
+	for _, r := range results {
+		rows = append(rows, []any{r.Name, r.AveWait, r.AveTurnaround, r.AveResponse, r.AveThroughput, r.ContextSwitches, r.IdleTime, r.Utilization, r.Unfinished, r.Fairness})
+	}
+	if err := setRows(f, comparisonSheet, 1, rows); err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(comparisonSheet, "A1", cell(len(comparisonColumns), 1), bold); err != nil {
+		return fmt.Errorf("%w: styling comparison sheet", err)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+
+	// one column chart of the average times and one of the context switches, beside the table
+	last := len(results) + 1
+	series := func(col int) excelize.ChartSeries {
+		name, _ := excelize.ColumnNumberToName(col)
+		return excelize.ChartSeries{
+			Name:       fmt.Sprintf("'%s'!$%s$1", comparisonSheet, name),
+			Categories: fmt.Sprintf("'%s'!$A$2:$A$%d", comparisonSheet, last),
+			Values:     fmt.Sprintf("'%s'!$%s$2:$%s$%d", comparisonSheet, name, name, last),
+		}
+	}
+	charts := []struct {
+		at      string
+		title   string
+		columns []int
+	}{
+		{at: cell(len(comparisonColumns)+2, 1), title: "Average times", columns: []int{2, 3, 4}},
+		{at: cell(len(comparisonColumns)+2, 17), title: "Context switches", columns: []int{6}},
+	}
+	for _, c := range charts {
+		chart := &excelize.Chart{
+			Type:   excelize.Col,
+			Title:  excelize.ChartTitle{Paragraph: []excelize.RichTextRun{{Text: c.title}}},
+			Legend: excelize.ChartLegend{Position: "bottom"},
+		}
+		for _, col := range c.columns {
+			chart.Series = append(chart.Series, series(col))
+		}
+		if err := f.AddChart(comparisonSheet, c.at, chart); err != nil {
+			return fmt.Errorf("%w: adding %s chart", err, c.title)
+		}
+	}
+
+	return nil
+}
+
+// writeAlgorithmSheet lays out r's schedule table and averages on the left of sheet and its gantt
+// slices on the right.
+func writeAlgorithmSheet(f *excelize.File, sheet string, bold int, r Result) error {
+	rows := [][]any{{r.Title}, toAny(scheduleHeader)}
+	for _, row := range r.Schedule {
+		cells := make([]any, len(row))
+		for i, c := range row {
+			cells[i] = xlsxValue(c)
+		}
+		rows = append(rows, cells)
+	}
+	rows = append(rows, nil,
+		[]any{"Avg wait", r.AveWait},
+		[]any{"Avg turnaround", r.AveTurnaround},
+		[]any{"Avg response", r.AveResponse},
+		[]any{"Throughput", r.AveThroughput},
+	)
+	if err := setRows(f, sheet, 1, rows); err != nil {
+		return err
+	}
+
+	gantt := [][]any{{"Gantt"}, {"PID", "Start", "Stop", "Reason"}}
+	for _, s := range r.Gantt {
+		gantt = append(gantt, []any{s.PID, s.Start, s.Stop, s.Reason})
+	}
+	ganttCol := len(scheduleHeader) + 2
+	for i, row := range gantt {
+		if err := f.SetSheetRow(sheet, cell(ganttCol, i+1), &row); err != nil {
+			return fmt.Errorf("%w: writing %s gantt", err, sheet)
+		}
+	}
+
+	for _, span := range [][2]string{{"A1", "A1"}, {"A2", cell(len(scheduleHeader), 2)}, {cell(ganttCol, 1), cell(ganttCol+3, 2)}} {
+		if err := f.SetCellStyle(sheet, span[0], span[1], bold); err != nil {
+			return fmt.Errorf("%w: styling %s", err, sheet)
+		}
+	}
+
+	return nil
+}
+
+// setRows writes rows to sheet from row first down.
+func setRows(f *excelize.File, sheet string, first int, rows [][]any) error {
+	for i, row := range rows {
+		if err := f.SetSheetRow(sheet, cell(1, first+i), &row); err != nil {
+			return fmt.Errorf("%w: writing sheet %s", err, sheet)
+		}
+	}
+
+	return nil
+}
+
+// cell names the cell at a 1-based column and row, e.g. cell(2, 3) is B3.
+func cell(col, row int) string {
+	name, _ := excelize.CoordinatesToCellName(col, row)
+	return name
+}
+
+func toAny(values []string) []any {
+	cells := make([]any, len(values))
+	for i, v := range values {
+		cells[i] = v
+	}
+
+	return cells
+}
+
+// xlsxValue stores a schedule cell as a number when it is one, so Excel does not flag it as text.
+func xlsxValue(s string) any {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+
+	return s
+}
+
+// xlsxSheetName makes an algorithm name a valid sheet name not in taken, which it then joins:
+// Excel forbids : \ / ? * [ and ] and allows 31 characters.
+func xlsxSheetName(name string, taken map[string]bool) string {
+	const maxLen = 31
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	base := []rune(name)
+	if len(base) > maxLen {
+		base = base[:maxLen]
+	}
+	name = string(base)
+	// sheet names are compared ignoring case
+	for i := 2; taken[strings.ToLower(name)] || name == ""; i++ {
+		suffix := fmt.Sprintf("~%d", i)
+		name = string(base[:min(len(base), maxLen-len(suffix))]) + suffix
+	}
+	taken[strings.ToLower(name)] = true
+
+	return name
+}
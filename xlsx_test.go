@@ -0,0 +1,83 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func Test_writeXLSX(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	}
+	var results []Result
+	for _, name := range []string{"fcfs", "rr"} {
+		results = append(results, runAlgorithm(*findAlgorithm(name), io.Discard, processes, Options{Quantum: 3}))
+	}
+
+	path := filepath.Join(t.TempDir(), "report.xlsx")
+	if err := writeXLSX(path, results); err != nil {
+		t.Fatal(err)
+	}
+	f, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if got, want := f.GetSheetList(), []string{comparisonSheet, results[0].Name, results[1].Name}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sheets = %v, want %v", got, want)
+	}
+	wait, err := f.GetCellValue(comparisonSheet, "B3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := strconv.ParseFloat(wait, 64); err != nil || got != results[1].AveWait {
+		t.Errorf("comparison B3 = %q, want %v", wait, results[1].AveWait)
+	}
+	// schedule cells are stored as numbers rather than text
+	typ, err := f.GetCellType(results[0].Name, "A3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ == excelize.CellTypeSharedString || typ == excelize.CellTypeInlineString {
+		t.Errorf("%s A3 has type %v, want a number", results[0].Name, typ)
+	}
+	rows, err := f.GetRows(results[1].Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// title, header, one row per process, then a blank row and four averages
+	if len(rows) < len(results[1].Gantt)+2 || len(rows) < len(processes)+7 {
+		t.Errorf("%s has %d rows, want room for %d processes and %d slices", results[1].Name, len(rows), len(processes), len(results[1].Gantt))
+	}
+}
+
+func Test_xlsxSheetName(t *testing.T) {
+	t.Parallel()
+	taken := map[string]bool{"comparison": true}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "fcfs", want: "fcfs"},
+		{name: "FCFS", want: "FCFS~2"},
+		{name: "comparison", want: "comparison~2"},
+		{name: "mlfq[a/b]", want: "mlfq_a_b_"},
+		{name: "a-very-long-plugin-scheduler-name", want: "a-very-long-plugin-scheduler-na"},
+		{name: "a-very-long-plugin-scheduler-nap", want: "a-very-long-plugin-scheduler-~2"},
+		{name: "", want: "~2"},
+	}
+	// run in order, as each name is taken for the next
+	for _, tt := range tests {
+		if got := xlsxSheetName(tt.name, taken); got != tt.want {
+			t.Errorf("xlsxSheetName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}